@@ -0,0 +1,331 @@
+package analytics
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/aaryareddy/log_cli/internal/database"
+)
+
+// significanceZ is the z-score a change must exceed to be reported as an insight (~95%)
+const significanceZ = 1.96
+
+// PeriodSnapshot holds the comparable metrics for one period
+type PeriodSnapshot struct {
+	Label        string
+	Entries      int
+	DaysLogged   int
+	Momentum     *MomentumStats
+	TagCounts    map[string]int // Context tags
+	FlagCounts   map[string]int
+	DriftCount   int
+	SignoffDays  int // Days completed with sign-off
+	CalendarDays int
+}
+
+// SnapshotPeriod computes the comparable metrics for a period's entries and days
+func SnapshotPeriod(label string, entries []*database.Entry, days []*database.Day, calendarDays int) *PeriodSnapshot {
+	snap := &PeriodSnapshot{
+		Label:        label,
+		Entries:      len(entries),
+		DaysLogged:   countDaysLogged(entries),
+		Momentum:     CalculateMomentumStats(entries),
+		TagCounts:    CountContextTags(entries),
+		FlagCounts:   make(map[string]int),
+		DriftCount:   BuildDriftReport(DetectGaps(entries, nil, DefaultDriftRules())).DriftCount,
+		CalendarDays: calendarDays,
+	}
+
+	for _, entry := range entries {
+		for _, tag := range entry.Tags {
+			if tag.TagType == "flag" {
+				snap.FlagCounts[tag.TagValue]++
+			}
+		}
+	}
+
+	for _, day := range days {
+		if day.Completed {
+			snap.SignoffDays++
+		}
+	}
+
+	return snap
+}
+
+// marked returns the number of entries with a momentum marker
+func (s *PeriodSnapshot) marked() int {
+	return s.Momentum.UpCount + s.Momentum.NeutralCount + s.Momentum.DownCount + s.Momentum.BackCount
+}
+
+// totalTags returns the number of context tags logged
+func (s *PeriodSnapshot) totalTags() int {
+	total := 0
+	for _, c := range s.TagCounts {
+		total += c
+	}
+	return total
+}
+
+// Delta is the change of a single metric between two periods
+type Delta struct {
+	Metric      string  `json:"metric"`
+	Current     float64 `json:"current"`
+	Baseline    float64 `json:"baseline"`
+	Change      float64 `json:"change"`        // Current - Baseline (percentage points for shares)
+	IsShare     bool    `json:"is_share"`      // Values are percentages (0-100) rather than counts
+	Significant bool    `json:"significant"`   // Change is unlikely to be noise given the sample sizes
+	HigherIsBad bool    `json:"higher_is_bad"` // An increase is a bad sign (e.g., [LEAK], drift)
+}
+
+// Comparison contrasts a current period with a baseline
+type Comparison struct {
+	CurrentLabel  string    `json:"current_label"`
+	BaselineLabel string    `json:"baseline_label"`
+	Deltas        []Delta   `json:"deltas"`
+	Trend         []float64 `json:"trend,omitempty"` // Optional ↑ share per period (oldest first) for a sparkline
+}
+
+// ComparePeriods compares momentum distribution, tag share, flag counts, drift and
+// sign-off rate. Baseline may be an average of several periods (see AverageSnapshots).
+func ComparePeriods(current, baseline *PeriodSnapshot) *Comparison {
+	cmp := &Comparison{
+		CurrentLabel:  current.Label,
+		BaselineLabel: baseline.Label,
+	}
+
+	// Momentum shares (two-proportion z-test)
+	for _, m := range []struct {
+		name string
+		cur  int
+		base int
+		bad  bool
+	}{
+		{"↑ productive", current.Momentum.UpCount, baseline.Momentum.UpCount, false},
+		{"↓ dragging", current.Momentum.DownCount, baseline.Momentum.DownCount, true},
+		{"← waste", current.Momentum.BackCount, baseline.Momentum.BackCount, true},
+	} {
+		cmp.Deltas = append(cmp.Deltas, shareDelta(m.name, m.cur, current.marked(), m.base, baseline.marked(), m.bad))
+	}
+
+	// Tag share
+	for _, tag := range unionKeys(current.TagCounts, baseline.TagCounts) {
+		cmp.Deltas = append(cmp.Deltas, shareDelta(tag+" share", current.TagCounts[tag], current.totalTags(),
+			baseline.TagCounts[tag], baseline.totalTags(), false))
+	}
+
+	// Flag counts, normalized per logged day (Poisson rate test)
+	for _, flag := range unionKeys(current.FlagCounts, baseline.FlagCounts) {
+		bad := flag == "[LEAK]" || flag == "[STUCK]"
+		cmp.Deltas = append(cmp.Deltas, rateDelta(flag, current.FlagCounts[flag], current.DaysLogged,
+			baseline.FlagCounts[flag], baseline.DaysLogged, bad))
+	}
+
+	// Drift per logged day
+	cmp.Deltas = append(cmp.Deltas, rateDelta("drift gaps", current.DriftCount, current.DaysLogged,
+		baseline.DriftCount, baseline.DaysLogged, true))
+
+	// Sign-off rate over logged days
+	cmp.Deltas = append(cmp.Deltas, shareDelta("sign-off rate", current.SignoffDays, current.DaysLogged,
+		baseline.SignoffDays, baseline.DaysLogged, false))
+
+	return cmp
+}
+
+// AverageSnapshots averages several baseline periods into one (e.g., the last 4 weeks)
+func AverageSnapshots(label string, snaps []*PeriodSnapshot) *PeriodSnapshot {
+	avg := &PeriodSnapshot{
+		Label:      label,
+		Momentum:   &MomentumStats{},
+		TagCounts:  make(map[string]int),
+		FlagCounts: make(map[string]int),
+	}
+	if len(snaps) == 0 {
+		return avg
+	}
+
+	// Shares are pooled (sums), while rates are normalized per logged day by ComparePeriods,
+	// so summing everything keeps both correct and preserves the real sample sizes.
+	for _, s := range snaps {
+		avg.Entries += s.Entries
+		avg.DaysLogged += s.DaysLogged
+		avg.CalendarDays += s.CalendarDays
+		avg.DriftCount += s.DriftCount
+		avg.SignoffDays += s.SignoffDays
+		avg.Momentum.UpCount += s.Momentum.UpCount
+		avg.Momentum.NeutralCount += s.Momentum.NeutralCount
+		avg.Momentum.DownCount += s.Momentum.DownCount
+		avg.Momentum.BackCount += s.Momentum.BackCount
+		avg.Momentum.TotalCount += s.Momentum.TotalCount
+		for k, v := range s.TagCounts {
+			avg.TagCounts[k] += v
+		}
+		for k, v := range s.FlagCounts {
+			avg.FlagCounts[k] += v
+		}
+	}
+
+	return avg
+}
+
+// DefaultComparisonHistory is how many previous periods the rolling average covers
+const DefaultComparisonHistory = 4
+
+// BuildComparisons compares current against the immediately preceding period and the
+// average of up to DefaultComparisonHistory previous periods. history is oldest first;
+// unit names the period kind for labels ("week" or "month").
+func BuildComparisons(current *PeriodSnapshot, history []*PeriodSnapshot, unit string) []*Comparison {
+	if len(history) == 0 {
+		return nil
+	}
+	if len(history) > DefaultComparisonHistory {
+		history = history[len(history)-DefaultComparisonHistory:]
+	}
+
+	previous := *history[len(history)-1]
+	previous.Label = "last " + unit
+	comparisons := []*Comparison{ComparePeriods(current, &previous)}
+
+	if len(history) > 1 {
+		avg := AverageSnapshots(fmt.Sprintf("%d-%s avg", len(history), unit), history)
+		comparisons = append(comparisons, ComparePeriods(current, avg))
+	}
+
+	// ↑ share across all periods for the sparkline
+	var trend []float64
+	for _, s := range history {
+		trend = append(trend, s.UpShare())
+	}
+	comparisons[0].Trend = append(trend, current.UpShare())
+
+	return comparisons
+}
+
+// UpShare returns the percentage of marked entries that were ↑
+func (s *PeriodSnapshot) UpShare() float64 {
+	if s.marked() == 0 {
+		return 0
+	}
+	return float64(s.Momentum.UpCount) / float64(s.marked()) * 100
+}
+
+// shareDelta compares two proportions with a two-proportion z-test
+func shareDelta(metric string, curCount, curTotal, baseCount, baseTotal int, higherIsBad bool) Delta {
+	d := Delta{Metric: metric, IsShare: true, HigherIsBad: higherIsBad}
+	if curTotal > 0 {
+		d.Current = float64(curCount) / float64(curTotal) * 100
+	}
+	if baseTotal > 0 {
+		d.Baseline = float64(baseCount) / float64(baseTotal) * 100
+	}
+	d.Change = d.Current - d.Baseline
+
+	if curTotal > 0 && baseTotal > 0 {
+		p1 := float64(curCount) / float64(curTotal)
+		p2 := float64(baseCount) / float64(baseTotal)
+		pooled := float64(curCount+baseCount) / float64(curTotal+baseTotal)
+		se := math.Sqrt(pooled * (1 - pooled) * (1/float64(curTotal) + 1/float64(baseTotal)))
+		if se > 0 {
+			d.Significant = math.Abs(p1-p2)/se >= significanceZ
+		}
+	}
+
+	return d
+}
+
+// rateDelta compares per-day event rates, treating counts as Poisson
+func rateDelta(metric string, curCount, curDays, baseCount, baseDays int, higherIsBad bool) Delta {
+	d := Delta{Metric: metric + " /day", HigherIsBad: higherIsBad}
+	if curDays > 0 {
+		d.Current = float64(curCount) / float64(curDays)
+	}
+	if baseDays > 0 {
+		d.Baseline = float64(baseCount) / float64(baseDays)
+	}
+	d.Change = d.Current - d.Baseline
+
+	if curDays > 0 && baseDays > 0 {
+		se := math.Sqrt(float64(curCount)/float64(curDays*curDays) + float64(baseCount)/float64(baseDays*baseDays))
+		if se > 0 {
+			d.Significant = math.Abs(d.Change)/se >= significanceZ
+		}
+	}
+
+	return d
+}
+
+// unionKeys returns the sorted union of two maps' keys
+func unionKeys(a, b map[string]int) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, m := range []map[string]int{a, b} {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ComparisonInsights returns insights only for statistically meaningful changes
+func ComparisonInsights(cmp *Comparison) []string {
+	if cmp == nil {
+		return nil
+	}
+
+	var insights []string
+	for _, d := range cmp.Deltas {
+		if !d.Significant {
+			continue
+		}
+		direction := "up"
+		if d.Change < 0 {
+			direction = "down"
+		}
+		improved := (d.Change > 0) != d.HigherIsBad
+		tone := "worth a look"
+		if improved {
+			tone = "nice trend"
+		}
+		insights = append(insights, fmt.Sprintf("%s is %s vs %s: %s → %s (%s).",
+			d.Metric, direction, cmp.BaselineLabel, d.FormatValue(d.Baseline), d.FormatValue(d.Current), tone))
+	}
+	return insights
+}
+
+// FormatValue formats a metric value as a percentage or a per-day rate
+func (d Delta) FormatValue(v float64) string {
+	if d.IsShare {
+		return fmt.Sprintf("%.0f%%", v)
+	}
+	return fmt.Sprintf("%.1f", v)
+}
+
+// Sparkline renders values as a compact unicode bar sequence
+func Sparkline(values []float64) string {
+	bars := []rune("▁▂▃▄▅▆▇█")
+	if len(values) == 0 {
+		return ""
+	}
+
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		idx := 0
+		if hi > lo {
+			idx = int((v - lo) / (hi - lo) * float64(len(bars)-1))
+		}
+		b.WriteRune(bars[idx])
+	}
+	return b.String()
+}
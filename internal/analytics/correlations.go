@@ -0,0 +1,276 @@
+package analytics
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aaryareddy/log_cli/internal/database"
+)
+
+const (
+	// MinCorrelationSamples is the minimum co-occurrence count before a finding is reported
+	MinCorrelationSamples = 3
+	// MinFindingLift is how much more likely than baseline an outcome must be to be reported
+	MinFindingLift = 1.5
+)
+
+// MomentumOrder is the column order for tag × momentum tables
+var MomentumOrder = []string{"up", "neutral", "down", "back"}
+
+// TagMomentumRow holds momentum counts and lift scores for a single tag or flag
+type TagMomentumRow struct {
+	Tag    string
+	Counts map[string]int     // Momentum → entries with this tag and momentum
+	Lift   map[string]float64 // Momentum → P(momentum | tag) / P(momentum)
+	Total  int                // Entries with this tag that carry a momentum marker
+}
+
+// TagMomentumTable is a tag × momentum contingency table
+type TagMomentumTable struct {
+	Rows           []TagMomentumRow
+	MomentumTotals map[string]int
+	Total          int // Entries with a momentum marker
+}
+
+// Transition counts how often one entry's tag/flag is followed by another's
+type Transition struct {
+	From        string
+	To          string
+	Count       int     // Times From was immediately followed by To
+	FromCount   int     // Times From was followed by any entry
+	Probability float64 // Count / FromCount
+	Lift        float64 // Probability / base rate of To
+}
+
+// Finding is a human-readable correlation with its sample size
+type Finding struct {
+	Text    string  `json:"text"`
+	Lift    float64 `json:"lift"`
+	Samples int     `json:"samples"`
+}
+
+// CorrelationReport combines tag-momentum correlation and sequence analysis
+type CorrelationReport struct {
+	TagMomentum *TagMomentumTable
+	Transitions []Transition
+	Findings    []Finding
+}
+
+// AnalyzeCorrelations builds the contingency table, transition probabilities and strongest findings
+func AnalyzeCorrelations(entries []*database.Entry) *CorrelationReport {
+	table := BuildTagMomentumTable(entries)
+	transitions := BuildTransitions(entries)
+
+	return &CorrelationReport{
+		TagMomentum: table,
+		Transitions: transitions,
+		Findings:    strongestFindings(table, transitions),
+	}
+}
+
+// BuildTagMomentumTable counts momentum per tag and scores each cell by lift
+func BuildTagMomentumTable(entries []*database.Entry) *TagMomentumTable {
+	table := &TagMomentumTable{
+		MomentumTotals: make(map[string]int),
+	}
+	rows := make(map[string]*TagMomentumRow)
+
+	for _, entry := range entries {
+		if entry.Momentum == nil {
+			continue
+		}
+		momentum := *entry.Momentum
+		table.MomentumTotals[momentum]++
+		table.Total++
+
+		for _, tag := range entry.Tags {
+			if tag.TagValue == string(database.TagSignoff) {
+				continue
+			}
+			row, ok := rows[tag.TagValue]
+			if !ok {
+				row = &TagMomentumRow{
+					Tag:    tag.TagValue,
+					Counts: make(map[string]int),
+					Lift:   make(map[string]float64),
+				}
+				rows[tag.TagValue] = row
+			}
+			row.Counts[momentum]++
+			row.Total++
+		}
+	}
+
+	for _, row := range rows {
+		for _, m := range MomentumOrder {
+			if table.MomentumTotals[m] == 0 || row.Total == 0 {
+				continue
+			}
+			pGivenTag := float64(row.Counts[m]) / float64(row.Total)
+			pBase := float64(table.MomentumTotals[m]) / float64(table.Total)
+			row.Lift[m] = pGivenTag / pBase
+		}
+		table.Rows = append(table.Rows, *row)
+	}
+
+	sort.Slice(table.Rows, func(i, j int) bool {
+		if table.Rows[i].Total == table.Rows[j].Total {
+			return table.Rows[i].Tag < table.Rows[j].Tag
+		}
+		return table.Rows[i].Total > table.Rows[j].Total
+	})
+
+	return table
+}
+
+// BuildTransitions computes first-order transition probabilities between the tags and
+// flags of consecutive entries on the same day (e.g., @admin → [LEAK])
+func BuildTransitions(entries []*database.Entry) []Transition {
+	sorted := make([]*database.Entry, len(entries))
+	copy(sorted, entries)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp.Before(sorted[j].Timestamp)
+	})
+
+	pairCounts := make(map[[2]string]int)
+	fromCounts := make(map[string]int)
+	toCounts := make(map[string]int)
+	pairs := 0
+
+	for i := 0; i+1 < len(sorted); i++ {
+		cur, next := sorted[i], sorted[i+1]
+		if !sameDay(cur.Timestamp, next.Timestamp) {
+			continue
+		}
+		pairs++
+
+		fromTags := transitionTags(cur)
+		toTags := transitionTags(next)
+		for _, from := range fromTags {
+			fromCounts[from]++
+		}
+		for _, to := range toTags {
+			toCounts[to]++
+		}
+		for _, from := range fromTags {
+			for _, to := range toTags {
+				pairCounts[[2]string{from, to}]++
+			}
+		}
+	}
+
+	var transitions []Transition
+	for pair, count := range pairCounts {
+		from, to := pair[0], pair[1]
+		prob := float64(count) / float64(fromCounts[from])
+		base := float64(toCounts[to]) / float64(pairs)
+		transitions = append(transitions, Transition{
+			From:        from,
+			To:          to,
+			Count:       count,
+			FromCount:   fromCounts[from],
+			Probability: prob,
+			Lift:        prob / base,
+		})
+	}
+
+	sort.Slice(transitions, func(i, j int) bool {
+		if transitions[i].Count == transitions[j].Count {
+			return transitions[i].From+transitions[i].To < transitions[j].From+transitions[j].To
+		}
+		return transitions[i].Count > transitions[j].Count
+	})
+
+	return transitions
+}
+
+// transitionTags returns the distinct tags and flags of an entry (excluding @signoff)
+func transitionTags(entry *database.Entry) []string {
+	seen := make(map[string]bool)
+	var tags []string
+	for _, tag := range entry.Tags {
+		if tag.TagValue == string(database.TagSignoff) || seen[tag.TagValue] {
+			continue
+		}
+		seen[tag.TagValue] = true
+		tags = append(tags, tag.TagValue)
+	}
+	return tags
+}
+
+// strongestFindings picks the highest-lift correlations with enough samples to be meaningful
+func strongestFindings(table *TagMomentumTable, transitions []Transition) []Finding {
+	var findings []Finding
+
+	for _, row := range table.Rows {
+		for _, m := range MomentumOrder {
+			count := row.Counts[m]
+			lift := row.Lift[m]
+			if count < MinCorrelationSamples || lift < MinFindingLift {
+				continue
+			}
+			findings = append(findings, Finding{
+				Text: fmt.Sprintf("%s entries are %s %.0f%% of the time (%d/%d), %.1f× your baseline",
+					row.Tag, MomentumArrow(m), float64(count)/float64(row.Total)*100, count, row.Total, lift),
+				Lift:    lift,
+				Samples: row.Total,
+			})
+		}
+	}
+
+	for _, t := range transitions {
+		if t.From == t.To || t.Count < MinCorrelationSamples || t.Lift < MinFindingLift {
+			continue
+		}
+		findings = append(findings, Finding{
+			Text: fmt.Sprintf("%s is followed by %s %.0f%% of the time (%d/%d), %.1f× your baseline",
+				t.From, t.To, t.Probability*100, t.Count, t.FromCount, t.Lift),
+			Lift:    t.Lift,
+			Samples: t.FromCount,
+		})
+	}
+
+	// Strongest first; larger samples break ties
+	sort.Slice(findings, func(i, j int) bool {
+		if findings[i].Lift == findings[j].Lift {
+			return findings[i].Samples > findings[j].Samples
+		}
+		return findings[i].Lift > findings[j].Lift
+	})
+
+	return findings
+}
+
+// TransitionsInto returns transitions leading into a target tag, strongest first
+// (e.g., what precedes [LEAK])
+func (r *CorrelationReport) TransitionsInto(target string) []Transition {
+	var result []Transition
+	for _, t := range r.Transitions {
+		if t.To == target && t.From != target {
+			result = append(result, t)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Probability == result[j].Probability {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Probability > result[j].Probability
+	})
+	return result
+}
+
+// MomentumArrow returns the arrow symbol for a momentum value
+func MomentumArrow(momentum string) string {
+	switch momentum {
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	case "neutral":
+		return "→"
+	case "back":
+		return "←"
+	default:
+		return momentum
+	}
+}
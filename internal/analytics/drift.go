@@ -0,0 +1,187 @@
+package analytics
+
+import (
+	"sort"
+	"time"
+
+	"github.com/aaryareddy/log_cli/internal/database"
+)
+
+// Config keys for drift detection (stored in the config table)
+const (
+	ConfigDriftThresholdMinutes = "drift.threshold_minutes"
+	ConfigAfterHoursStart       = "drift.after_hours_start"
+)
+
+// DefaultAfterHoursStart is the hour (24h) after which gaps are not counted as drift
+const DefaultAfterHoursStart = 20
+
+// GapKind classifies a long gap between consecutive entries
+type GapKind string
+
+const (
+	GapDrift       GapKind = "drift"        // Unexplained time without logging
+	GapSteppedAway GapKind = "stepped_away" // User acknowledged stepping away
+	GapBreak       GapKind = "break"        // Preceded by an @break entry
+	GapAfterHours  GapKind = "after_hours"  // After sign-off or after the after-hours cutoff
+)
+
+// DriftRules controls how gaps are detected and classified
+type DriftRules struct {
+	Threshold       time.Duration // Minimum gap length to report
+	AfterHoursStart int           // Hour of day (0-23) when after-hours begins
+}
+
+// DefaultDriftRules returns the rules matching the log TUI's drift alert
+func DefaultDriftRules() DriftRules {
+	return DriftRules{
+		Threshold:       DefaultDriftThreshold,
+		AfterHoursStart: DefaultAfterHoursStart,
+	}
+}
+
+// Gap is a stretch of time between two consecutive entries longer than the threshold
+type Gap struct {
+	Start  time.Time
+	End    time.Time
+	Kind   GapKind
+	Before *database.Entry // Entry that opened the gap
+	After  *database.Entry // Entry that closed the gap
+}
+
+// Duration returns the length of the gap
+func (g Gap) Duration() time.Duration {
+	return g.End.Sub(g.Start)
+}
+
+// DetectGaps finds gaps of at least rules.Threshold between consecutive same-day
+// entries, classifying each as drift, stepped away, break or after-hours
+func DetectGaps(entries []*database.Entry, away []database.AwayInterval, rules DriftRules) []Gap {
+	if rules.Threshold <= 0 {
+		rules.Threshold = DefaultDriftThreshold
+	}
+
+	sorted := make([]*database.Entry, len(entries))
+	copy(sorted, entries)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp.Before(sorted[j].Timestamp)
+	})
+
+	var gaps []Gap
+	signedOff := make(map[string]bool)
+
+	for i := 0; i+1 < len(sorted); i++ {
+		cur, next := sorted[i], sorted[i+1]
+		dateKey := cur.Timestamp.Format("2006-01-02")
+		if hasTag(cur, string(database.TagSignoff)) {
+			signedOff[dateKey] = true
+		}
+
+		if !sameDay(cur.Timestamp, next.Timestamp) {
+			continue
+		}
+		if next.Timestamp.Sub(cur.Timestamp) < rules.Threshold {
+			continue
+		}
+
+		gap := Gap{
+			Start:  cur.Timestamp,
+			End:    next.Timestamp,
+			Before: cur,
+			After:  next,
+		}
+
+		switch {
+		case signedOff[dateKey] || cur.Timestamp.Hour() >= rules.AfterHoursStart:
+			gap.Kind = GapAfterHours
+		case hasTag(cur, string(database.TagBreak)):
+			gap.Kind = GapBreak
+		case coveredByAway(gap, away):
+			gap.Kind = GapSteppedAway
+		default:
+			gap.Kind = GapDrift
+		}
+
+		gaps = append(gaps, gap)
+	}
+
+	return gaps
+}
+
+// coveredByAway reports whether acknowledged away intervals cover at least half of the gap
+func coveredByAway(gap Gap, away []database.AwayInterval) bool {
+	var covered time.Duration
+	for _, a := range away {
+		start, end := a.StartTime, a.EndTime
+		if start.Before(gap.Start) {
+			start = gap.Start
+		}
+		if end.After(gap.End) {
+			end = gap.End
+		}
+		if end.After(start) {
+			covered += end.Sub(start)
+		}
+	}
+	return covered*2 >= gap.Duration()
+}
+
+// DayDrift summarizes gaps for a single day
+type DayDrift struct {
+	Date             string
+	DriftCount       int
+	DriftTime        time.Duration
+	SteppedAwayCount int
+	SteppedAwayTime  time.Duration
+	BreakTime        time.Duration
+}
+
+// DriftReport summarizes gaps per day and in total
+type DriftReport struct {
+	Days             []DayDrift
+	DriftCount       int
+	DriftTime        time.Duration
+	SteppedAwayCount int
+	SteppedAwayTime  time.Duration
+	BreakTime        time.Duration
+}
+
+// BuildDriftReport aggregates gaps per day and for the whole range
+func BuildDriftReport(gaps []Gap) *DriftReport {
+	report := &DriftReport{}
+	byDate := make(map[string]*DayDrift)
+	var dates []string
+
+	for _, gap := range gaps {
+		date := gap.Start.Format("2006-01-02")
+		day, ok := byDate[date]
+		if !ok {
+			day = &DayDrift{Date: date}
+			byDate[date] = day
+			dates = append(dates, date)
+		}
+
+		switch gap.Kind {
+		case GapDrift:
+			day.DriftCount++
+			day.DriftTime += gap.Duration()
+			report.DriftCount++
+			report.DriftTime += gap.Duration()
+		case GapSteppedAway:
+			day.SteppedAwayCount++
+			day.SteppedAwayTime += gap.Duration()
+			report.SteppedAwayCount++
+			report.SteppedAwayTime += gap.Duration()
+		case GapBreak:
+			day.BreakTime += gap.Duration()
+			report.BreakTime += gap.Duration()
+		}
+	}
+
+	sort.Strings(dates)
+	for _, date := range dates {
+		report.Days = append(report.Days, *byDate[date])
+	}
+
+	return report
+}
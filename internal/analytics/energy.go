@@ -0,0 +1,275 @@
+package analytics
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aaryareddy/log_cli/internal/database"
+)
+
+const (
+	// DefaultEnergyWindowDays is how far back energy patterns look by default
+	DefaultEnergyWindowDays = 14
+	// minEnergySamples is the minimum number of matching entries before an insight is reported
+	minEnergySamples = 5
+	// energyWindowHours is the width of the peak/trough window in insights
+	energyWindowHours = 2
+)
+
+// ConfigEnergyWindowDays is the config key for how many days energy patterns look back
+const ConfigEnergyWindowDays = "energy.window_days"
+
+// Energy metrics that can be plotted on the heatmap.
+// Context tags (e.g., "@deep") and flags (e.g., "[LEAK]") are also valid metrics.
+const (
+	MetricAll  = "all"
+	MetricUp   = "up"
+	MetricDown = "down"
+)
+
+// EnergyMetrics is the default order metrics are cycled through in the energy view
+var EnergyMetrics = []string{MetricUp, MetricDown, "[FLOW]", "[LEAK]", "@deep", "@admin", MetricAll}
+
+// EnergyCell counts entries logged in a single weekday × hour slot
+type EnergyCell struct {
+	Total    int
+	Momentum map[string]int // "up", "neutral", "down", "back"
+	Tags     map[string]int // Context tags and flags
+}
+
+// EnergyMap is an hour-of-day × weekday heatmap of logged entries
+type EnergyMap struct {
+	StartDate string
+	EndDate   string
+	Cells     [7][24]EnergyCell // Indexed by time.Weekday, then hour
+}
+
+// BuildEnergyMap buckets entries by weekday and hour of their timestamp
+func BuildEnergyMap(entries []*database.Entry, startDate, endDate string) *EnergyMap {
+	m := &EnergyMap{
+		StartDate: startDate,
+		EndDate:   endDate,
+	}
+
+	for _, entry := range entries {
+		if hasTag(entry, string(database.TagSignoff)) {
+			continue
+		}
+
+		cell := &m.Cells[entry.Timestamp.Weekday()][entry.Timestamp.Hour()]
+		if cell.Momentum == nil {
+			cell.Momentum = make(map[string]int)
+			cell.Tags = make(map[string]int)
+		}
+
+		cell.Total++
+		if entry.Momentum != nil {
+			cell.Momentum[*entry.Momentum]++
+		}
+		for _, tag := range entry.Tags {
+			cell.Tags[tag.TagValue]++
+		}
+	}
+
+	return m
+}
+
+// EnergyWindow returns the days days ending with the period's last day, the
+// range energy patterns are built from (the period itself if it is longer)
+func EnergyWindow(period Period, days int) Period {
+	if days <= period.CalendarDays() {
+		return period
+	}
+	return RangePeriod(period.End.AddDate(0, 0, -(days-1)), period.End)
+}
+
+// Count returns how many entries in a cell match the metric
+func (c EnergyCell) Count(metric string) int {
+	switch {
+	case metric == MetricAll:
+		return c.Total
+	case strings.HasPrefix(metric, "@") || strings.HasPrefix(metric, "["):
+		return c.Tags[metric]
+	default:
+		return c.Momentum[metric]
+	}
+}
+
+// HourCounts returns metric counts per hour summed across all weekdays
+func (m *EnergyMap) HourCounts(metric string) [24]int {
+	var counts [24]int
+	for wd := 0; wd < 7; wd++ {
+		for h := 0; h < 24; h++ {
+			counts[h] += m.Cells[wd][h].Count(metric)
+		}
+	}
+	return counts
+}
+
+// WeekdayCounts returns metric counts per weekday summed across all hours
+func (m *EnergyMap) WeekdayCounts(metric string) [7]int {
+	var counts [7]int
+	for wd := 0; wd < 7; wd++ {
+		for h := 0; h < 24; h++ {
+			counts[wd] += m.Cells[wd][h].Count(metric)
+		}
+	}
+	return counts
+}
+
+// Total returns how many entries match the metric across the whole map
+func (m *EnergyMap) Total(metric string) int {
+	total := 0
+	for _, c := range m.HourCounts(metric) {
+		total += c
+	}
+	return total
+}
+
+// PeakWindow finds the window of consecutive hours with the most matching entries.
+// Returns the first hour of the window and its count.
+func (m *EnergyMap) PeakWindow(metric string, width int) (startHour, count int) {
+	counts := m.HourCounts(metric)
+
+	startHour = -1
+	for h := 0; h+width <= 24; h++ {
+		sum := 0
+		for i := h; i < h+width; i++ {
+			sum += counts[i]
+		}
+		if startHour == -1 || sum > count {
+			startHour, count = h, sum
+		}
+	}
+	return startHour, count
+}
+
+// ActiveHours returns the first and last hour that has any entries
+func (m *EnergyMap) ActiveHours() (first, last int) {
+	counts := m.HourCounts(MetricAll)
+	first, last = -1, -1
+	for h, c := range counts {
+		if c == 0 {
+			continue
+		}
+		if first == -1 {
+			first = h
+		}
+		last = h
+	}
+	return first, last
+}
+
+// EnergyInsights generates textual insights about peak and trough energy windows
+func EnergyInsights(m *EnergyMap) []string {
+	if m == nil {
+		return nil
+	}
+
+	var insights []string
+
+	// Peak ↑ window
+	if m.Total(MetricUp) >= minEnergySamples {
+		start, count := m.PeakWindow(MetricUp, energyWindowHours)
+		if count > 0 {
+			insights = append(insights, fmt.Sprintf("You log ↑ most often between %s (%d entries).",
+				formatHourRange(start, start+energyWindowHours), count))
+		}
+	}
+
+	// Trough: window where ↓ and ← make up the largest share of entries
+	if m.Total(MetricDown)+m.Total("back") >= minEnergySamples {
+		all := m.HourCounts(MetricAll)
+		down := m.HourCounts(MetricDown)
+		back := m.HourCounts("back")
+
+		bestStart, bestShare, bestTotal := -1, 0.0, 0
+		for h := 0; h+energyWindowHours <= 24; h++ {
+			total, low := 0, 0
+			for i := h; i < h+energyWindowHours; i++ {
+				total += all[i]
+				low += down[i] + back[i]
+			}
+			if total < minEnergySamples {
+				continue
+			}
+			share := float64(low) / float64(total)
+			if share > bestShare {
+				bestStart, bestShare, bestTotal = h, share, total
+			}
+		}
+		if bestStart >= 0 && bestShare > 0 {
+			insights = append(insights, fmt.Sprintf("Energy dips between %s: %.0f%% of %d entries were ↓ or ←.",
+				formatHourRange(bestStart, bestStart+energyWindowHours), bestShare*100, bestTotal))
+		}
+	}
+
+	// Peak leak window
+	if m.Total("[LEAK]") >= minEnergySamples {
+		start, count := m.PeakWindow("[LEAK]", energyWindowHours)
+		if count > 0 {
+			insights = append(insights, fmt.Sprintf("[LEAK] shows up most between %s (%d times).",
+				formatHourRange(start, start+energyWindowHours), count))
+		}
+	}
+
+	// Best weekday by ↑ share
+	weekdayAll := m.WeekdayCounts(MetricAll)
+	weekdayUp := m.WeekdayCounts(MetricUp)
+	bestDay, bestShare := -1, 0.0
+	for wd := 0; wd < 7; wd++ {
+		if weekdayAll[wd] < minEnergySamples {
+			continue
+		}
+		share := float64(weekdayUp[wd]) / float64(weekdayAll[wd])
+		if share > bestShare {
+			bestDay, bestShare = wd, share
+		}
+	}
+	if bestDay >= 0 && bestShare > 0 {
+		insights = append(insights, fmt.Sprintf("%ss are your strongest day: %.0f%% of entries marked ↑.",
+			time.Weekday(bestDay), bestShare*100))
+	}
+
+	return insights
+}
+
+// MetricLabel returns a readable name for a heatmap metric
+func MetricLabel(metric string) string {
+	switch metric {
+	case MetricAll:
+		return "all entries"
+	case MetricUp:
+		return "↑ productive"
+	case MetricDown:
+		return "↓ dragging"
+	case "back":
+		return "← waste"
+	default:
+		return metric
+	}
+}
+
+// FormatHour formats an hour of day as "9am" / "12pm"
+func FormatHour(h int) string {
+	h = h % 24
+	suffix := "am"
+	if h >= 12 {
+		suffix = "pm"
+	}
+	display := h % 12
+	if display == 0 {
+		display = 12
+	}
+	return fmt.Sprintf("%d%s", display, suffix)
+}
+
+// formatHourRange formats an hour range as "9-11am" or "11am-1pm"
+func formatHourRange(start, end int) string {
+	startStr, endStr := FormatHour(start), FormatHour(end)
+	if (start%24 < 12) == (end%24 < 12) {
+		return strings.TrimSuffix(strings.TrimSuffix(startStr, "am"), "pm") + "-" + endStr
+	}
+	return startStr + "-" + endStr
+}
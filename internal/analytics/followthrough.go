@@ -0,0 +1,154 @@
+package analytics
+
+import (
+	"strings"
+	"time"
+
+	"github.com/aaryareddy/log_cli/internal/database"
+)
+
+// FollowThrough counts how often morning intentions were met, partly met or missed
+type FollowThrough struct {
+	WithIntention int `json:"with_intention"` // Days with an intention set
+	Met           int `json:"met"`
+	Partial       int `json:"partial"`
+	Missed        int `json:"missed"`
+	Unrated       int `json:"unrated"` // Intentions never rated at sign-off
+}
+
+// Rated returns the number of intentions that were rated
+func (f *FollowThrough) Rated() int {
+	return f.Met + f.Partial + f.Missed
+}
+
+// Rate returns the follow-through rate (0-100) over rated intentions, counting partial as half
+func (f *FollowThrough) Rate() float64 {
+	if f.Rated() == 0 {
+		return 0
+	}
+	return (float64(f.Met) + float64(f.Partial)*0.5) / float64(f.Rated()) * 100
+}
+
+// CalculateFollowThrough tallies intention ratings across days
+func CalculateFollowThrough(days []*database.Day) *FollowThrough {
+	f := &FollowThrough{}
+	for _, day := range days {
+		if day.Intention == nil || strings.TrimSpace(*day.Intention) == "" {
+			continue
+		}
+		f.WithIntention++
+
+		if day.IntentionStatus == nil {
+			f.Unrated++
+			continue
+		}
+		switch database.IntentionStatus(*day.IntentionStatus) {
+		case database.IntentionMet:
+			f.Met++
+		case database.IntentionPartial:
+			f.Partial++
+		case database.IntentionMissed:
+			f.Missed++
+		default:
+			f.Unrated++
+		}
+	}
+	return f
+}
+
+// ProtectPairing lines up the previous evening's "protect tomorrow" with what happened
+type ProtectPairing struct {
+	Date      time.Time
+	Protect   string // Previous day's tomorrow_protect
+	Intention string // This day's intention
+	Status    string // This day's intention rating ("" if unrated)
+	Entries   []*database.Entry
+	Mentions  []*database.Entry // Entries that share keywords with Protect
+	Carried   bool              // The intention shares keywords with Protect
+}
+
+// PairProtections pairs each day's tomorrow_protect with the next calendar day's
+// intention and entries. Keyword overlap shows whether the commitment carried over.
+func PairProtections(days []*database.Day, entries []*database.Entry) []ProtectPairing {
+	byDate := make(map[string]*database.Day, len(days))
+	for _, day := range days {
+		byDate[day.Date.Format("2006-01-02")] = day
+	}
+
+	entriesByDate := make(map[string][]*database.Entry)
+	for _, entry := range entries {
+		key := entry.Timestamp.Format("2006-01-02")
+		entriesByDate[key] = append(entriesByDate[key], entry)
+	}
+
+	var pairings []ProtectPairing
+	for _, prev := range days {
+		if prev.TomorrowProtect == nil || strings.TrimSpace(*prev.TomorrowProtect) == "" {
+			continue
+		}
+
+		date := truncateDay(prev.Date).AddDate(0, 0, 1)
+		key := date.Format("2006-01-02")
+		pairing := ProtectPairing{
+			Date:    date,
+			Protect: strings.TrimSpace(*prev.TomorrowProtect),
+			Entries: entriesByDate[key],
+		}
+
+		protectWords := keywordSet(pairing.Protect)
+		if next, ok := byDate[key]; ok {
+			if next.Intention != nil {
+				pairing.Intention = strings.TrimSpace(*next.Intention)
+			}
+			if next.IntentionStatus != nil {
+				pairing.Status = *next.IntentionStatus
+			}
+		}
+		pairing.Carried = overlaps(protectWords, pairing.Intention)
+		for _, entry := range pairing.Entries {
+			if overlaps(protectWords, entry.EntryText) {
+				pairing.Mentions = append(pairing.Mentions, entry)
+			}
+		}
+
+		pairings = append(pairings, pairing)
+	}
+
+	return pairings
+}
+
+// keywordSet returns the normalized keywords of text as a set
+func keywordSet(text string) map[string]bool {
+	set := make(map[string]bool)
+	for _, kw := range Keywords(text, DefaultSynonyms) {
+		set[kw] = true
+	}
+	return set
+}
+
+// overlaps reports whether text shares any keyword with the set
+func overlaps(set map[string]bool, text string) bool {
+	for _, kw := range Keywords(text, DefaultSynonyms) {
+		if set[kw] {
+			return true
+		}
+	}
+	return false
+}
+
+// ClusterReflections groups recurring "what pulled you off track" answers into themes.
+// Each answer becomes a pseudo-entry timestamped at its day so themes can list dates.
+func ClusterReflections(days []*database.Day, synonyms Synonyms) []Theme {
+	var reasons []*database.Entry
+	for _, day := range days {
+		if day.PulledOffTrack == nil || strings.TrimSpace(*day.PulledOffTrack) == "" {
+			continue
+		}
+		reasons = append(reasons, &database.Entry{
+			DayID:     day.ID,
+			Timestamp: day.Date,
+			EntryText: strings.TrimSpace(*day.PulledOffTrack),
+		})
+	}
+	return ExtractThemes(reasons, synonyms)
+}
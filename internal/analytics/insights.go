@@ -0,0 +1,78 @@
+package analytics
+
+import "fmt"
+
+// WeekInsights creates actionable insights from a week's (or month's) data,
+// as shown at the end of the review
+func WeekInsights(summary *WeeklyPatternSummary) []string {
+	var insights []string
+
+	// Momentum insights
+	if summary.MomentumStats.TotalCount > 0 {
+		upPct := float64(summary.MomentumStats.UpCount) / float64(summary.MomentumStats.TotalCount) * 100
+		backPct := float64(summary.MomentumStats.BackCount) / float64(summary.MomentumStats.TotalCount) * 100
+
+		if upPct > 60 {
+			insights = append(insights, "Strong momentum! You logged ↑ on over 60% of marked entries.")
+		} else if upPct < 30 {
+			insights = append(insights, "Momentum was lower than usual. Consider what conditions help you feel more energized.")
+		}
+
+		if backPct > 10 {
+			insights = append(insights, fmt.Sprintf("%.0f%% of entries were marked as waste (←). Review these patterns to reclaim time.", backPct))
+		}
+	}
+
+	// Pattern insights
+	if len(summary.PatternGroups["[FLOW]"]) > 0 {
+		insights = append(insights, fmt.Sprintf("You hit flow %d times this week. What conditions enabled those states?", len(summary.PatternGroups["[FLOW]"])))
+	}
+
+	if len(summary.PatternGroups["[LEAK]"]) > 0 {
+		insights = append(insights, fmt.Sprintf("Identified %d leak patterns. Common themes in what pulled you off track?", len(summary.PatternGroups["[LEAK]"])))
+	}
+
+	if len(summary.PatternGroups["[STUCK]"]) > 0 {
+		insights = append(insights, fmt.Sprintf("You got stuck %d times. Consider documenting solutions when you break through.", len(summary.PatternGroups["[STUCK]"])))
+	}
+
+	if len(summary.PatternGroups["[GOLD]"]) > 0 {
+		insights = append(insights, fmt.Sprintf("Captured %d gold moments! Celebrate these wins.", len(summary.PatternGroups["[GOLD]"])))
+	}
+
+	// Energy pattern insights (peak/trough windows)
+	insights = append(insights, EnergyInsights(summary.Energy)...)
+
+	// Drift insight
+	if summary.Drift != nil && summary.Drift.DriftCount > 0 && summary.Drift.DriftCount >= summary.DaysLogged {
+		insights = append(insights, fmt.Sprintf("%d drift gaps (%s unexplained). Press 'r' at a drift alert when you intentionally stepped away.",
+			summary.Drift.DriftCount, FormatHours(summary.Drift.DriftTime)))
+	}
+
+	// Follow-through insight
+	if ft := summary.FollowThrough; ft != nil && ft.Rated() >= 3 {
+		if ft.Rate() < 50 {
+			insights = append(insights, fmt.Sprintf("You followed through on %.0f%% of rated intentions. Try smaller, more specific intentions.", ft.Rate()))
+		} else if ft.Rate() >= 80 {
+			insights = append(insights, fmt.Sprintf("%.0f%% intention follow-through. Your morning plans are sticking.", ft.Rate()))
+		}
+	}
+
+	// Trend insights (only changes unlikely to be noise), against the broadest baseline
+	if n := len(summary.Comparisons); n > 0 {
+		insights = append(insights, ComparisonInsights(summary.Comparisons[n-1])...)
+	}
+
+	// Entry frequency insight (averaged over days actually logged)
+	avgPerDay := summary.AvgPerLoggedDay()
+	if summary.DaysLogged > 0 && summary.TotalDays > 0 && summary.DaysLogged*2 < summary.TotalDays {
+		insights = append(insights, fmt.Sprintf("You logged on %d of %d days. Consistency matters more than volume.", summary.DaysLogged, summary.TotalDays))
+	}
+	if avgPerDay < 3 {
+		insights = append(insights, "Log frequency is low. More frequent logs = better awareness and pattern detection.")
+	} else if avgPerDay > 15 {
+		insights = append(insights, "High logging frequency! You're building strong awareness habits.")
+	}
+
+	return insights
+}
@@ -1,37 +1,9 @@
 package analytics
 
 import (
-	"fmt"
 	"sort"
-	"strings"
 
 	"github.com/aaryareddy/log_cli/internal/database"
-	"github.com/charmbracelet/lipgloss"
-)
-
-// Color palette (duplicated from tui to avoid import cycle)
-var (
-	colorSuccess  = lipgloss.Color("#50FA7B") // Green
-	colorAccent   = lipgloss.Color("#8BE9FD") // Cyan
-	colorWarning  = lipgloss.Color("#FFB86C") // Orange
-	colorError    = lipgloss.Color("#FF5555") // Red
-	colorMetadata = lipgloss.Color("#BD93F9") // Purple
-	colorDim      = lipgloss.Color("#6272A4") // Gray
-	colorText     = lipgloss.Color("#D0D0D0") // Off-white
-)
-
-// Styles for pattern formatting
-var (
-	flowStyle     = lipgloss.NewStyle().Foreground(colorSuccess).Bold(true)
-	goldStyle     = lipgloss.NewStyle().Foreground(colorAccent).Bold(true)
-	stuckStyle    = lipgloss.NewStyle().Foreground(colorWarning).Bold(true)
-	leakStyle     = lipgloss.NewStyle().Foreground(colorError).Bold(true)
-	metadataStyle = lipgloss.NewStyle().Foreground(colorMetadata).Bold(true)
-	dimStyle      = lipgloss.NewStyle().Foreground(colorDim)
-	bodyStyle     = lipgloss.NewStyle().Foreground(colorText)
-	successStyle  = lipgloss.NewStyle().Foreground(colorSuccess).Bold(true)
-	warningStyle  = lipgloss.NewStyle().Foreground(colorWarning).Bold(true)
-	errorStyle    = lipgloss.NewStyle().Foreground(colorError).Bold(true)
 )
 
 // PatternGroup represents entries grouped by a specific pattern flag
@@ -42,11 +14,11 @@ type PatternGroup struct {
 
 // MomentumStats represents momentum distribution statistics
 type MomentumStats struct {
-	UpCount      int
-	DownCount    int
-	NeutralCount int
-	BackCount    int
-	TotalCount   int
+	UpCount      int `json:"up_count"`
+	DownCount    int `json:"down_count"`
+	NeutralCount int `json:"neutral_count"`
+	BackCount    int `json:"back_count"`
+	TotalCount   int `json:"total_count"`
 }
 
 // GroupByPatternFlag groups entries by pattern flags ([LEAK], [FLOW], [STUCK], [GOLD])
@@ -111,202 +83,111 @@ func GetWastePatterns(entries []*database.Entry) []*database.Entry {
 	return wasteEntries
 }
 
-// FormatPatternGroup formats a pattern group for display with colored box borders
-func FormatPatternGroup(flagType string, entries []*database.Entry) string {
-	if len(entries) == 0 {
-		return ""
-	}
-
-	var b strings.Builder
-
-	// Header with colored box border and description
-	var title, description, styledTitle string
-
-	switch flagType {
-	case "[LEAK]":
-		title = "LEAK PATTERNS"
-		description = "What pulled you off track"
-		styledTitle = leakStyle.Render("┌─ " + title)
-	case "[FLOW]":
-		title = "FLOW PATTERNS"
-		description = "When you were in the zone"
-		styledTitle = flowStyle.Render("┌─ " + title)
-	case "[STUCK]":
-		title = "STUCK PATTERNS"
-		description = "Where you got blocked"
-		styledTitle = stuckStyle.Render("┌─ " + title)
-	case "[GOLD]":
-		title = "GOLD PATTERNS"
-		description = "Wins and breakthroughs"
-		styledTitle = goldStyle.Render("┌─ " + title)
-	default:
-		title = fmt.Sprintf("%s PATTERNS", flagType)
-		description = ""
-		styledTitle = bodyStyle.Render("┌─ " + title)
-	}
-
-	// Fancy box border with colored title
-	b.WriteString(styledTitle)
-	b.WriteString("\n")
-	if description != "" {
-		b.WriteString(dimStyle.Render("│ " + description))
-		b.WriteString("\n")
-	}
-	b.WriteString(dimStyle.Render("├" + strings.Repeat("─", 59)))
-	b.WriteString("\n")
-
-	// Sort entries by timestamp
-	sortedEntries := make([]*database.Entry, len(entries))
-	copy(sortedEntries, entries)
-	sort.Slice(sortedEntries, func(i, j int) bool {
-		return sortedEntries[i].Timestamp.Before(sortedEntries[j].Timestamp)
-	})
-
-	// Format each entry with box prefix
-	for _, entry := range sortedEntries {
-		// Date and time
-		dateStr := entry.Timestamp.Format("Mon 1/2")
-		timeStr := entry.Timestamp.Format("3:04pm")
-		b.WriteString(dimStyle.Render("│ "))
-		b.WriteString(fmt.Sprintf("%s  %s | %s", dateStr, timeStr, entry.EntryText))
-
-		// Add momentum indicator if present
-		if entry.Momentum != nil {
-			switch *entry.Momentum {
-			case "up":
-				b.WriteString(" " + successStyle.Render("↑"))
-			case "down":
-				b.WriteString(" " + warningStyle.Render("↓"))
-			case "neutral":
-				b.WriteString(" " + bodyStyle.Render("→"))
-			case "back":
-				b.WriteString(" " + errorStyle.Render("←"))
-			}
-		}
-
-		// Add context tags (exclude the current flag)
-		for _, tag := range entry.Tags {
-			if tag.TagType == "context" {
-				b.WriteString(" " + dimStyle.Render(tag.TagValue))
-			}
-		}
+// WeeklyPatternSummary contains all pattern analysis for a week
+type WeeklyPatternSummary struct {
+	Kind          string // PeriodWeek, PeriodMonth or PeriodRange
+	Label         string // e.g., "2025-W41" or "October 2025"
+	StartDate     string
+	EndDate       string
+	TotalDays     int // Calendar days in the range
+	DaysLogged    int // Days with at least one entry
+	TotalEntries  int
+	PatternGroups map[string][]*database.Entry
+	MomentumStats *MomentumStats
+	WastePatterns []*database.Entry
+	TagCounts     map[string]int
+	TimeStats     *TimeStats
+	Energy        *EnergyMap // Optional; built over a longer window than the week
+	Correlations  *CorrelationReport
+	Themes        map[string][]Theme // Flag type → clustered entry themes
+	Drift         *DriftReport
+	Comparisons   []*Comparison  // Optional; vs previous periods (see BuildComparisons)
+	FollowThrough *FollowThrough // Optional; set by ApplyDays
+	entries       []*database.Entry
+}
 
-		b.WriteString("\n")
+// AnalyzeWeek performs comprehensive pattern analysis on a week of entries
+func AnalyzeWeek(entries []*database.Entry, startDate, endDate string) *WeeklyPatternSummary {
+	summary := &WeeklyPatternSummary{
+		Kind:          PeriodWeek,
+		StartDate:     startDate,
+		EndDate:       endDate,
+		TotalDays:     CalendarDaysBetween(startDate, endDate),
+		DaysLogged:    countDaysLogged(entries),
+		TotalEntries:  len(entries),
+		PatternGroups: GroupByPatternFlag(entries),
+		MomentumStats: CalculateMomentumStats(entries),
+		WastePatterns: GetWastePatterns(entries),
+		TagCounts:     CountContextTags(entries),
+		TimeStats:     CalculateTimeStats(entries, DefaultDriftThreshold),
+		Correlations:  AnalyzeCorrelations(entries),
+		entries:       entries,
 	}
-
-	// Closing border
-	b.WriteString(dimStyle.Render("└" + strings.Repeat("─", 59)))
-
-	return b.String()
+	summary.ClusterThemes(DefaultSynonyms)
+	summary.ApplyAwayIntervals(nil, DefaultDriftRules())
+	return summary
 }
 
-// FormatMomentumStats formats momentum statistics for display with colors and bars
-func FormatMomentumStats(stats *MomentumStats) string {
-	var b strings.Builder
-
-	// Colored header with double line divider
-	b.WriteString(metadataStyle.Render("MOMENTUM DISTRIBUTION"))
-	b.WriteString("\n")
-	b.WriteString(dimStyle.Render(strings.Repeat("━", 70)))
-	b.WriteString("\n\n")
-
-	// Calculate percentages
-	if stats.TotalCount > 0 {
-		upPct := float64(stats.UpCount) / float64(stats.TotalCount) * 100
-		downPct := float64(stats.DownCount) / float64(stats.TotalCount) * 100
-		neutralPct := float64(stats.NeutralCount) / float64(stats.TotalCount) * 100
-		backPct := float64(stats.BackCount) / float64(stats.TotalCount) * 100
-
-		// Color-coded momentum lines with visual bars
-		b.WriteString(successStyle.Render("↑") + " Productive   ")
-		b.WriteString(fmt.Sprintf("%2d (%.0f%%)  %s\n", stats.UpCount, upPct, generateMiniBar(upPct, 10, successStyle)))
-
-		b.WriteString(bodyStyle.Render("→") + " Neutral      ")
-		b.WriteString(fmt.Sprintf("%2d (%.0f%%)  %s\n", stats.NeutralCount, neutralPct, generateMiniBar(neutralPct, 10, bodyStyle)))
-
-		b.WriteString(warningStyle.Render("↓") + " Dragging     ")
-		b.WriteString(fmt.Sprintf("%2d (%.0f%%)  %s\n", stats.DownCount, downPct, generateMiniBar(downPct, 10, warningStyle)))
-
-		b.WriteString(errorStyle.Render("←") + " Waste        ")
-		b.WriteString(fmt.Sprintf("%2d (%.0f%%)  %s\n", stats.BackCount, backPct, generateMiniBar(backPct, 10, errorStyle)))
-	} else {
-		b.WriteString(dimStyle.Render("  No entries with momentum markers"))
-		b.WriteString("\n")
-	}
+// ApplyAwayIntervals recomputes drift using acknowledged "stepped away" intervals
+// and the given rules (until called, every long gap counts as unexplained drift)
+func (s *WeeklyPatternSummary) ApplyAwayIntervals(away []database.AwayInterval, rules DriftRules) {
+	s.Drift = BuildDriftReport(DetectGaps(s.entries, away, rules))
+}
 
-	return b.String()
+// ApplyEnergy builds the energy map from the entries of a longer window
+// ending with the period (see EnergyWindow)
+func (s *WeeklyPatternSummary) ApplyEnergy(entries []*database.Entry, window Period) {
+	s.Energy = BuildEnergyMap(entries, window.StartDate(), window.EndDate())
 }
 
-// generateMiniBar creates a small visual bar chart with color-coded filled portion
-func generateMiniBar(percentage float64, maxWidth int, filledStyle lipgloss.Style) string {
-	filled := int(percentage / 100.0 * float64(maxWidth))
-	if filled > maxWidth {
-		filled = maxWidth
-	}
-	if filled < 0 {
-		filled = 0
-	}
+// ApplyDays adds day-level reflections (intention follow-through) to the summary
+func (s *WeeklyPatternSummary) ApplyDays(days []*database.Day) {
+	s.FollowThrough = CalculateFollowThrough(days)
+}
 
-	empty := maxWidth - filled
-	filledBar := filledStyle.Render(strings.Repeat("█", filled))
-	emptyBar := dimStyle.Render(strings.Repeat("░", empty))
-	return filledBar + emptyBar
+// AnalyzePeriod performs pattern analysis over a calendar week, month or arbitrary range
+func AnalyzePeriod(entries []*database.Entry, period Period) *WeeklyPatternSummary {
+	summary := AnalyzeWeek(entries, period.StartDate(), period.EndDate())
+	summary.Kind = period.Kind
+	summary.Label = period.Label
+	return summary
 }
 
-// FormatWastePatterns formats waste pattern entries for display with warning colors
-func FormatWastePatterns(entries []*database.Entry) string {
-	if len(entries) == 0 {
-		return ""
+// AvgPerLoggedDay returns the average entries per day that had any logs
+func (s *WeeklyPatternSummary) AvgPerLoggedDay() float64 {
+	if s.DaysLogged == 0 {
+		return 0
 	}
+	return float64(s.TotalEntries) / float64(s.DaysLogged)
+}
 
-	var b strings.Builder
-
-	// Warning-styled header with double line divider
-	b.WriteString("\n")
-	b.WriteString(warningStyle.Render("WASTE PATTERNS"))
-	b.WriteString("\n")
-	b.WriteString(dimStyle.Render(strings.Repeat("━", 70)))
-	b.WriteString("\n")
-	b.WriteString(dimStyle.Render("Activities marked with ← (back arrow)"))
-	b.WriteString("\n\n")
-
-	// Sort by timestamp
-	sortedEntries := make([]*database.Entry, len(entries))
-	copy(sortedEntries, entries)
-	sort.Slice(sortedEntries, func(i, j int) bool {
-		return sortedEntries[i].Timestamp.Before(sortedEntries[j].Timestamp)
-	})
-
-	for _, entry := range sortedEntries {
-		dateStr := entry.Timestamp.Format("Mon 1/2")
-		timeStr := entry.Timestamp.Format("3:04pm")
-		b.WriteString(fmt.Sprintf("  %s  %s | %s ", dateStr, timeStr, entry.EntryText))
-		b.WriteString(errorStyle.Render("←"))
-		b.WriteString("\n")
+// AvgPerCalendarDay returns the average entries per calendar day in the range
+func (s *WeeklyPatternSummary) AvgPerCalendarDay() float64 {
+	if s.TotalDays == 0 {
+		return 0
 	}
-
-	return b.String()
+	return float64(s.TotalEntries) / float64(s.TotalDays)
 }
 
-// WeeklyPatternSummary contains all pattern analysis for a week
-type WeeklyPatternSummary struct {
-	StartDate      string
-	EndDate        string
-	TotalDays      int
-	TotalEntries   int
-	PatternGroups  map[string][]*database.Entry
-	MomentumStats  *MomentumStats
-	WastePatterns  []*database.Entry
+// countDaysLogged returns the number of distinct dates with entries
+func countDaysLogged(entries []*database.Entry) int {
+	dates := make(map[string]bool)
+	for _, entry := range entries {
+		dates[entry.Timestamp.Format("2006-01-02")] = true
+	}
+	return len(dates)
 }
 
-// AnalyzeWeek performs comprehensive pattern analysis on a week of entries
-func AnalyzeWeek(entries []*database.Entry, startDate, endDate string) *WeeklyPatternSummary {
-	return &WeeklyPatternSummary{
-		StartDate:     startDate,
-		EndDate:       endDate,
-		TotalEntries:  len(entries),
-		PatternGroups: GroupByPatternFlag(entries),
-		MomentumStats: CalculateMomentumStats(entries),
-		WastePatterns: GetWastePatterns(entries),
+// ClusterThemes groups the entries of each pattern flag into text themes
+// using the given synonym list (e.g., one loaded with LoadSynonyms)
+func (s *WeeklyPatternSummary) ClusterThemes(synonyms Synonyms) {
+	s.Themes = make(map[string][]Theme, len(s.PatternGroups))
+	for flagType, entries := range s.PatternGroups {
+		sorted := make([]*database.Entry, len(entries))
+		copy(sorted, entries)
+		sort.Slice(sorted, func(i, j int) bool {
+			return sorted[i].Timestamp.Before(sorted[j].Timestamp)
+		})
+		s.Themes[flagType] = ExtractThemes(sorted, synonyms)
 	}
 }
@@ -0,0 +1,225 @@
+package analytics
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Period kinds
+const (
+	PeriodWeek  = "week"
+	PeriodMonth = "month"
+	PeriodRange = "range"
+)
+
+// ConfigWeekStart is the config key for the first day of the week ("monday", "sunday", ...)
+const ConfigWeekStart = "week.start"
+
+// DefaultWeekStart is the first day of a calendar week (ISO 8601)
+const DefaultWeekStart = time.Monday
+
+// Period is an inclusive range of calendar days used for reviews and stats
+type Period struct {
+	Kind  string // PeriodWeek, PeriodMonth or PeriodRange
+	Start time.Time
+	End   time.Time
+	Label string // e.g., "2025-W41", "October 2025", "2025-10-01 to 2025-10-15"
+}
+
+// StartDate returns the first day in YYYY-MM-DD format
+func (p Period) StartDate() string {
+	return p.Start.Format("2006-01-02")
+}
+
+// EndDate returns the last day in YYYY-MM-DD format
+func (p Period) EndDate() string {
+	return p.End.Format("2006-01-02")
+}
+
+// CalendarDays returns the number of calendar days in the period
+func (p Period) CalendarDays() int {
+	return CalendarDaysBetween(p.StartDate(), p.EndDate())
+}
+
+// Previous returns the period of the same kind immediately before this one
+func (p Period) Previous(weekStart time.Weekday) Period {
+	switch p.Kind {
+	case PeriodWeek:
+		return WeekPeriod(p.Start.AddDate(0, 0, -7), weekStart)
+	case PeriodMonth:
+		return MonthPeriod(p.Start.AddDate(0, -1, 0))
+	default:
+		days := p.CalendarDays()
+		return RangePeriod(p.Start.AddDate(0, 0, -days), p.Start.AddDate(0, 0, -1))
+	}
+}
+
+// WeekPeriod returns the calendar week containing t, starting on weekStart
+func WeekPeriod(t time.Time, weekStart time.Weekday) Period {
+	day := truncateDay(t)
+	offset := (int(day.Weekday()) - int(weekStart) + 7) % 7
+	start := day.AddDate(0, 0, -offset)
+	end := start.AddDate(0, 0, 6)
+
+	label := fmt.Sprintf("%s to %s", start.Format("Jan 2"), end.Format("Jan 2, 2006"))
+	if weekStart == time.Monday {
+		year, week := start.ISOWeek()
+		label = fmt.Sprintf("%d-W%02d", year, week)
+	}
+
+	return Period{Kind: PeriodWeek, Start: start, End: end, Label: label}
+}
+
+// MonthPeriod returns the calendar month containing t
+func MonthPeriod(t time.Time) Period {
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.Local)
+	end := start.AddDate(0, 1, -1)
+	return Period{Kind: PeriodMonth, Start: start, End: end, Label: start.Format("January 2006")}
+}
+
+// RangePeriod returns an arbitrary inclusive day range
+func RangePeriod(from, to time.Time) Period {
+	start, end := truncateDay(from), truncateDay(to)
+	return Period{
+		Kind:  PeriodRange,
+		Start: start,
+		End:   end,
+		Label: fmt.Sprintf("%s to %s", start.Format("2006-01-02"), end.Format("2006-01-02")),
+	}
+}
+
+// ParseWeekArg parses a week argument relative to now:
+// "" (this week), "-N" (N weeks ago), "YYYY-Www" (ISO week) or "YYYY-MM-DD" (week containing date)
+func ParseWeekArg(arg string, now time.Time, weekStart time.Weekday) (Period, error) {
+	arg = strings.TrimSpace(arg)
+
+	switch {
+	case arg == "" || arg == "0":
+		return WeekPeriod(now, weekStart), nil
+
+	case strings.HasPrefix(arg, "-"):
+		n, err := strconv.Atoi(arg[1:])
+		if err != nil || n < 0 {
+			return Period{}, fmt.Errorf("invalid week offset: %s (use -1 for last week)", arg)
+		}
+		return WeekPeriod(now.AddDate(0, 0, -7*n), weekStart), nil
+
+	case strings.Contains(strings.ToUpper(arg), "-W"):
+		var year, week int
+		if _, err := fmt.Sscanf(strings.ToUpper(arg), "%d-W%d", &year, &week); err != nil {
+			return Period{}, fmt.Errorf("invalid ISO week: %s (use YYYY-Www, e.g. 2025-W41)", arg)
+		}
+		start, err := isoWeekStart(year, week)
+		if err != nil {
+			return Period{}, err
+		}
+		// ISO weeks always start on Monday
+		return WeekPeriod(start, time.Monday), nil
+
+	default:
+		date, err := time.ParseInLocation("2006-01-02", arg, time.Local)
+		if err != nil {
+			return Period{}, fmt.Errorf("invalid week: %s (use -N, YYYY-Www or YYYY-MM-DD)", arg)
+		}
+		return WeekPeriod(date, weekStart), nil
+	}
+}
+
+// ParseMonthArg parses a month argument relative to now:
+// "" (this month), "-N" (N months ago) or "YYYY-MM"
+func ParseMonthArg(arg string, now time.Time) (Period, error) {
+	arg = strings.TrimSpace(arg)
+
+	switch {
+	case arg == "" || arg == "0":
+		return MonthPeriod(now), nil
+
+	case strings.HasPrefix(arg, "-"):
+		n, err := strconv.Atoi(arg[1:])
+		if err != nil || n < 0 {
+			return Period{}, fmt.Errorf("invalid month offset: %s (use -1 for last month)", arg)
+		}
+		first := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+		return MonthPeriod(first.AddDate(0, -n, 0)), nil
+
+	default:
+		date, err := time.ParseInLocation("2006-01", arg, time.Local)
+		if err != nil {
+			return Period{}, fmt.Errorf("invalid month: %s (use -N or YYYY-MM)", arg)
+		}
+		return MonthPeriod(date), nil
+	}
+}
+
+// ParseRangeArgs parses --from/--to dates (YYYY-MM-DD). An empty to defaults to today;
+// an empty from defaults to 6 days before to.
+func ParseRangeArgs(from, to string, now time.Time) (Period, error) {
+	end := truncateDay(now)
+	if to != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", to, time.Local)
+		if err != nil {
+			return Period{}, fmt.Errorf("invalid --to date: %s (use YYYY-MM-DD)", to)
+		}
+		end = parsed
+	}
+
+	start := end.AddDate(0, 0, -6)
+	if from != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", from, time.Local)
+		if err != nil {
+			return Period{}, fmt.Errorf("invalid --from date: %s (use YYYY-MM-DD)", from)
+		}
+		start = parsed
+	}
+
+	if start.After(end) {
+		return Period{}, fmt.Errorf("--from (%s) is after --to (%s)", start.Format("2006-01-02"), end.Format("2006-01-02"))
+	}
+
+	return RangePeriod(start, end), nil
+}
+
+// ParseWeekStart parses a weekday name used as the first day of the week
+func ParseWeekStart(value string) (time.Weekday, error) {
+	if strings.TrimSpace(value) == "" {
+		return DefaultWeekStart, nil
+	}
+	days, err := ParseRestDays(value)
+	if err != nil || len(days) != 1 {
+		return DefaultWeekStart, fmt.Errorf("invalid week start: %q", value)
+	}
+	return days[0], nil
+}
+
+// CalendarDaysBetween returns the number of calendar days in an inclusive YYYY-MM-DD range
+func CalendarDaysBetween(startDate, endDate string) int {
+	start, err1 := time.ParseInLocation("2006-01-02", startDate, time.Local)
+	end, err2 := time.ParseInLocation("2006-01-02", endDate, time.Local)
+	if err1 != nil || err2 != nil || end.Before(start) {
+		return 0
+	}
+	days := 0
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		days++
+	}
+	return days
+}
+
+// isoWeekStart returns the Monday starting ISO week `week` of `year`
+func isoWeekStart(year, week int) (time.Time, error) {
+	if week < 1 || week > 53 {
+		return time.Time{}, fmt.Errorf("invalid ISO week number: %d", week)
+	}
+
+	// January 4th is always in week 1
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.Local)
+	offset := (int(jan4.Weekday()) + 6) % 7
+	start := jan4.AddDate(0, 0, -offset+(week-1)*7)
+
+	if y, w := start.ISOWeek(); y != year || w != week {
+		return time.Time{}, fmt.Errorf("%d has no ISO week %d", year, week)
+	}
+	return start, nil
+}
@@ -8,57 +8,52 @@ import (
 	"github.com/aaryareddy/log_cli/internal/database"
 )
 
-// FormatWeeklyStats formats weekly statistics for display
-// Now includes momentum distribution if momentumStats is provided
-func FormatWeeklyStats(stats *database.WeeklyStats, momentumStats *MomentumStats) string {
+// FormatWeeklyStats formats weekly statistics for display with the time-weighted
+// tag breakdown, plus the momentum distribution if momentumStats is provided
+func FormatWeeklyStats(stats *database.WeeklyStats, momentumStats *MomentumStats, timeStats *TimeStats) string {
+	return FormatWeeklyStatsMode(stats, momentumStats, timeStats, StatsByTime)
+}
+
+// FormatWeeklyStatsMode formats weekly statistics with the tag breakdown weighted
+// by time (timeStats) or by entry count, depending on mode
+func FormatWeeklyStatsMode(stats *database.WeeklyStats, momentumStats *MomentumStats, timeStats *TimeStats, mode StatsMode) string {
 	var b strings.Builder
 
-	b.WriteString("THIS WEEK\n")
+	// Header (rolling week by default, otherwise the requested range)
+	if stats.StartDate != "" {
+		b.WriteString(fmt.Sprintf("%s TO %s\n", stats.StartDate, stats.EndDate))
+	} else {
+		b.WriteString("THIS WEEK\n")
+	}
 	b.WriteString(strings.Repeat("─", 40))
 	b.WriteString("\n\n")
 
-	// Total entries
-	avgPerDay := float64(stats.TotalEntries) / 7.0
+	// Total entries, averaged over days actually logged and over calendar days
+	calendarDays := stats.CalendarDays
+	if calendarDays == 0 {
+		calendarDays = 7
+	}
 	b.WriteString(fmt.Sprintf("Total logs:  %d\n", stats.TotalEntries))
-	b.WriteString(fmt.Sprintf("Avg per day: %.1f\n\n", avgPerDay))
+	b.WriteString(fmt.Sprintf("Days logged: %d of %d\n", stats.DaysLogged, calendarDays))
+	if stats.DaysLogged > 0 {
+		b.WriteString(fmt.Sprintf("Avg per day: %.1f logged · %.1f calendar\n\n",
+			float64(stats.TotalEntries)/float64(stats.DaysLogged),
+			float64(stats.TotalEntries)/float64(calendarDays)))
+	} else {
+		b.WriteString(fmt.Sprintf("Avg per day: %.1f\n\n", float64(stats.TotalEntries)/float64(calendarDays)))
+	}
 
 	// Tag distribution
-	if len(stats.TagCounts) > 0 {
-		b.WriteString("YOUR TIME THIS WEEK:\n\n")
-
-		// Calculate total tags for percentages
-		totalTags := 0
-		for _, count := range stats.TagCounts {
-			totalTags += count
-		}
-
-		// Sort tags by count (descending)
-		type tagCount struct {
-			tag   string
-			count int
-		}
-		var tags []tagCount
-		for tag, count := range stats.TagCounts {
-			tags = append(tags, tagCount{tag, count})
-		}
-		sort.Slice(tags, func(i, j int) bool {
-			return tags[i].count > tags[j].count
-		})
-
-		// Display each tag with bar chart
-		for _, tc := range tags {
-			percentage := float64(tc.count) / float64(totalTags) * 100
-			bar := generateBar(percentage, 20)
-			b.WriteString(fmt.Sprintf("%-10s %s %.0f%%\n", tc.tag, bar, percentage))
-		}
+	if mode == StatsByTime {
+		b.WriteString(FormatTimeStats(timeStats))
 	} else {
-		b.WriteString("No context tags logged this week\n")
+		b.WriteString(FormatTagCounts(stats.TagCounts))
 	}
 
 	// Momentum distribution (if provided)
 	if momentumStats != nil && (momentumStats.UpCount > 0 || momentumStats.DownCount > 0 || momentumStats.NeutralCount > 0 || momentumStats.BackCount > 0) {
 		b.WriteString("\n")
-		b.WriteString("MOMENTUM THIS WEEK:\n\n")
+		b.WriteString("MOMENTUM:\n\n")
 
 		totalWithMomentum := momentumStats.UpCount + momentumStats.DownCount + momentumStats.NeutralCount + momentumStats.BackCount
 
@@ -79,6 +74,59 @@ func FormatWeeklyStats(stats *database.WeeklyStats, momentumStats *MomentumStats
 	return b.String()
 }
 
+// FormatTagCounts formats the count-based tag distribution with bar charts
+func FormatTagCounts(tagCounts map[string]int) string {
+	var b strings.Builder
+
+	if len(tagCounts) == 0 {
+		b.WriteString("No context tags logged in this period\n")
+		return b.String()
+	}
+
+	b.WriteString("YOUR TIME (by entry count):\n\n")
+
+	// Calculate total tags for percentages
+	totalTags := 0
+	for _, count := range tagCounts {
+		totalTags += count
+	}
+
+	// Sort tags by count (descending)
+	type tagCount struct {
+		tag   string
+		count int
+	}
+	var tags []tagCount
+	for tag, count := range tagCounts {
+		tags = append(tags, tagCount{tag, count})
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].count > tags[j].count
+	})
+
+	// Display each tag with bar chart
+	for _, tc := range tags {
+		percentage := float64(tc.count) / float64(totalTags) * 100
+		bar := generateBar(percentage, 20)
+		b.WriteString(fmt.Sprintf("%-10s %s %.0f%%\n", tc.tag, bar, percentage))
+	}
+
+	return b.String()
+}
+
+// CountContextTags counts context tag occurrences across entries (excluding @signoff)
+func CountContextTags(entries []*database.Entry) map[string]int {
+	counts := make(map[string]int)
+	for _, entry := range entries {
+		for _, tag := range entry.Tags {
+			if tag.TagType == "context" && tag.TagValue != string(database.TagSignoff) {
+				counts[tag.TagValue]++
+			}
+		}
+	}
+	return counts
+}
+
 // generateBar creates an ASCII bar chart
 func generateBar(percentage float64, maxWidth int) string {
 	filled := int(percentage / 100.0 * float64(maxWidth))
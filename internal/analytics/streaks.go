@@ -0,0 +1,299 @@
+package analytics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aaryareddy/log_cli/internal/database"
+)
+
+const (
+	// DefaultConsistencyWindowDays is how many days the consistency score looks back
+	DefaultConsistencyWindowDays = 30
+	// targetEntriesPerDay is the entry count that earns full marks for logging density
+	targetEntriesPerDay = 8
+)
+
+// Config keys for streak rules and personal bests (stored in the config table)
+const (
+	ConfigStreakSkipWeekends  = "streaks.skip_weekends"
+	ConfigStreakRestDays      = "streaks.rest_days"
+	ConfigStreakBestLogging   = "streaks.best_logging"
+	ConfigStreakBestSignoff   = "streaks.best_signoff"
+	ConfigStreakBestIntention = "streaks.best_intention"
+)
+
+// StreakMilestones are the streak lengths worth celebrating
+var StreakMilestones = []int{3, 7, 14, 21, 30, 50, 100, 200, 365}
+
+// StreakRules controls which days are expected to have logs
+type StreakRules struct {
+	SkipWeekends bool           // Saturdays and Sundays never break a streak
+	RestDays     []time.Weekday // Additional weekdays that never break a streak
+}
+
+// isRestDay reports whether a missing log on this date is allowed
+func (r StreakRules) isRestDay(date time.Time) bool {
+	wd := date.Weekday()
+	if r.SkipWeekends && (wd == time.Saturday || wd == time.Sunday) {
+		return true
+	}
+	for _, rest := range r.RestDays {
+		if wd == rest {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseRestDays parses a comma-separated list of weekday names ("sat,sun" or "Saturday")
+func ParseRestDays(value string) ([]time.Weekday, error) {
+	var days []time.Weekday
+	for _, part := range strings.Split(value, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" {
+			continue
+		}
+		found := false
+		for wd := time.Sunday; wd <= time.Saturday; wd++ {
+			name := strings.ToLower(wd.String())
+			if part == name || part == name[:3] {
+				days = append(days, wd)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown weekday: %q", part)
+		}
+	}
+	return days, nil
+}
+
+// Streak holds current and longest run lengths for one habit
+type Streak struct {
+	Current      int
+	Longest      int
+	LongestStart string // YYYY-MM-DD
+	LongestEnd   string // YYYY-MM-DD
+}
+
+// StreakRecords are the persisted personal bests, used to detect broken records
+type StreakRecords struct {
+	Logging   int
+	Signoff   int
+	Intention int
+}
+
+// StreakReport contains all streak and consistency statistics
+type StreakReport struct {
+	Logging      Streak // Days with at least one entry
+	Signoff      Streak // Days completed with @signoff
+	Intention    Streak // Days with a morning intention set
+	Consistency  float64
+	ActiveDays   int // Days logged within the consistency window
+	ExpectedDays int // Non-rest days within the consistency window
+	AvgEntries   float64
+	DriftGaps    int
+}
+
+// CalculateStreaks computes logging, sign-off and intention streaks up to today,
+// plus a 0-100 consistency score over the last DefaultConsistencyWindowDays days.
+// Today only extends a streak once it has been logged; it never breaks one.
+func CalculateStreaks(days []*database.Day, entries []*database.Entry, today time.Time, rules StreakRules) *StreakReport {
+	entryCounts := make(map[string]int)
+	for _, entry := range entries {
+		entryCounts[entry.Timestamp.Format("2006-01-02")]++
+	}
+
+	signedOff := make(map[string]bool)
+	intended := make(map[string]bool)
+	var first time.Time
+	for _, day := range days {
+		key := day.Date.Format("2006-01-02")
+		if day.Completed {
+			signedOff[key] = true
+		}
+		if day.Intention != nil && strings.TrimSpace(*day.Intention) != "" {
+			intended[key] = true
+		}
+		if first.IsZero() || day.Date.Before(first) {
+			first = day.Date
+		}
+	}
+
+	report := &StreakReport{
+		Logging:   computeStreak(first, today, rules, func(k string) bool { return entryCounts[k] > 0 }),
+		Signoff:   computeStreak(first, today, rules, func(k string) bool { return signedOff[k] }),
+		Intention: computeStreak(first, today, rules, func(k string) bool { return intended[k] }),
+	}
+
+	report.calculateConsistency(entries, entryCounts, today, rules)
+
+	return report
+}
+
+// computeStreak walks calendar days from first to today, tracking runs of days that
+// satisfy done. Rest days without activity neither extend nor break a run.
+func computeStreak(first, today time.Time, rules StreakRules, done func(string) bool) Streak {
+	var s Streak
+	if first.IsZero() {
+		return s
+	}
+
+	start := truncateDay(first)
+	end := truncateDay(today)
+	run := 0
+	var runStart string
+
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		key := d.Format("2006-01-02")
+		isToday := d.Equal(end)
+
+		switch {
+		case done(key):
+			if run == 0 {
+				runStart = key
+			}
+			run++
+			if run > s.Longest {
+				s.Longest = run
+				s.LongestStart = runStart
+				s.LongestEnd = key
+			}
+		case rules.isRestDay(d) || isToday:
+			// Allowed gap - keep the run going
+		default:
+			run = 0
+		}
+	}
+
+	s.Current = run
+	return s
+}
+
+// calculateConsistency scores the recent window on days logged (50%), entry density (25%)
+// and how rarely consecutive entries were separated by drift-length gaps (25%)
+func (r *StreakReport) calculateConsistency(entries []*database.Entry, entryCounts map[string]int, today time.Time, rules StreakRules) {
+	end := truncateDay(today)
+	start := end.AddDate(0, 0, -(DefaultConsistencyWindowDays - 1))
+
+	totalEntries := 0
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		key := d.Format("2006-01-02")
+		count := entryCounts[key]
+		if count > 0 {
+			r.ActiveDays++
+			totalEntries += count
+		}
+		if !rules.isRestDay(d) {
+			r.ExpectedDays++
+		}
+	}
+
+	if r.ActiveDays == 0 {
+		return
+	}
+	r.AvgEntries = float64(totalEntries) / float64(r.ActiveDays)
+
+	// Drift gaps between consecutive same-day entries in the window
+	var windowEntries []*database.Entry
+	for _, entry := range entries {
+		if !entry.Timestamp.Before(start) {
+			windowEntries = append(windowEntries, entry)
+		}
+	}
+	sort.Slice(windowEntries, func(i, j int) bool {
+		return windowEntries[i].Timestamp.Before(windowEntries[j].Timestamp)
+	})
+	gaps := 0
+	for i := 0; i+1 < len(windowEntries); i++ {
+		cur, next := windowEntries[i].Timestamp, windowEntries[i+1].Timestamp
+		if !sameDay(cur, next) {
+			continue
+		}
+		gaps++
+		if next.Sub(cur) >= DefaultDriftThreshold {
+			r.DriftGaps++
+		}
+	}
+
+	dayScore := 1.0
+	if r.ExpectedDays > 0 {
+		dayScore = minFloat(float64(r.ActiveDays)/float64(r.ExpectedDays), 1)
+	}
+	densityScore := minFloat(r.AvgEntries/targetEntriesPerDay, 1)
+	driftScore := 1.0
+	if gaps > 0 {
+		driftScore = 1 - float64(r.DriftGaps)/float64(gaps)
+	}
+
+	r.Consistency = (dayScore*0.5 + densityScore*0.25 + driftScore*0.25) * 100
+}
+
+// Records returns the longest streaks as persistable personal bests
+func (r *StreakReport) Records() StreakRecords {
+	return StreakRecords{
+		Logging:   r.Logging.Longest,
+		Signoff:   r.Signoff.Longest,
+		Intention: r.Intention.Longest,
+	}
+}
+
+// BrokenRecords compares current streaks against previous personal bests and returns
+// a celebration message for each record that was beaten at a milestone
+func (r *StreakReport) BrokenRecords(previous StreakRecords) []string {
+	var messages []string
+
+	check := func(name string, current, prev int) {
+		if current <= prev {
+			return
+		}
+		if m := reachedMilestone(prev, current); m > 0 {
+			messages = append(messages, fmt.Sprintf("New record: %d-day %s streak!", current, name))
+		}
+	}
+
+	check("logging", r.Logging.Current, previous.Logging)
+	check("sign-off", r.Signoff.Current, previous.Signoff)
+	check("intention", r.Intention.Current, previous.Intention)
+
+	return messages
+}
+
+// reachedMilestone returns the highest milestone crossed going from prev to current, or 0
+func reachedMilestone(prev, current int) int {
+	reached := 0
+	for _, m := range StreakMilestones {
+		if prev < m && current >= m {
+			reached = m
+		}
+	}
+	return reached
+}
+
+// NextMilestone returns the next milestone above n, or 0 if all are reached
+func NextMilestone(n int) int {
+	for _, m := range StreakMilestones {
+		if m > n {
+			return m
+		}
+	}
+	return 0
+}
+
+// truncateDay returns midnight (local) for the given time
+func truncateDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+}
+
+// minFloat returns the smaller of two floats
+func minFloat(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
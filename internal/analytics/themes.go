@@ -0,0 +1,369 @@
+package analytics
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/aaryareddy/log_cli/internal/database"
+	"github.com/aaryareddy/log_cli/internal/parser"
+)
+
+// themeSimilarityThreshold is the minimum cosine similarity for two entries to share a theme
+const themeSimilarityThreshold = 0.3
+
+// Theme is a cluster of similar entries within a pattern group
+type Theme struct {
+	Label    string            // Readable label built from the top keywords
+	Keywords []string          // Top stemmed keywords (after synonym mapping)
+	Entries  []*database.Entry // Members of the theme
+}
+
+// Count returns the number of entries in the theme
+func (t Theme) Count() int {
+	return len(t.Entries)
+}
+
+// Synonyms maps words (already lowercased) to a canonical term
+type Synonyms map[string]string
+
+// DefaultSynonyms covers the most common distraction and work vocabulary
+var DefaultSynonyms = Synonyms{
+	"twitter":   "social media",
+	"x":         "social media",
+	"instagram": "social media",
+	"reddit":    "social media",
+	"tiktok":    "social media",
+	"facebook":  "social media",
+	"scrolling": "social media",
+	"youtube":   "videos",
+	"video":     "videos",
+	"netflix":   "videos",
+	"news":      "news",
+	"headlines": "news",
+	"email":     "email",
+	"emails":    "email",
+	"inbox":     "email",
+	"gmail":     "email",
+	"slack":     "chat",
+	"messages":  "chat",
+	"texting":   "chat",
+	"article":   "articles",
+	"blog":      "articles",
+	"meeting":   "meetings",
+	"call":      "meetings",
+	"standup":   "meetings",
+	"sync":      "meetings",
+}
+
+// LoadSynonyms reads a user-editable synonym file and merges it over the defaults.
+// Each line has the form "canonical: word, other word" and # starts a comment.
+// A missing file is not an error.
+func LoadSynonyms(path string) (Synonyms, error) {
+	synonyms := make(Synonyms, len(DefaultSynonyms))
+	for k, v := range DefaultSynonyms {
+		synonyms[k] = v
+	}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return synonyms, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open synonyms file: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		canonical, words, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid synonym on line %d: expected \"canonical: word, word\"", lineNum)
+		}
+		canonical = strings.ToLower(strings.TrimSpace(canonical))
+		for _, word := range strings.Split(words, ",") {
+			word = strings.ToLower(strings.TrimSpace(word))
+			if word != "" {
+				synonyms[word] = canonical
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading synonyms file: %w", err)
+	}
+
+	return synonyms, nil
+}
+
+// stopWords are ignored when extracting keywords
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "the": true, "to": true, "of": true,
+	"in": true, "on": true, "for": true, "with": true, "at": true, "from": true,
+	"by": true, "about": true, "into": true, "is": true, "was": true, "are": true,
+	"be": true, "been": true, "it": true, "its": true, "this": true, "that": true,
+	"my": true, "me": true, "i": true, "im": true, "we": true, "our": true,
+	"some": true, "more": true, "again": true, "still": true, "just": true,
+	"got": true, "get": true, "getting": true, "went": true, "go": true,
+	"then": true, "now": true, "bit": true, "too": true, "very": true, "up": true,
+	"many": true, "much": true, "all": true, "day": true, "today": true, "lot": true,
+}
+
+// ExtractThemes clusters entries by text similarity. Entry text is normalized into
+// keywords (lowercased, stop words removed, synonyms applied, stemmed), weighted by
+// TF-IDF, and grouped greedily by cosine similarity. Themes are sorted by size.
+func ExtractThemes(entries []*database.Entry, synonyms Synonyms) []Theme {
+	if len(entries) == 0 {
+		return nil
+	}
+	if synonyms == nil {
+		synonyms = DefaultSynonyms
+	}
+
+	// Tokenize each entry
+	docs := make([][]string, len(entries))
+	docFreq := make(map[string]int)
+	for i, entry := range entries {
+		docs[i] = Keywords(entry.EntryText, synonyms)
+		seen := make(map[string]bool)
+		for _, term := range docs[i] {
+			if !seen[term] {
+				docFreq[term]++
+				seen[term] = true
+			}
+		}
+	}
+
+	// TF-IDF vectors
+	vectors := make([]map[string]float64, len(entries))
+	for i, doc := range docs {
+		vectors[i] = tfidf(doc, docFreq, len(entries))
+	}
+
+	// Greedy single-pass clustering against cluster centroids
+	type cluster struct {
+		members  []int
+		centroid map[string]float64
+	}
+	var clusters []*cluster
+
+	for i, vec := range vectors {
+		best, bestSim := -1, 0.0
+		for c, cl := range clusters {
+			if sim := cosine(vec, cl.centroid); sim > bestSim {
+				best, bestSim = c, sim
+			}
+		}
+
+		if best >= 0 && bestSim >= themeSimilarityThreshold {
+			cl := clusters[best]
+			cl.members = append(cl.members, i)
+			cl.centroid = centroid(vectors, cl.members)
+		} else {
+			clusters = append(clusters, &cluster{members: []int{i}, centroid: vec})
+		}
+	}
+
+	themes := make([]Theme, 0, len(clusters))
+	for _, cl := range clusters {
+		theme := Theme{Keywords: topTerms(cl.centroid, 2)}
+		for _, idx := range cl.members {
+			theme.Entries = append(theme.Entries, entries[idx])
+		}
+		if allPrivate(theme.Entries) {
+			theme.Keywords = nil // Drawn from private text alone
+		}
+		theme.Label = themeLabel(theme)
+		themes = append(themes, theme)
+	}
+
+	sort.SliceStable(themes, func(i, j int) bool {
+		return len(themes[i].Entries) > len(themes[j].Entries)
+	})
+
+	return themes
+}
+
+// Keywords normalizes text into stemmed keywords with synonyms applied
+func Keywords(text string, synonyms Synonyms) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	var keywords []string
+	for _, word := range words {
+		if canonical, ok := synonyms[word]; ok {
+			keywords = append(keywords, canonical)
+			continue
+		}
+		if stopWords[word] || len(word) < 2 {
+			continue
+		}
+		stem := Stem(word)
+		if canonical, ok := synonyms[stem]; ok {
+			stem = canonical
+		}
+		keywords = append(keywords, stem)
+	}
+
+	return keywords
+}
+
+// Stem reduces a word to a rough stem by stripping common English suffixes.
+// It is intentionally light - good enough to merge "reading"/"reads"/"read".
+func Stem(word string) string {
+	if len(word) <= 3 {
+		return word
+	}
+
+	for _, suffix := range []string{"ational", "ization", "fulness", "ousness", "iveness"} {
+		if strings.HasSuffix(word, suffix) && len(word)-len(suffix) >= 3 {
+			return word[:len(word)-len(suffix)]
+		}
+	}
+
+	switch {
+	case strings.HasSuffix(word, "ies") && len(word) > 4:
+		return word[:len(word)-3] + "y"
+	case strings.HasSuffix(word, "sses"):
+		return word[:len(word)-2]
+	case strings.HasSuffix(word, "ss"):
+		return word
+	}
+
+	for _, suffix := range []string{"ingly", "edly", "ing", "ed", "ly", "es", "s"} {
+		if !strings.HasSuffix(word, suffix) {
+			continue
+		}
+		stem := word[:len(word)-len(suffix)]
+		if len(stem) < 3 {
+			continue
+		}
+		// "es" is only a plural ending after sibilants ("boxes", "watches"); "articles" drops just "s"
+		if suffix == "es" && !hasSibilantEnding(stem) {
+			continue
+		}
+		// Undo doubled consonants ("running" → "run")
+		if n := len(stem); n >= 2 && stem[n-1] == stem[n-2] && !strings.ContainsRune("aeiouls", rune(stem[n-1])) {
+			stem = stem[:n-1]
+		}
+		return stem
+	}
+
+	return word
+}
+
+// hasSibilantEnding reports whether a stem ends in s, x, z, ch or sh
+func hasSibilantEnding(stem string) bool {
+	for _, end := range []string{"s", "x", "z", "ch", "sh"} {
+		if strings.HasSuffix(stem, end) {
+			return true
+		}
+	}
+	return false
+}
+
+// tfidf builds a TF-IDF weighted term vector for a document
+func tfidf(doc []string, docFreq map[string]int, numDocs int) map[string]float64 {
+	vec := make(map[string]float64)
+	if len(doc) == 0 {
+		return vec
+	}
+
+	for _, term := range doc {
+		vec[term]++
+	}
+	for term, tf := range vec {
+		// Smoothed IDF so terms shared by every entry still carry some weight
+		idf := math.Log(float64(1+numDocs)/float64(1+docFreq[term])) + 1
+		vec[term] = tf / float64(len(doc)) * idf
+	}
+
+	return vec
+}
+
+// cosine returns the cosine similarity between two sparse vectors
+func cosine(a, b map[string]float64) float64 {
+	var dot, normA, normB float64
+	for term, wa := range a {
+		normA += wa * wa
+		if wb, ok := b[term]; ok {
+			dot += wa * wb
+		}
+	}
+	for _, wb := range b {
+		normB += wb * wb
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// centroid averages the vectors of the given members
+func centroid(vectors []map[string]float64, members []int) map[string]float64 {
+	c := make(map[string]float64)
+	for _, idx := range members {
+		for term, w := range vectors[idx] {
+			c[term] += w
+		}
+	}
+	for term := range c {
+		c[term] /= float64(len(members))
+	}
+	return c
+}
+
+// topTerms returns the n highest-weighted terms in a vector
+func topTerms(vec map[string]float64, n int) []string {
+	terms := make([]string, 0, len(vec))
+	for term := range vec {
+		terms = append(terms, term)
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		if vec[terms[i]] == vec[terms[j]] {
+			return terms[i] < terms[j]
+		}
+		return vec[terms[i]] > vec[terms[j]]
+	})
+	if len(terms) > n {
+		terms = terms[:n]
+	}
+	return terms
+}
+
+// themeLabel builds a display label, preferring the member text when the theme
+// has one entry. A theme of private entries is labeled by the placeholder.
+func themeLabel(theme Theme) string {
+	if allPrivate(theme.Entries) {
+		return parser.PrivatePlaceholder
+	}
+	if len(theme.Entries) == 1 || len(theme.Keywords) == 0 {
+		return theme.Entries[0].EntryText
+	}
+	label := strings.Join(theme.Keywords, " ")
+	first, size := utf8.DecodeRuneInString(label)
+	return string(unicode.ToUpper(first)) + label[size:]
+}
+
+// allPrivate reports whether every entry is private
+func allPrivate(entries []*database.Entry) bool {
+	for _, entry := range entries {
+		if !entry.Private {
+			return false
+		}
+	}
+	return len(entries) > 0
+}
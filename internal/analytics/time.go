@@ -0,0 +1,245 @@
+package analytics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aaryareddy/log_cli/internal/database"
+)
+
+// DefaultDriftThreshold is the longest gap an entry can claim before the
+// time is treated as unlogged (matches the drift alert in the log TUI)
+const DefaultDriftThreshold = 90 * time.Minute
+
+// StatsMode selects how "YOUR TIME" breakdowns are weighted
+type StatsMode int
+
+const (
+	// StatsByTime weights each entry by the time until the next entry
+	StatsByTime StatsMode = iota
+	// StatsByCount weights every entry equally (the original view)
+	StatsByCount
+)
+
+// ConfigStatsMode is the config key for the default tag breakdown of
+// `log stats` and `log week`: "time" or "count"
+const ConfigStatsMode = "stats.mode"
+
+// String returns the mode's config value
+func (m StatsMode) String() string {
+	if m == StatsByCount {
+		return "count"
+	}
+	return "time"
+}
+
+// ParseStatsMode parses "time" or "count"
+func ParseStatsMode(value string) (StatsMode, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "time":
+		return StatsByTime, nil
+	case "count":
+		return StatsByCount, nil
+	}
+	return StatsByTime, fmt.Errorf("invalid stats mode %q (use time or count)", value)
+}
+
+// TimeStats holds time-weighted totals derived from gaps between entries
+type TimeStats struct {
+	Total      time.Duration
+	ByTag      map[string]time.Duration // An entry's time is split across its tags
+	ByMomentum map[string]time.Duration
+	ByFlag     map[string]time.Duration
+	Untagged   time.Duration // Time on entries without a context tag
+}
+
+// EntryDuration pairs an entry with the time attributed to it
+type EntryDuration struct {
+	Entry    *database.Entry
+	Duration time.Duration
+}
+
+// ComputeEntryDurations attributes time to each entry based on the gap until the
+// next entry on the same day. Gaps are capped at threshold (anything longer is
+// drift, not activity), and the @signoff entry ends the working day.
+func ComputeEntryDurations(entries []*database.Entry, threshold time.Duration) []EntryDuration {
+	if threshold <= 0 {
+		threshold = DefaultDriftThreshold
+	}
+
+	sorted := make([]*database.Entry, len(entries))
+	copy(sorted, entries)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp.Before(sorted[j].Timestamp)
+	})
+
+	durations := make([]EntryDuration, len(sorted))
+	for i, entry := range sorted {
+		durations[i].Entry = entry
+
+		// Sign-off closes the day - it doesn't represent activity itself
+		if hasTag(entry, string(database.TagSignoff)) {
+			continue
+		}
+
+		// Last entry of the log has no end point
+		if i == len(sorted)-1 {
+			continue
+		}
+
+		next := sorted[i+1]
+		if !sameDay(entry.Timestamp, next.Timestamp) {
+			continue
+		}
+
+		gap := next.Timestamp.Sub(entry.Timestamp)
+		if gap > threshold {
+			gap = threshold
+		}
+		if gap > 0 {
+			durations[i].Duration = gap
+		}
+	}
+
+	return durations
+}
+
+// CalculateTimeStats sums entry durations per context tag, momentum and flag.
+// An entry with several context tags gives each an equal share, so tag hours
+// add up to the tracked total.
+func CalculateTimeStats(entries []*database.Entry, threshold time.Duration) *TimeStats {
+	stats := &TimeStats{
+		ByTag:      make(map[string]time.Duration),
+		ByMomentum: make(map[string]time.Duration),
+		ByFlag:     make(map[string]time.Duration),
+	}
+
+	for _, ed := range ComputeEntryDurations(entries, threshold) {
+		if ed.Duration == 0 {
+			continue
+		}
+		stats.Total += ed.Duration
+
+		if ed.Entry.Momentum != nil {
+			stats.ByMomentum[*ed.Entry.Momentum] += ed.Duration
+		}
+
+		var contexts []string
+		for _, tag := range ed.Entry.Tags {
+			switch tag.TagType {
+			case "context":
+				if tag.TagValue != string(database.TagSignoff) {
+					contexts = append(contexts, tag.TagValue)
+				}
+			case "flag":
+				stats.ByFlag[tag.TagValue] += ed.Duration
+			}
+		}
+		if len(contexts) == 0 {
+			stats.Untagged += ed.Duration
+		}
+		for _, tag := range contexts {
+			stats.ByTag[tag] += ed.Duration / time.Duration(len(contexts))
+		}
+	}
+
+	return stats
+}
+
+// FormatTimeStats formats time-weighted statistics as hours per tag, momentum and flag
+func FormatTimeStats(stats *TimeStats) string {
+	var b strings.Builder
+
+	b.WriteString("YOUR TIME:\n\n")
+
+	if stats == nil || stats.Total == 0 {
+		b.WriteString("Not enough consecutive entries to estimate time yet\n")
+		return b.String()
+	}
+
+	b.WriteString(fmt.Sprintf("Tracked time: %s\n\n", FormatHours(stats.Total)))
+
+	tagTotals := make(map[string]time.Duration, len(stats.ByTag)+1)
+	for tag, d := range stats.ByTag {
+		tagTotals[tag] = d
+	}
+	if stats.Untagged > 0 {
+		tagTotals["untagged"] = stats.Untagged
+	}
+	writeDurationBars(&b, tagTotals)
+
+	if len(stats.ByMomentum) > 0 {
+		b.WriteString("\nBY MOMENTUM:\n\n")
+		for _, m := range []struct{ key, label string }{
+			{"up", "↑ Productive:"},
+			{"neutral", "→ Neutral:"},
+			{"down", "↓ Dragging:"},
+			{"back", "← Waste:"},
+		} {
+			if d, ok := stats.ByMomentum[m.key]; ok {
+				b.WriteString(fmt.Sprintf("%-14s %s\n", m.label, FormatHours(d)))
+			}
+		}
+	}
+
+	if len(stats.ByFlag) > 0 {
+		b.WriteString("\nBY FLAG:\n\n")
+		writeDurationBars(&b, stats.ByFlag)
+	}
+
+	return b.String()
+}
+
+// writeDurationBars writes one bar per key, sorted by duration (descending)
+func writeDurationBars(b *strings.Builder, totals map[string]time.Duration) {
+	type keyDuration struct {
+		key string
+		d   time.Duration
+	}
+
+	var sum time.Duration
+	var rows []keyDuration
+	for k, d := range totals {
+		rows = append(rows, keyDuration{k, d})
+		sum += d
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].d == rows[j].d {
+			return rows[i].key < rows[j].key
+		}
+		return rows[i].d > rows[j].d
+	})
+
+	for _, row := range rows {
+		percentage := float64(row.d) / float64(sum) * 100
+		bar := generateBar(percentage, 20)
+		b.WriteString(fmt.Sprintf("%-10s %s %6s %3.0f%%\n", row.key, bar, FormatHours(row.d), percentage))
+	}
+}
+
+// FormatHours formats a duration as decimal hours (e.g., "2.5h") or minutes under an hour
+func FormatHours(d time.Duration) string {
+	if d < time.Hour {
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	return fmt.Sprintf("%.1fh", d.Hours())
+}
+
+// hasTag reports whether an entry carries the given tag value
+func hasTag(entry *database.Entry, value string) bool {
+	for _, tag := range entry.Tags {
+		if tag.TagValue == value {
+			return true
+		}
+	}
+	return false
+}
+
+// sameDay reports whether two timestamps fall on the same calendar day
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aaryareddy/log_cli/internal/database"
+)
+
+// RunCommand handles `log config <subcommand>`:
+//
+//	get <key>            resolved value
+//	set <key> <value>    store in the config table (validated)
+//	unset <key>          remove from the config table
+//	list                 every setting with its value and source
+func RunCommand(c *Config, store *database.Store, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		args = []string{"list"}
+	}
+
+	switch args[0] {
+	case "get":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: log config get <key>")
+		}
+		if _, ok := Lookup(args[1]); !ok {
+			return unknownKey(args[1])
+		}
+		value, _, _ := c.Get(args[1])
+		fmt.Fprintln(out, value)
+		return nil
+
+	case "set":
+		if len(args) < 3 {
+			return fmt.Errorf("usage: log config set <key> <value>")
+		}
+		return c.Set(store, args[1], strings.Join(args[2:], " "))
+
+	case "unset":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: log config unset <key>")
+		}
+		return c.Unset(store, args[1])
+
+	case "list":
+		fmt.Fprint(out, FormatList(c))
+		return nil
+	}
+
+	return fmt.Errorf("unknown config command: %q (use get, set, unset or list)", args[0])
+}
+
+// Set validates and stores a value in the config table
+func (c *Config) Set(store *database.Store, key, value string) error {
+	s, ok := Lookup(key)
+	if !ok {
+		return unknownKey(key)
+	}
+	if s.FileOnly {
+		return fmt.Errorf("%s can only be set in %s, %s or a flag", key, c.filePath, s.EnvVar())
+	}
+
+	normalized, err := s.Validate(value)
+	if err != nil {
+		return err
+	}
+	if err := store.SetConfig(key, normalized); err != nil {
+		return err
+	}
+	return c.WithStore(store)
+}
+
+// Unset removes a value from the config table, falling back to lower layers
+func (c *Config) Unset(store *database.Store, key string) error {
+	if _, ok := Lookup(key); !ok {
+		return unknownKey(key)
+	}
+	if err := store.DeleteConfig(key); err != nil {
+		return err
+	}
+	return c.WithStore(store)
+}
+
+// FormatList renders every setting with its resolved value and source
+func FormatList(c *Config) string {
+	var b strings.Builder
+
+	for _, e := range c.List() {
+		b.WriteString(fmt.Sprintf("%-24s %-22s (%s)\n", e.Key, e.Value, e.Source))
+	}
+	b.WriteString(fmt.Sprintf("\nconfig file: %s\n", c.filePath))
+
+	return b.String()
+}
+
+// unknownKey reports a key that isn't a setting
+func unknownKey(key string) error {
+	if key == KeyServerToken {
+		return fmt.Errorf("%s isn't a setting: `log serve` generates it and keeps it in the database", key)
+	}
+	return fmt.Errorf("unknown setting %q (known: %s)", key, strings.Join(Keys(), ", "))
+}
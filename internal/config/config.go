@@ -0,0 +1,683 @@
+// Package config resolves daylog settings from layered sources. Later layers win:
+//
+//	defaults < $XDG_CONFIG_HOME/daylog/config.toml < config table < DAYLOG_* env vars < flags
+//
+// The database path can't live in the database, so the config table layer is
+// added after the store is opened (see WithStore).
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aaryareddy/log_cli/internal/analytics"
+	"github.com/aaryareddy/log_cli/internal/database"
+	"github.com/aaryareddy/log_cli/internal/hooks"
+	"github.com/aaryareddy/log_cli/internal/output"
+	"github.com/aaryareddy/log_cli/internal/parser"
+	"github.com/aaryareddy/log_cli/internal/paths"
+	"github.com/aaryareddy/log_cli/internal/theme"
+)
+
+// ThemesTable holds user themes: [themes.NAME] tables of palette colors
+const ThemesTable = "themes"
+
+// KeyServerToken is the config table key holding the API token. It isn't a
+// setting: `log serve` generates it, and it never comes from a file or env.
+const KeyServerToken = "server.token"
+
+// EnvPrefix prefixes environment overrides (drift.threshold_minutes → DAYLOG_DRIFT_THRESHOLD_MINUTES)
+const EnvPrefix = "DAYLOG_"
+
+// Setting keys
+const (
+	KeyDBPath          = "db.path"
+	KeyMarkdownDir     = "markdown.dir"
+	KeyDriftThreshold  = analytics.ConfigDriftThresholdMinutes
+	KeyAfterHoursStart = analytics.ConfigAfterHoursStart
+	KeyWinPrompt       = "win.prompt_entries"
+	KeyMaxCollapsedLen = "view.max_collapsed_len"
+	KeyWeekStart       = analytics.ConfigWeekStart
+	KeySkipWeekends    = analytics.ConfigStreakSkipWeekends
+	KeyRestDays        = analytics.ConfigStreakRestDays
+	KeyEnergyWindow    = analytics.ConfigEnergyWindowDays
+	KeyStatsMode       = analytics.ConfigStatsMode
+	KeyContextTags     = "tags.context"
+	KeyUnlockMinutes   = "encryption.unlock_minutes"
+	KeyExportRedact    = "export.redact"
+	KeyTheme           = "ui.theme"
+	KeyHooksTimeout    = hooks.ConfigTimeoutSeconds
+	KeyHooksRedact     = hooks.ConfigRedact
+
+	KeyRitualIntention  = "rituals.intention"
+	KeyRitualWin        = "rituals.win"
+	KeyRitualDrift      = "rituals.drift"
+	KeyRitualSignoff    = "rituals.signoff"
+	KeyRitualAfterHours = "rituals.after_hours"
+)
+
+// Source identifies the layer a value came from
+type Source string
+
+const (
+	SourceDefault Source = "default"
+	SourceFile    Source = "file"
+	SourceStore   Source = "config table"
+	SourceEnv     Source = "env"
+	SourceFlag    Source = "flag"
+)
+
+// precedence lists layers from lowest to highest priority
+var precedence = []Source{SourceDefault, SourceFile, SourceStore, SourceEnv, SourceFlag}
+
+// Kind is the type of a setting's value
+type Kind int
+
+const (
+	KindString Kind = iota
+	KindInt
+	KindBool
+	KindPath
+)
+
+// Setting describes a known configuration key
+type Setting struct {
+	Key         string
+	Kind        Kind
+	Default     string
+	Min, Max    int                // Bounds for KindInt
+	FileOnly    bool               // Can't be stored in the config table (needed before it opens)
+	AllowEmpty  bool               // An empty string is a valid value
+	Check       func(string) error // Extra validation for KindString
+	Description string
+}
+
+// Settings lists every known key, in display order
+var Settings = []Setting{
+	{Key: KeyDBPath, Kind: KindPath, FileOnly: true, Description: "SQLite database (default $XDG_DATA_HOME/daylog/daylog.db)"},
+	{Key: KeyMarkdownDir, Kind: KindPath, Default: paths.DefaultMarkdownDir, Description: "Markdown log directory"},
+	{Key: KeyDriftThreshold, Kind: KindInt, Default: "90", Min: 15, Max: 480, Description: "Minutes without logging before a drift alert"},
+	{Key: KeyAfterHoursStart, Kind: KindInt, Default: strconv.Itoa(analytics.DefaultAfterHoursStart), Min: 0, Max: 23, Description: "Hour (24h) when after-hours begins"},
+	{Key: KeyWinPrompt, Kind: KindInt, Default: "10", Min: 1, Max: 100, Description: "Entry count that triggers the win prompt"},
+	{Key: KeyMaxCollapsedLen, Kind: KindInt, Default: "100", Min: 20, Max: 1000, Description: "Characters shown before long entries collapse in `log view`"},
+	{Key: KeyWeekStart, Kind: KindString, Default: "monday", Check: checkWeekStart, Description: "First day of the week for `log week`"},
+	{Key: KeyStatsMode, Kind: KindString, Default: "time", Check: checkStatsMode, Description: "Tag breakdown in `log stats` and `log week`: time or count (t toggles it in the review)"},
+	{Key: KeySkipWeekends, Kind: KindBool, Default: "false", Description: "Weekends never break a streak"},
+	{Key: KeyRestDays, Kind: KindString, Default: "", AllowEmpty: true, Check: checkRestDays, Description: "Other weekdays that never break a streak (e.g. \"fri\")"},
+	{Key: KeyEnergyWindow, Kind: KindInt, Default: strconv.Itoa(analytics.DefaultEnergyWindowDays), Min: 7, Max: 365, Description: "Days of entries energy patterns look back over"},
+	{Key: KeyContextTags, Kind: KindString, AllowEmpty: true, Check: checkContextTags, Description: "Extra @tags for this profile, comma-separated (e.g. \"client,billing\")"},
+	{Key: KeyTheme, Kind: KindString, Default: theme.Auto, Check: checkTheme, Description: "Color theme: auto, dark, light, high-contrast or a [themes.NAME] table"},
+	{Key: KeyExportRedact, Kind: KindString, Default: string(output.RedactPrivate), Check: checkRedaction, Description: "Default --redact level for exports: none, private or all"},
+	{Key: KeyUnlockMinutes, Kind: KindInt, Default: "15", Min: 0, Max: 1440, Description: "Minutes an encrypted log stays unlocked after `log unlock` (0 asks every time)"},
+	{Key: KeyRitualIntention, Kind: KindBool, Default: "true", Description: "Ask for an intention on the first log of the day"},
+	{Key: KeyRitualWin, Kind: KindBool, Default: "true", Description: "Prompt for a win at the win-prompt entry"},
+	{Key: KeyRitualDrift, Kind: KindBool, Default: "true", Description: "Show drift alerts"},
+	{Key: KeyRitualSignoff, Kind: KindBool, Default: "true", Description: "Ask sign-off reflection questions on @signoff"},
+	{Key: KeyRitualAfterHours, Kind: KindBool, Default: "true", Description: "Show after-hours messages after sign-off"},
+	{Key: KeyHooksTimeout, Kind: KindInt, Default: strconv.Itoa(int(hooks.DefaultTimeout.Seconds())), Min: 1, Max: 600, Description: "Seconds a hook may run before it is killed"},
+	{Key: KeyHooksRedact, Kind: KindString, Default: string(output.RedactPrivate), Check: checkRedaction, Description: "Entry text in hook payloads: none, private or all"},
+	{Key: hooks.ConfigKey(hooks.EventIntention), Kind: KindString, AllowEmpty: true, Description: "Shell command run when the day's intention is set"},
+	{Key: hooks.ConfigKey(hooks.EventEntry), Kind: KindString, AllowEmpty: true, Description: "Shell command run when an entry is logged"},
+	{Key: hooks.ConfigKey(hooks.EventDrift), Kind: KindString, AllowEmpty: true, Description: "Shell command run when a drift alert is acknowledged"},
+	{Key: hooks.ConfigKey(hooks.EventWin), Kind: KindString, AllowEmpty: true, Description: "Shell command run when a win is recorded"},
+	{Key: hooks.ConfigKey(hooks.EventSignoff), Kind: KindString, AllowEmpty: true, Description: "Shell command run when the day is signed off"},
+}
+
+// Lookup returns the setting for key
+func Lookup(key string) (Setting, bool) {
+	for _, s := range Settings {
+		if s.Key == key {
+			return s, true
+		}
+	}
+	return Setting{}, false
+}
+
+// Validate checks a value against the setting's kind and bounds, returning it normalized
+func (s Setting) Validate(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	switch s.Kind {
+	case KindInt:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return "", fmt.Errorf("%s must be a whole number, got %q", s.Key, value)
+		}
+		if n < s.Min || n > s.Max {
+			return "", fmt.Errorf("%s must be between %d and %d, got %d", s.Key, s.Min, s.Max, n)
+		}
+		return strconv.Itoa(n), nil
+	case KindBool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			switch strings.ToLower(value) {
+			case "on", "yes":
+				b = true
+			case "off", "no":
+				b = false
+			default:
+				return "", fmt.Errorf("%s must be true or false, got %q", s.Key, value)
+			}
+		}
+		return strconv.FormatBool(b), nil
+	default:
+		if value == "" {
+			if s.AllowEmpty {
+				return "", nil
+			}
+			return "", fmt.Errorf("%s cannot be empty", s.Key)
+		}
+		if s.Check != nil {
+			if err := s.Check(value); err != nil {
+				return "", fmt.Errorf("%s: %w", s.Key, err)
+			}
+		}
+		return value, nil
+	}
+}
+
+// checkWeekStart validates a weekday name
+func checkWeekStart(value string) error {
+	_, err := analytics.ParseWeekStart(value)
+	return err
+}
+
+// checkStatsMode validates a stats breakdown mode
+func checkStatsMode(value string) error {
+	_, err := analytics.ParseStatsMode(value)
+	return err
+}
+
+// checkContextTags validates a comma-separated list of tag names
+func checkContextTags(value string) error {
+	for _, tag := range splitList(value) {
+		if !parser.ValidTagName(tag) {
+			return fmt.Errorf("invalid tag name: %q", tag)
+		}
+	}
+	return nil
+}
+
+// checkRedaction validates an export redaction level
+func checkRedaction(value string) error {
+	_, err := output.ParseRedaction(value)
+	return err
+}
+
+// checkTheme validates a theme name; whether a user theme by that name
+// exists is checked when the theme is resolved
+func checkTheme(value string) error {
+	if !theme.ValidName(value) {
+		return fmt.Errorf("invalid theme name: %q", value)
+	}
+	return nil
+}
+
+// splitList splits a comma-separated list, dropping blanks and leading @
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimPrefix(strings.TrimSpace(item), "@"); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// checkRestDays validates a comma-separated list of weekday names
+func checkRestDays(value string) error {
+	_, err := analytics.ParseRestDays(value)
+	return err
+}
+
+// EnvVar returns the environment variable overriding the setting
+func (s Setting) EnvVar() string {
+	return EnvPrefix + strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(s.Key))
+}
+
+// Config is the resolved configuration
+type Config struct {
+	layers   map[Source]map[string]string
+	themes   map[string]theme.Palette
+	noColor  bool
+	filePath string
+	paths    paths.Paths
+}
+
+// Load resolves the default profile's settings from defaults, the config file,
+// the environment and flags. An empty filePath means the XDG location. A missing
+// config file is fine; an invalid value in any layer is an error.
+func Load(filePath string, environ []string, flags map[string]string) (*Config, error) {
+	return load(paths.DefaultProfile, filePath, environ, flags)
+}
+
+// LoadProfile resolves a named profile's settings. The profile's own file
+// (profiles/<name>.toml) is layered over config.toml, and the profile gets its
+// own database and markdown directory unless its file says otherwise.
+func LoadProfile(name string, environ []string, flags map[string]string) (*Config, error) {
+	return load(name, "", environ, flags)
+}
+
+// load resolves every layer for a profile
+func load(profile, filePath string, environ []string, flags map[string]string) (*Config, error) {
+	base, err := paths.Resolve(environ)
+	if err != nil {
+		return nil, err
+	}
+	p, err := base.ForProfile(profile)
+	if err != nil {
+		return nil, err
+	}
+	c := &Config{layers: make(map[Source]map[string]string), paths: p, noColor: theme.NoColor(environ)}
+
+	defaults := make(map[string]string, len(Settings))
+	for _, s := range Settings {
+		defaults[s.Key] = s.Default
+	}
+	defaults[KeyDBPath] = p.DB
+	defaults[KeyMarkdownDir] = p.MarkdownDir
+	c.layers[SourceDefault] = defaults
+
+	if filePath == "" {
+		filePath = p.ConfigFile
+	}
+	path, err := paths.Expand(filePath)
+	if err != nil {
+		return nil, err
+	}
+	c.filePath = path
+
+	values, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if p.ProfileFile != "" {
+		// Storage locations in config.toml belong to the default profile
+		delete(values, KeyDBPath)
+		delete(values, KeyMarkdownDir)
+
+		overrides, err := readFile(p.ProfileFile)
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range overrides {
+			values[key] = value
+		}
+	}
+	if c.themes, err = parseThemes(values); err != nil {
+		return nil, err
+	}
+	if err := c.setLayer(SourceFile, values); err != nil {
+		return nil, err
+	}
+
+	env := make(map[string]string)
+	for _, kv := range environ {
+		name, value, _ := strings.Cut(kv, "=")
+		for _, s := range Settings {
+			if s.EnvVar() == name {
+				env[s.Key] = value
+			}
+		}
+	}
+	if err := c.setLayer(SourceEnv, env); err != nil {
+		return nil, fmt.Errorf("environment: %w", err)
+	}
+
+	if err := c.setLayer(SourceFlag, flags); err != nil {
+		return nil, fmt.Errorf("flags: %w", err)
+	}
+
+	return c, nil
+}
+
+// readFile parses a TOML config file, checking keys and values. A missing file is empty.
+func readFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	values, err := parseTOML(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	for key, value := range values {
+		if name, _, ok := themeKey(key); ok {
+			if !theme.ValidName(name) {
+				return nil, fmt.Errorf("%s: invalid theme name: %q", path, name)
+			}
+			continue
+		}
+		s, ok := Lookup(key)
+		if !ok {
+			return nil, fmt.Errorf("%s: unknown setting %q", path, key)
+		}
+		if _, err := s.Validate(value); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return values, nil
+}
+
+// themeKey splits a [themes.NAME] key into the theme name and color role
+func themeKey(key string) (name, role string, ok bool) {
+	rest, ok := strings.CutPrefix(key, ThemesTable+".")
+	if !ok {
+		return "", "", false
+	}
+	return strings.Cut(rest, ".")
+}
+
+// parseThemes moves the [themes.NAME] keys out of values and reads them as palettes
+func parseThemes(values map[string]string) (map[string]theme.Palette, error) {
+	raw := make(map[string]map[string]string)
+	for key, value := range values {
+		name, role, ok := themeKey(key)
+		if !ok {
+			continue
+		}
+		if raw[name] == nil {
+			raw[name] = make(map[string]string)
+		}
+		raw[name][role] = value
+		delete(values, key)
+	}
+
+	themes := make(map[string]theme.Palette, len(raw))
+	for name, roles := range raw {
+		if theme.IsBuiltin(name) {
+			return nil, fmt.Errorf("%s.%s: built-in themes can't be redefined (set base = %q instead)", ThemesTable, name, name)
+		}
+		palette, err := theme.ParsePalette(roles)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", ThemesTable, name, err)
+		}
+		themes[name] = palette
+	}
+	return themes, nil
+}
+
+// WithStore adds values from the config table. Unknown keys there belong to
+// other features (server.token, hooks.*) and are ignored; invalid values are
+// skipped so one bad row can't lock the user out.
+func (c *Config) WithStore(store *database.Store) error {
+	values := make(map[string]string)
+	for _, s := range Settings {
+		if s.FileOnly {
+			continue
+		}
+		value, ok, err := store.GetConfig(s.Key)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		if normalized, err := s.Validate(value); err == nil {
+			values[s.Key] = normalized
+		}
+	}
+	c.layers[SourceStore] = values
+	return nil
+}
+
+// setLayer validates and stores a layer
+func (c *Config) setLayer(source Source, values map[string]string) error {
+	layer := make(map[string]string, len(values))
+	for key, value := range values {
+		s, ok := Lookup(key)
+		if !ok {
+			return fmt.Errorf("unknown setting %q", key)
+		}
+		normalized, err := s.Validate(value)
+		if err != nil {
+			return err
+		}
+		layer[key] = normalized
+	}
+	c.layers[source] = layer
+	return nil
+}
+
+// Get returns a value and the layer it came from
+func (c *Config) Get(key string) (string, Source, bool) {
+	for i := len(precedence) - 1; i >= 0; i-- {
+		if value, ok := c.layers[precedence[i]][key]; ok {
+			return value, precedence[i], true
+		}
+	}
+	return "", "", false
+}
+
+// String returns a value (expanding ~ for paths)
+func (c *Config) String(key string) string {
+	value, _, _ := c.Get(key)
+	if s, ok := Lookup(key); ok && s.Kind == KindPath {
+		if expanded, err := paths.Expand(value); err == nil {
+			return expanded
+		}
+	}
+	return value
+}
+
+// Int returns an integer value (0 if unset)
+func (c *Config) Int(key string) int {
+	value, _, _ := c.Get(key)
+	n, _ := strconv.Atoi(value)
+	return n
+}
+
+// Bool returns a boolean value (false if unset)
+func (c *Config) Bool(key string) bool {
+	value, _, _ := c.Get(key)
+	b, _ := strconv.ParseBool(value)
+	return b
+}
+
+// FilePath returns the config file location
+func (c *Config) FilePath() string {
+	return c.filePath
+}
+
+// Paths returns the resolved file locations, with the database and markdown
+// directory as configured
+func (c *Config) Paths() paths.Paths {
+	p := c.paths
+	p.DB = c.DBPath()
+	p.MarkdownDir = c.MarkdownDir()
+	p.ConfigFile = c.filePath
+	return p
+}
+
+// MigrateLegacy moves ~/.daylog to the XDG locations on first run. It is skipped
+// when the database path was set explicitly, since the user chose where it lives.
+func (c *Config) MigrateLegacy() ([]paths.Move, error) {
+	if _, source, _ := c.Get(KeyDBPath); source != SourceDefault || c.Profile() != paths.DefaultProfile {
+		return nil, nil
+	}
+	return paths.MigrateLegacy(c.paths)
+}
+
+// DBPath returns the database location
+func (c *Config) DBPath() string {
+	return c.String(KeyDBPath)
+}
+
+// MarkdownDir returns the markdown log directory
+func (c *Config) MarkdownDir() string {
+	return c.String(KeyMarkdownDir)
+}
+
+// DriftThreshold returns how long without logging counts as drift
+func (c *Config) DriftThreshold() time.Duration {
+	return time.Duration(c.Int(KeyDriftThreshold)) * time.Minute
+}
+
+// ExportRedaction returns the default redaction level for exports
+func (c *Config) ExportRedaction() output.Redaction {
+	level, err := output.ParseRedaction(c.String(KeyExportRedact))
+	if err != nil {
+		return output.RedactPrivate
+	}
+	return level
+}
+
+// UnlockTimeout returns how long an unlock session lasts
+func (c *Config) UnlockTimeout() time.Duration {
+	return time.Duration(c.Int(KeyUnlockMinutes)) * time.Minute
+}
+
+// Theme resolves ui.theme against the built-in and [themes.NAME] themes,
+// without color when NO_COLOR is set
+func (c *Config) Theme() (*theme.Theme, error) {
+	t, err := theme.Resolve(c.String(KeyTheme), c.themes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", KeyTheme, err)
+	}
+	if c.noColor {
+		t = t.WithoutColor()
+	}
+	return t, nil
+}
+
+// DriftRules returns gap detection rules from the drift settings
+func (c *Config) DriftRules() analytics.DriftRules {
+	return analytics.DriftRules{
+		Threshold:       c.DriftThreshold(),
+		AfterHoursStart: c.Int(KeyAfterHoursStart),
+	}
+}
+
+// WeekStart returns the first day of the week
+func (c *Config) WeekStart() time.Weekday {
+	weekStart, _ := analytics.ParseWeekStart(c.String(KeyWeekStart))
+	return weekStart
+}
+
+// StreakRules returns which days may be skipped without breaking a streak
+func (c *Config) StreakRules() analytics.StreakRules {
+	rules := analytics.StreakRules{SkipWeekends: c.Bool(KeySkipWeekends)}
+	if value := c.String(KeyRestDays); value != "" {
+		rules.RestDays, _ = analytics.ParseRestDays(value)
+	}
+	return rules
+}
+
+// Synonyms returns the default synonyms merged with the user's synonym file
+// (see analytics.LoadSynonyms); a missing file leaves the defaults
+func (c *Config) Synonyms() (analytics.Synonyms, error) {
+	return analytics.LoadSynonyms(c.paths.Synonyms)
+}
+
+// StatsMode returns the default tag breakdown for `log stats` and `log week`
+func (c *Config) StatsMode() analytics.StatsMode {
+	mode, _ := analytics.ParseStatsMode(c.String(KeyStatsMode))
+	return mode
+}
+
+// EnergyWindowDays returns how many days energy patterns look back over
+func (c *Config) EnergyWindowDays() int {
+	return c.Int(KeyEnergyWindow)
+}
+
+// Profile returns the profile these settings belong to
+func (c *Config) Profile() string {
+	return c.paths.Profile
+}
+
+// ContextTags returns the profile's extra context tags (without @)
+func (c *Config) ContextTags() []string {
+	return splitList(c.String(KeyContextTags))
+}
+
+// WinPromptEntries returns the entry count that triggers the win prompt
+func (c *Config) WinPromptEntries() int {
+	return c.Int(KeyWinPrompt)
+}
+
+// MaxCollapsedLen returns how many characters `log view` shows before collapsing
+func (c *Config) MaxCollapsedLen() int {
+	return c.Int(KeyMaxCollapsedLen)
+}
+
+// RitualEnabled reports whether a ritual toggle (rituals.*) is on
+func (c *Config) RitualEnabled(key string) bool {
+	return c.Bool(key)
+}
+
+// Entry is a resolved setting, for `log config list`
+type Entry struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source Source `json:"source"`
+}
+
+// List returns every known setting with its resolved value and source
+func (c *Config) List() []Entry {
+	entries := make([]Entry, 0, len(Settings))
+	for _, s := range Settings {
+		value, source, _ := c.Get(s.Key)
+		entries = append(entries, Entry{Key: s.Key, Value: value, Source: source})
+	}
+	return entries
+}
+
+// Keys returns all known keys, sorted
+func Keys() []string {
+	keys := make([]string, 0, len(Settings))
+	for _, s := range Settings {
+		keys = append(keys, s.Key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ParseFlags extracts --db, --markdown-dir and repeated --set key=value from args,
+// returning flag values by key and the remaining arguments
+func ParseFlags(args []string) (map[string]string, []string, error) {
+	flags := make(map[string]string)
+	var rest []string
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		name, value, hasValue := strings.Cut(arg, "=")
+
+		var key string
+		switch name {
+		case "--db":
+			key = KeyDBPath
+		case "--markdown-dir":
+			key = KeyMarkdownDir
+		case "--set":
+		default:
+			rest = append(rest, arg)
+			continue
+		}
+
+		if !hasValue {
+			if i+1 >= len(args) {
+				return nil, nil, fmt.Errorf("%s requires a value", name)
+			}
+			i++
+			value = args[i]
+		}
+
+		if name == "--set" {
+			k, v, ok := strings.Cut(value, "=")
+			if !ok {
+				return nil, nil, fmt.Errorf("--set expects key=value, got %q", value)
+			}
+			key, value = strings.TrimSpace(k), v
+		}
+		flags[key] = value
+	}
+
+	return flags, rest, nil
+}
@@ -0,0 +1,72 @@
+package database
+
+import (
+	"fmt"
+)
+
+// InsertAwayInterval records an acknowledged "stepped away" interval
+func (s *Store) InsertAwayInterval(interval *AwayInterval) error {
+	note, err := s.sealPtr(interval.Note)
+	if err != nil {
+		return err
+	}
+
+	result, err := s.db.Exec(`
+		INSERT INTO away_intervals (day_id, start_time, end_time, note)
+		VALUES (?, ?, ?, ?)
+	`, interval.DayID, interval.StartTime, interval.EndTime, note)
+	if err != nil {
+		return fmt.Errorf("failed to insert away interval: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get away interval id: %w", err)
+	}
+	interval.ID = int(id)
+
+	return nil
+}
+
+// GetAwayIntervalsForRange retrieves all away intervals within a date range (inclusive)
+// Dates should be in YYYY-MM-DD format
+func (s *Store) GetAwayIntervalsForRange(startDate, endDate string) ([]AwayInterval, error) {
+	rows, err := s.db.Query(`
+		SELECT a.id, a.day_id, a.start_time, a.end_time, a.note, a.created_at
+		FROM away_intervals a
+		JOIN days d ON a.day_id = d.id
+		WHERE d.date >= ? AND d.date <= ?
+		ORDER BY a.start_time ASC
+	`, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query away intervals: %w", err)
+	}
+	defer rows.Close()
+
+	var intervals []AwayInterval
+	for rows.Next() {
+		var a AwayInterval
+		if err := rows.Scan(&a.ID, &a.DayID, &a.StartTime, &a.EndTime, &a.Note, &a.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan away interval: %w", err)
+		}
+		if err := s.openPtr(a.Note); err != nil {
+			return nil, err
+		}
+		intervals = append(intervals, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return intervals, nil
+}
+
+// DeleteAwayInterval removes an acknowledged interval (it becomes drift again)
+func (s *Store) DeleteAwayInterval(id int) error {
+	_, err := s.db.Exec(`DELETE FROM away_intervals WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete away interval: %w", err)
+	}
+	return nil
+}
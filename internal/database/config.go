@@ -0,0 +1,54 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+)
+
+// GetConfig retrieves a value from the config table
+// Returns "", false, nil if the key doesn't exist
+func (s *Store) GetConfig(key string) (string, bool, error) {
+	var value string
+	err := s.db.QueryRow(`SELECT value FROM config WHERE key = ?`, key).Scan(&value)
+	if err == sql.ErrNoRows {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to query config: %w", err)
+	}
+	return value, true, nil
+}
+
+// SetConfig inserts or updates a value in the config table
+func (s *Store) SetConfig(key, value string) error {
+	_, err := s.db.Exec(`
+		INSERT INTO config (key, value) VALUES (?, ?)
+		ON CONFLICT(key) DO UPDATE SET value = excluded.value
+	`, key, value)
+	if err != nil {
+		return fmt.Errorf("failed to set config: %w", err)
+	}
+	return nil
+}
+
+// GetConfigInt retrieves an integer config value, returning def if unset
+func (s *Store) GetConfigInt(key string, def int) (int, error) {
+	value, ok, err := s.GetConfig(key)
+	if err != nil || !ok {
+		return def, err
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return def, fmt.Errorf("invalid integer for config %q: %w", key, err)
+	}
+	return n, nil
+}
+
+// DeleteConfig removes a value from the config table (no-op if missing)
+func (s *Store) DeleteConfig(key string) error {
+	if _, err := s.db.Exec(`DELETE FROM config WHERE key = ?`, key); err != nil {
+		return fmt.Errorf("failed to delete config: %w", err)
+	}
+	return nil
+}
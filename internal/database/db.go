@@ -3,6 +3,7 @@ package database
 import (
 	"database/sql"
 	"fmt"
+	"os"
 	"time"
 
 	_ "modernc.org/sqlite"
@@ -10,7 +11,8 @@ import (
 
 // Store handles all database operations
 type Store struct {
-	db *sql.DB
+	db     *sql.DB
+	cipher FieldCipher // Encrypts sensitive columns when set
 }
 
 // NewStore creates a new database store and runs migrations
@@ -34,6 +36,27 @@ func NewStore(dbPath string) (*Store, error) {
 	return store, nil
 }
 
+// OpenReadOnly opens an existing database without running migrations or
+// allowing writes (for reports across profiles). The schema must be current.
+func OpenReadOnly(dbPath string) (*Store, error) {
+	if _, err := os.Stat(dbPath); err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	db, err := sql.Open("sqlite", "file:"+dbPath+"?mode=ro")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	store := &Store{db: db}
+	if version := store.getSchemaVersion(); version < CurrentSchemaVersion {
+		db.Close()
+		return nil, fmt.Errorf("database %s is at schema v%d (need v%d): open it with log once to upgrade", dbPath, version, CurrentSchemaVersion)
+	}
+
+	return store, nil
+}
+
 // Close closes the database connection
 func (s *Store) Close() error {
 	return s.db.Close()
@@ -46,12 +69,12 @@ func (s *Store) GetOrCreateToday() (*Day, error) {
 	var day Day
 	err := s.db.QueryRow(`
 		SELECT id, date, intention, win, pulled_off_track,
-		       kept_on_track, tomorrow_protect, completed, created_at
+		       kept_on_track, tomorrow_protect, intention_status, completed, created_at
 		FROM days WHERE date = ?
 	`, today).Scan(
 		&day.ID, &day.Date, &day.Intention, &day.Win,
 		&day.PulledOffTrack, &day.KeptOnTrack, &day.TomorrowProtect,
-		&day.Completed, &day.CreatedAt,
+		&day.IntentionStatus, &day.Completed, &day.CreatedAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -78,12 +101,20 @@ func (s *Store) GetOrCreateToday() (*Day, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to query day: %w", err)
 	}
+	if err := s.openDay(&day); err != nil {
+		return nil, err
+	}
 
 	return &day, nil
 }
 
 // InsertEntry creates a new log entry with tags
 func (s *Store) InsertEntry(entry *Entry) error {
+	text, err := s.seal(entry.EntryText)
+	if err != nil {
+		return err
+	}
+
 	tx, err := s.db.Begin()
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
@@ -92,9 +123,9 @@ func (s *Store) InsertEntry(entry *Entry) error {
 
 	// Insert entry
 	result, err := tx.Exec(`
-		INSERT INTO entries (day_id, timestamp, entry_text, momentum)
-		VALUES (?, ?, ?, ?)
-	`, entry.DayID, entry.Timestamp, entry.EntryText, entry.Momentum)
+		INSERT INTO entries (day_id, timestamp, entry_text, momentum, private)
+		VALUES (?, ?, ?, ?, ?)
+	`, entry.DayID, entry.Timestamp, text, entry.Momentum, entry.Private)
 	if err != nil {
 		return fmt.Errorf("failed to insert entry: %w", err)
 	}
@@ -126,7 +157,7 @@ func (s *Store) InsertEntry(entry *Entry) error {
 // GetTodayEntries retrieves all entries for today
 func (s *Store) GetTodayEntries(dayID int) ([]*Entry, error) {
 	rows, err := s.db.Query(`
-		SELECT id, day_id, timestamp, entry_text, momentum, created_at
+		SELECT id, day_id, timestamp, entry_text, momentum, private, created_at
 		FROM entries
 		WHERE day_id = ?
 		ORDER BY timestamp ASC
@@ -140,10 +171,13 @@ func (s *Store) GetTodayEntries(dayID int) ([]*Entry, error) {
 	for rows.Next() {
 		var e Entry
 		err := rows.Scan(&e.ID, &e.DayID, &e.Timestamp,
-			&e.EntryText, &e.Momentum, &e.CreatedAt)
+			&e.EntryText, &e.Momentum, &e.Private, &e.CreatedAt)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan entry: %w", err)
 		}
+		if e.EntryText, err = s.open(e.EntryText); err != nil {
+			return nil, err
+		}
 
 		// Load tags for this entry
 		tags, err := s.GetEntryTags(e.ID)
@@ -192,7 +226,11 @@ func (s *Store) GetEntryTags(entryID int) ([]Tag, error) {
 
 // UpdateDayIntention updates the intention for a day
 func (s *Store) UpdateDayIntention(dayID int, intention string) error {
-	_, err := s.db.Exec(`
+	intention, err := s.seal(intention)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
 		UPDATE days SET intention = ? WHERE id = ?
 	`, intention, dayID)
 	if err != nil {
@@ -203,7 +241,11 @@ func (s *Store) UpdateDayIntention(dayID int, intention string) error {
 
 // UpdateDayWin updates the win for a day
 func (s *Store) UpdateDayWin(dayID int, win string) error {
-	_, err := s.db.Exec(`
+	win, err := s.seal(win)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
 		UPDATE days SET win = ? WHERE id = ?
 	`, win, dayID)
 	if err != nil {
@@ -214,7 +256,13 @@ func (s *Store) UpdateDayWin(dayID int, win string) error {
 
 // CompleteDaySignoff marks a day as completed and saves sign-off reflections
 func (s *Store) CompleteDaySignoff(dayID int, pulledOff, keptOn, protect string) error {
-	_, err := s.db.Exec(`
+	var err error
+	for _, field := range []*string{&pulledOff, &keptOn, &protect} {
+		if *field, err = s.seal(*field); err != nil {
+			return err
+		}
+	}
+	_, err = s.db.Exec(`
 		UPDATE days
 		SET pulled_off_track = ?,
 		    kept_on_track = ?,
@@ -228,6 +276,17 @@ func (s *Store) CompleteDaySignoff(dayID int, pulledOff, keptOn, protect string)
 	return nil
 }
 
+// UpdateIntentionStatus records whether the day's intention was met, partly met or missed
+func (s *Store) UpdateIntentionStatus(dayID int, status IntentionStatus) error {
+	_, err := s.db.Exec(`
+		UPDATE days SET intention_status = ? WHERE id = ?
+	`, string(status), dayID)
+	if err != nil {
+		return fmt.Errorf("failed to update intention status: %w", err)
+	}
+	return nil
+}
+
 // GetEntryByIndex retrieves an entry by its index within the day (1-indexed)
 func (s *Store) GetEntryByIndex(dayID int, index int) (*Entry, error) {
 	// Get all entries for the day
@@ -245,8 +304,41 @@ func (s *Store) GetEntryByIndex(dayID int, index int) (*Entry, error) {
 	return entries[index-1], nil
 }
 
-// UpdateEntry updates an existing entry's text, momentum, and tags
+// GetEntryByID retrieves an entry with its tags
+// Returns nil, nil if the entry doesn't exist
+func (s *Store) GetEntryByID(entryID int) (*Entry, error) {
+	var e Entry
+	err := s.db.QueryRow(`
+		SELECT id, day_id, timestamp, entry_text, momentum, private, created_at
+		FROM entries WHERE id = ?
+	`, entryID).Scan(&e.ID, &e.DayID, &e.Timestamp, &e.EntryText, &e.Momentum, &e.Private, &e.CreatedAt)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query entry: %w", err)
+	}
+	if e.EntryText, err = s.open(e.EntryText); err != nil {
+		return nil, err
+	}
+
+	tags, err := s.GetEntryTags(e.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+	e.Tags = tags
+
+	return &e, nil
+}
+
+// UpdateEntry updates an existing entry's text, momentum, time, and tags
 func (s *Store) UpdateEntry(entry *Entry) error {
+	text, err := s.seal(entry.EntryText)
+	if err != nil {
+		return err
+	}
+
 	tx, err := s.db.Begin()
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
@@ -256,9 +348,9 @@ func (s *Store) UpdateEntry(entry *Entry) error {
 	// Update entry
 	_, err = tx.Exec(`
 		UPDATE entries
-		SET entry_text = ?, momentum = ?
+		SET entry_text = ?, momentum = ?, private = ?, timestamp = ?
 		WHERE id = ?
-	`, entry.EntryText, entry.Momentum, entry.ID)
+	`, text, entry.Momentum, entry.Private, entry.Timestamp, entry.ID)
 	if err != nil {
 		return fmt.Errorf("failed to update entry: %w", err)
 	}
@@ -320,12 +412,12 @@ func (s *Store) GetDayByDate(dateStr string) (*Day, error) {
 	var day Day
 	err := s.db.QueryRow(`
 		SELECT id, date, intention, win, pulled_off_track,
-		       kept_on_track, tomorrow_protect, completed, created_at
+		       kept_on_track, tomorrow_protect, intention_status, completed, created_at
 		FROM days WHERE date = ?
 	`, dateStr).Scan(
 		&day.ID, &day.Date, &day.Intention, &day.Win,
 		&day.PulledOffTrack, &day.KeptOnTrack, &day.TomorrowProtect,
-		&day.Completed, &day.CreatedAt,
+		&day.IntentionStatus, &day.Completed, &day.CreatedAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -335,6 +427,37 @@ func (s *Store) GetDayByDate(dateStr string) (*Day, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to query day: %w", err)
 	}
+	if err := s.openDay(&day); err != nil {
+		return nil, err
+	}
+
+	return &day, nil
+}
+
+// GetDayByID retrieves a day record by id
+// Returns nil, nil if day doesn't exist
+func (s *Store) GetDayByID(dayID int) (*Day, error) {
+	var day Day
+	err := s.db.QueryRow(`
+		SELECT id, date, intention, win, pulled_off_track,
+		       kept_on_track, tomorrow_protect, intention_status, completed, created_at
+		FROM days WHERE id = ?
+	`, dayID).Scan(
+		&day.ID, &day.Date, &day.Intention, &day.Win,
+		&day.PulledOffTrack, &day.KeptOnTrack, &day.TomorrowProtect,
+		&day.IntentionStatus, &day.Completed, &day.CreatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query day: %w", err)
+	}
+	if err := s.openDay(&day); err != nil {
+		return nil, err
+	}
 
 	return &day, nil
 }
@@ -344,7 +467,7 @@ func (s *Store) GetDayByDate(dateStr string) (*Day, error) {
 func (s *Store) GetDaysInRange(startDate, endDate string) ([]*Day, error) {
 	rows, err := s.db.Query(`
 		SELECT id, date, intention, win, pulled_off_track,
-		       kept_on_track, tomorrow_protect, completed, created_at
+		       kept_on_track, tomorrow_protect, intention_status, completed, created_at
 		FROM days
 		WHERE date >= ? AND date <= ?
 		ORDER BY date ASC
@@ -359,10 +482,13 @@ func (s *Store) GetDaysInRange(startDate, endDate string) ([]*Day, error) {
 		var d Day
 		err := rows.Scan(&d.ID, &d.Date, &d.Intention, &d.Win,
 			&d.PulledOffTrack, &d.KeptOnTrack, &d.TomorrowProtect,
-			&d.Completed, &d.CreatedAt)
+			&d.IntentionStatus, &d.Completed, &d.CreatedAt)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan day: %w", err)
 		}
+		if err := s.openDay(&d); err != nil {
+			return nil, err
+		}
 		days = append(days, &d)
 	}
 
@@ -377,7 +503,7 @@ func (s *Store) GetDaysInRange(startDate, endDate string) ([]*Day, error) {
 // Returns entries with tags loaded, ordered by timestamp
 func (s *Store) GetEntriesForDateRange(startDate, endDate string) ([]*Entry, error) {
 	rows, err := s.db.Query(`
-		SELECT e.id, e.day_id, e.timestamp, e.entry_text, e.momentum, e.created_at
+		SELECT e.id, e.day_id, e.timestamp, e.entry_text, e.momentum, e.private, e.created_at
 		FROM entries e
 		JOIN days d ON e.day_id = d.id
 		WHERE d.date >= ? AND d.date <= ?
@@ -392,10 +518,13 @@ func (s *Store) GetEntriesForDateRange(startDate, endDate string) ([]*Entry, err
 	for rows.Next() {
 		var e Entry
 		err := rows.Scan(&e.ID, &e.DayID, &e.Timestamp,
-			&e.EntryText, &e.Momentum, &e.CreatedAt)
+			&e.EntryText, &e.Momentum, &e.Private, &e.CreatedAt)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan entry: %w", err)
 		}
+		if e.EntryText, err = s.open(e.EntryText); err != nil {
+			return nil, err
+		}
 
 		// Load tags for this entry
 		tags, err := s.GetEntryTags(e.ID)
@@ -414,17 +543,23 @@ func (s *Store) GetEntriesForDateRange(startDate, endDate string) ([]*Entry, err
 	return entries, nil
 }
 
-// GetWeeklyStats calculates statistics for the past 7 days
+// GetWeeklyStats calculates statistics for the past 7 days (including today)
 func (s *Store) GetWeeklyStats() (*WeeklyStats, error) {
-	weekAgo := time.Now().AddDate(0, 0, -7).Format("2006-01-02")
+	today := time.Now()
+	weekAgo := today.AddDate(0, 0, -6)
+	return s.GetStatsForRange(weekAgo.Format("2006-01-02"), today.Format("2006-01-02"))
+}
 
-	// Count entries
-	var totalEntries int
+// GetStatsForRange calculates statistics for a date range (inclusive)
+// Dates should be in YYYY-MM-DD format
+func (s *Store) GetStatsForRange(startDate, endDate string) (*WeeklyStats, error) {
+	// Count entries and the days they were logged on
+	var totalEntries, daysLogged int
 	err := s.db.QueryRow(`
-		SELECT COUNT(*) FROM entries e
+		SELECT COUNT(*), COUNT(DISTINCT d.id) FROM entries e
 		JOIN days d ON e.day_id = d.id
-		WHERE d.date >= ?
-	`, weekAgo).Scan(&totalEntries)
+		WHERE d.date >= ? AND d.date <= ?
+	`, startDate, endDate).Scan(&totalEntries, &daysLogged)
 	if err != nil {
 		return nil, fmt.Errorf("failed to count entries: %w", err)
 	}
@@ -435,9 +570,9 @@ func (s *Store) GetWeeklyStats() (*WeeklyStats, error) {
 		FROM tags t
 		JOIN entries e ON t.entry_id = e.id
 		JOIN days d ON e.day_id = d.id
-		WHERE d.date >= ? AND t.tag_type = 'context' AND t.tag_value != '@signoff'
+		WHERE d.date >= ? AND d.date <= ? AND t.tag_type = 'context' AND t.tag_value != '@signoff'
 		GROUP BY t.tag_value
-	`, weekAgo)
+	`, startDate, endDate)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query tag distribution: %w", err)
 	}
@@ -457,7 +592,25 @@ func (s *Store) GetWeeklyStats() (*WeeklyStats, error) {
 		return nil, fmt.Errorf("row iteration error: %w", err)
 	}
 
+	// Calendar days in range
+	calendarDays := 0
+	start, err := time.Parse("2006-01-02", startDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start date: %w", err)
+	}
+	end, err := time.Parse("2006-01-02", endDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end date: %w", err)
+	}
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		calendarDays++
+	}
+
 	return &WeeklyStats{
+		StartDate:    startDate,
+		EndDate:      endDate,
+		CalendarDays: calendarDays,
+		DaysLogged:   daysLogged,
 		TotalEntries: totalEntries,
 		TagCounts:    tagCounts,
 	}, nil
@@ -0,0 +1,226 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SealedPrefix marks an encrypted column value. Values are self-describing so
+// a store can hold plaintext and ciphertext side by side while it converts.
+const SealedPrefix = "enc:v1:"
+
+// ConfigEncryptionKDF is the config key holding the key-derivation parameters.
+// Its presence means the store is encrypted.
+const ConfigEncryptionKDF = "encryption.kdf"
+
+// ErrLocked is returned when encrypted data is read or written without a key
+var ErrLocked = errors.New("the log is encrypted and locked: run `log unlock`")
+
+// FieldCipher encrypts sensitive text columns: entry text, day reflections,
+// away notes and queued webhook payloads. Tags, momentum and timestamps stay
+// in the clear so the schema and indexes keep working.
+type FieldCipher interface {
+	Seal(plaintext string) (string, error)
+	Open(sealed string) (string, error)
+}
+
+// sealedColumns lists every encrypted column
+var sealedColumns = []struct{ table, column string }{
+	{"entries", "entry_text"},
+	{"days", "intention"},
+	{"days", "win"},
+	{"days", "pulled_off_track"},
+	{"days", "kept_on_track"},
+	{"days", "tomorrow_protect"},
+	{"away_intervals", "note"},
+	{"webhook_outbox", "payload"},
+}
+
+// IsSealed reports whether a column value is encrypted
+func IsSealed(value string) bool {
+	return strings.HasPrefix(value, SealedPrefix)
+}
+
+// WithCipher encrypts sensitive columns on write and decrypts them on read
+func (s *Store) WithCipher(cipher FieldCipher) *Store {
+	s.cipher = cipher
+	return s
+}
+
+// Encrypted reports whether encryption has been set up for this store
+func (s *Store) Encrypted() (bool, error) {
+	_, ok, err := s.GetConfig(ConfigEncryptionKDF)
+	return ok, err
+}
+
+// seal encrypts a value for writing. Without a cipher, writing to an
+// encrypted store fails rather than leaving plaintext behind.
+func (s *Store) seal(value string) (string, error) {
+	if s.cipher == nil {
+		encrypted, err := s.Encrypted()
+		if err != nil {
+			return "", err
+		}
+		if encrypted {
+			return "", ErrLocked
+		}
+		return value, nil
+	}
+	if value == "" {
+		return value, nil
+	}
+	sealed, err := s.cipher.Seal(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt: %w", err)
+	}
+	return sealed, nil
+}
+
+// sealPtr encrypts an optional value for writing
+func (s *Store) sealPtr(value *string) (*string, error) {
+	if value == nil {
+		return nil, nil
+	}
+	sealed, err := s.seal(*value)
+	if err != nil {
+		return nil, err
+	}
+	return &sealed, nil
+}
+
+// open decrypts a value that was read, passing plaintext through
+func (s *Store) open(value string) (string, error) {
+	if !IsSealed(value) {
+		return value, nil
+	}
+	if s.cipher == nil {
+		return "", ErrLocked
+	}
+	opened, err := s.cipher.Open(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt: %w", err)
+	}
+	return opened, nil
+}
+
+// openPtr decrypts an optional value in place
+func (s *Store) openPtr(value *string) error {
+	if value == nil {
+		return nil
+	}
+	opened, err := s.open(*value)
+	if err != nil {
+		return err
+	}
+	*value = opened
+	return nil
+}
+
+// openDay decrypts a day's free-text fields in place
+func (s *Store) openDay(day *Day) error {
+	for _, field := range []*string{day.Intention, day.Win, day.PulledOffTrack, day.KeptOnTrack, day.TomorrowProtect} {
+		if err := s.openPtr(field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reseal rewrites every encrypted column, opening values with from and sealing
+// them with to (nil on either side means plaintext). It converts a store in
+// place for `log encrypt` and `log decrypt`, then vacuums and truncates the
+// write-ahead log so the old values don't linger in free pages or the log. It returns the number of values rewritten.
+func (s *Store) Reseal(from, to FieldCipher) (int, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	type row struct {
+		id    int
+		value string
+	}
+
+	count := 0
+	for _, col := range sealedColumns {
+		rows, err := tx.Query(fmt.Sprintf(`SELECT id, %s FROM %s WHERE %s IS NOT NULL AND %s != ''`,
+			col.column, col.table, col.column, col.column))
+		if err != nil {
+			return 0, fmt.Errorf("failed to query %s.%s: %w", col.table, col.column, err)
+		}
+		var pending []row
+		for rows.Next() {
+			var r row
+			if err := rows.Scan(&r.id, &r.value); err != nil {
+				rows.Close()
+				return 0, fmt.Errorf("failed to scan %s.%s: %w", col.table, col.column, err)
+			}
+			pending = append(pending, r)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return 0, fmt.Errorf("row iteration error: %w", err)
+		}
+
+		update := fmt.Sprintf(`UPDATE %s SET %s = ? WHERE id = ?`, col.table, col.column)
+		for _, r := range pending {
+			value := r.value
+			if IsSealed(value) && from != nil && from == to {
+				continue // Already sealed with the target key
+			}
+			if IsSealed(value) {
+				if from == nil {
+					return 0, ErrLocked
+				}
+				if value, err = from.Open(value); err != nil {
+					return 0, fmt.Errorf("failed to decrypt %s.%s #%d: %w", col.table, col.column, r.id, err)
+				}
+			}
+			if to != nil {
+				if value, err = to.Seal(value); err != nil {
+					return 0, fmt.Errorf("failed to encrypt: %w", err)
+				}
+			}
+			if value == r.value {
+				continue
+			}
+			if _, err := tx.Exec(update, value, r.id); err != nil {
+				return 0, fmt.Errorf("failed to update %s.%s: %w", col.table, col.column, err)
+			}
+			count++
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	// In WAL mode the old values sit in the log until it is checkpointed,
+	// and VACUUM writes the whole rebuilt database through it too
+	if err := s.checkpoint(); err != nil {
+		return count, err
+	}
+	if _, err := s.db.Exec(`VACUUM`); err != nil {
+		return count, fmt.Errorf("failed to vacuum database: %w", err)
+	}
+	if err := s.checkpoint(); err != nil {
+		return count, err
+	}
+
+	return count, nil
+}
+
+// checkpoint copies the write-ahead log into the database file and truncates
+// it, failing if a reader kept it from completing
+func (s *Store) checkpoint() error {
+	var busy, logFrames, checkpointed int
+	if err := s.db.QueryRow(`PRAGMA wal_checkpoint(TRUNCATE)`).Scan(&busy, &logFrames, &checkpointed); err != nil {
+		return fmt.Errorf("failed to checkpoint database: %w", err)
+	}
+	if busy != 0 {
+		return fmt.Errorf("failed to checkpoint database: log in use by another connection")
+	}
+	return nil
+}
@@ -7,7 +7,7 @@ import (
 
 const (
 	// CurrentSchemaVersion is the current database schema version
-	CurrentSchemaVersion = 1
+	CurrentSchemaVersion = 5
 )
 
 // migrate runs database migrations
@@ -67,12 +67,35 @@ func (s *Store) runMigrations(fromVersion int) error {
 		}
 	}
 
+	// Migration 1 → 2: Stepped-away intervals
+	if fromVersion < 2 {
+		if err := s.migrateV2(tx); err != nil {
+			return fmt.Errorf("failed to migrate to v2: %w", err)
+		}
+	}
+
+	// Migration 2 → 3: Intention follow-through
+	if fromVersion < 3 {
+		if err := s.migrateV3(tx); err != nil {
+			return fmt.Errorf("failed to migrate to v3: %w", err)
+		}
+	}
+
+	// Migration 3 → 4: Webhook targets and outbox
+	if fromVersion < 4 {
+		if err := s.migrateV4(tx); err != nil {
+			return fmt.Errorf("failed to migrate to v4: %w", err)
+		}
+	}
+
+	// Migration 4 → 5: Private entries
+	if fromVersion < 5 {
+		if err := s.migrateV5(tx); err != nil {
+			return fmt.Errorf("failed to migrate to v5: %w", err)
+		}
+	}
+
 	// Future migrations go here
-	// if fromVersion < 2 {
-	//     if err := s.migrateV2(tx); err != nil {
-	//         return fmt.Errorf("failed to migrate to v2: %w", err)
-	//     }
-	// }
 
 	return tx.Commit()
 }
@@ -94,3 +117,64 @@ func (s *Store) migrateV1(tx *sql.Tx) error {
 
 	return nil
 }
+
+// migrateV2 adds the away_intervals table for acknowledged "stepped away" time
+func (s *Store) migrateV2(tx *sql.Tx) error {
+	if _, err := tx.Exec(SchemaAwayIntervals); err != nil {
+		return fmt.Errorf("failed to create away_intervals table: %w", err)
+	}
+
+	_, err := tx.Exec("INSERT INTO schema_version (version) VALUES (?)", 2)
+	if err != nil {
+		return fmt.Errorf("failed to record schema version: %w", err)
+	}
+
+	return nil
+}
+
+// migrateV3 adds days.intention_status for rating intentions at sign-off
+func (s *Store) migrateV3(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE days ADD COLUMN intention_status TEXT
+		CHECK(intention_status IN ('met', 'partial', 'missed'))
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to add intention_status column: %w", err)
+	}
+
+	_, err = tx.Exec("INSERT INTO schema_version (version) VALUES (?)", 3)
+	if err != nil {
+		return fmt.Errorf("failed to record schema version: %w", err)
+	}
+
+	return nil
+}
+
+// migrateV4 adds the webhook_targets and webhook_outbox tables
+func (s *Store) migrateV4(tx *sql.Tx) error {
+	if _, err := tx.Exec(SchemaWebhooks); err != nil {
+		return fmt.Errorf("failed to create webhook tables: %w", err)
+	}
+
+	_, err := tx.Exec("INSERT INTO schema_version (version) VALUES (?)", 4)
+	if err != nil {
+		return fmt.Errorf("failed to record schema version: %w", err)
+	}
+
+	return nil
+}
+
+// migrateV5 adds entries.private for entries kept out of markdown and exports
+func (s *Store) migrateV5(tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE entries ADD COLUMN private INTEGER NOT NULL DEFAULT 0`)
+	if err != nil {
+		return fmt.Errorf("failed to add private column: %w", err)
+	}
+
+	_, err = tx.Exec("INSERT INTO schema_version (version) VALUES (?)", 5)
+	if err != nil {
+		return fmt.Errorf("failed to record schema version: %w", err)
+	}
+
+	return nil
+}
@@ -4,36 +4,80 @@ import "time"
 
 // Day represents a single day's metadata and reflections
 type Day struct {
-	ID              int       `db:"id"`
-	Date            time.Time `db:"date"`
-	Intention       *string   `db:"intention"`
-	Win             *string   `db:"win"`
-	PulledOffTrack  *string   `db:"pulled_off_track"`
-	KeptOnTrack     *string   `db:"kept_on_track"`
-	TomorrowProtect *string   `db:"tomorrow_protect"`
-	Completed       bool      `db:"completed"`
-	CreatedAt       time.Time `db:"created_at"`
+	ID              int       `db:"id" json:"id"`
+	Date            time.Time `db:"date" json:"date"`
+	Intention       *string   `db:"intention" json:"intention,omitempty"`
+	Win             *string   `db:"win" json:"win,omitempty"`
+	PulledOffTrack  *string   `db:"pulled_off_track" json:"pulled_off_track,omitempty"`
+	KeptOnTrack     *string   `db:"kept_on_track" json:"kept_on_track,omitempty"`
+	TomorrowProtect *string   `db:"tomorrow_protect" json:"tomorrow_protect,omitempty"`
+	IntentionStatus *string   `db:"intention_status" json:"intention_status,omitempty"` // "met", "partial", "missed"
+	Completed       bool      `db:"completed" json:"completed"`
+	CreatedAt       time.Time `db:"created_at" json:"created_at"`
 }
 
 // Entry represents a single log entry
 type Entry struct {
-	ID        int       `db:"id"`
-	DayID     int       `db:"day_id"`
-	Timestamp time.Time `db:"timestamp"`
-	EntryText string    `db:"entry_text"`
-	Momentum  *string   `db:"momentum"` // "up", "neutral", "down"
-	CreatedAt time.Time `db:"created_at"`
-	Tags      []Tag     `db:"-"` // Loaded separately
+	ID        int       `db:"id" json:"id"`
+	DayID     int       `db:"day_id" json:"day_id"`
+	Timestamp time.Time `db:"timestamp" json:"timestamp"`
+	EntryText string    `db:"entry_text" json:"entry_text"`
+	Momentum  *string   `db:"momentum" json:"momentum,omitempty"` // "up", "neutral", "down"
+	Private   bool      `db:"private" json:"private"`             // Counted in analytics, kept out of markdown and exports
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	Tags      []Tag     `db:"-" json:"tags"` // Loaded separately
 }
 
 // Tag represents a context tag or pattern flag
 type Tag struct {
-	ID       int    `db:"id"`
-	EntryID  int    `db:"entry_id"`
-	TagType  string `db:"tag_type"`  // "context" or "flag"
-	TagValue string `db:"tag_value"` // e.g., "@deep", "[LEAK]"
+	ID       int    `db:"id" json:"id"`
+	EntryID  int    `db:"entry_id" json:"entry_id"`
+	TagType  string `db:"tag_type" json:"tag_type"`   // "context" or "flag"
+	TagValue string `db:"tag_value" json:"tag_value"` // e.g., "@deep", "[LEAK]"
 }
 
+// AwayInterval is a user-acknowledged "stepped away" period (not drift)
+type AwayInterval struct {
+	ID        int       `db:"id" json:"id"`
+	DayID     int       `db:"day_id" json:"day_id"`
+	StartTime time.Time `db:"start_time" json:"start_time"`
+	EndTime   time.Time `db:"end_time" json:"end_time"`
+	Note      *string   `db:"note" json:"note,omitempty"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+// WebhookTarget is an HTTP endpoint that receives signed event deliveries
+type WebhookTarget struct {
+	ID        int       `db:"id" json:"id"`
+	URL       string    `db:"url" json:"url"`
+	Secret    string    `db:"secret" json:"-"`                // HMAC-SHA256 signing key
+	Events    string    `db:"events" json:"events,omitempty"` // Comma-separated event filter ("" = all)
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+// WebhookDelivery is a row in the webhook outbox
+type WebhookDelivery struct {
+	ID            int        `db:"id" json:"id"`
+	TargetID      int        `db:"target_id" json:"target_id"`
+	Event         string     `db:"event" json:"event"`
+	Payload       string     `db:"payload" json:"-"`
+	Status        string     `db:"status" json:"status"` // "pending", "delivered", "failed"
+	Attempts      int        `db:"attempts" json:"attempts"`
+	NextAttemptAt time.Time  `db:"next_attempt_at" json:"next_attempt_at"`
+	LastError     *string    `db:"last_error" json:"last_error,omitempty"`
+	CreatedAt     time.Time  `db:"created_at" json:"created_at"`
+	DeliveredAt   *time.Time `db:"delivered_at" json:"delivered_at,omitempty"`
+}
+
+// Webhook delivery statuses
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"   // Waiting for its next attempt
+	DeliveryDelivered DeliveryStatus = "delivered" // Accepted by the target (2xx)
+	DeliveryFailed    DeliveryStatus = "failed"    // Gave up after the maximum attempts
+)
+
 // Momentum types
 type Momentum string
 
@@ -44,6 +88,15 @@ const (
 	MomentumBack    Momentum = "back" // Waste/destructive action
 )
 
+// Intention follow-through ratings (set at sign-off)
+type IntentionStatus string
+
+const (
+	IntentionMet     IntentionStatus = "met"
+	IntentionPartial IntentionStatus = "partial"
+	IntentionMissed  IntentionStatus = "missed"
+)
+
 // Context tags
 type ContextTag string
 
@@ -68,8 +121,12 @@ const (
 	FlagAnchor FlagTag = "[ANCHOR]"
 )
 
-// WeeklyStats holds statistics for a week
+// WeeklyStats holds statistics for a week (or any date range)
 type WeeklyStats struct {
-	TotalEntries int
-	TagCounts    map[string]int
+	StartDate    string         `json:"start_date"`    // YYYY-MM-DD, inclusive
+	EndDate      string         `json:"end_date"`      // YYYY-MM-DD, inclusive
+	CalendarDays int            `json:"calendar_days"` // Days in the range
+	DaysLogged   int            `json:"days_logged"`   // Days in the range with at least one entry
+	TotalEntries int            `json:"total_entries"`
+	TagCounts    map[string]int `json:"tag_counts"`
 }
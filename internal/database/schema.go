@@ -80,6 +80,50 @@ CREATE TABLE IF NOT EXISTS schema_version (
 `
 )
 
+// SchemaAwayIntervals creates the away_intervals table (added in v2)
+// Records user-acknowledged "stepped away" time, kept distinct from unexplained drift
+const SchemaAwayIntervals = `
+CREATE TABLE IF NOT EXISTS away_intervals (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	day_id INTEGER NOT NULL,
+	start_time DATETIME NOT NULL,
+	end_time DATETIME NOT NULL,
+	note TEXT,
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY (day_id) REFERENCES days(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_away_day ON away_intervals(day_id);
+`
+
+// SchemaWebhooks creates the webhook tables (added in v4)
+// Targets are HTTP endpoints; the outbox holds one row per event per target until delivered
+const SchemaWebhooks = `
+CREATE TABLE IF NOT EXISTS webhook_targets (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	url TEXT NOT NULL,
+	secret TEXT NOT NULL,
+	events TEXT NOT NULL DEFAULT '',
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS webhook_outbox (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	target_id INTEGER NOT NULL,
+	event TEXT NOT NULL,
+	payload TEXT NOT NULL,
+	status TEXT NOT NULL DEFAULT 'pending' CHECK(status IN ('pending', 'delivered', 'failed')),
+	attempts INTEGER NOT NULL DEFAULT 0,
+	next_attempt_at INTEGER NOT NULL, -- Unix seconds, so due rows compare numerically
+	last_error TEXT,
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	delivered_at DATETIME,
+	FOREIGN KEY (target_id) REFERENCES webhook_targets(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_due ON webhook_outbox(status, next_attempt_at);
+`
+
 // AllSchemas is an ordered list of all schema creation statements
 var AllSchemas = []string{
 	SchemaVersion,
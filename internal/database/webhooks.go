@@ -0,0 +1,224 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// InsertWebhookTarget registers a webhook endpoint
+func (s *Store) InsertWebhookTarget(target *WebhookTarget) error {
+	result, err := s.db.Exec(`
+		INSERT INTO webhook_targets (url, secret, events)
+		VALUES (?, ?, ?)
+	`, target.URL, target.Secret, target.Events)
+	if err != nil {
+		return fmt.Errorf("failed to insert webhook target: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get webhook target id: %w", err)
+	}
+	target.ID = int(id)
+
+	return nil
+}
+
+// GetWebhookTargets retrieves all webhook endpoints
+func (s *Store) GetWebhookTargets() ([]WebhookTarget, error) {
+	rows, err := s.db.Query(`
+		SELECT id, url, secret, events, created_at
+		FROM webhook_targets
+		ORDER BY id ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook targets: %w", err)
+	}
+	defer rows.Close()
+
+	var targets []WebhookTarget
+	for rows.Next() {
+		var t WebhookTarget
+		if err := rows.Scan(&t.ID, &t.URL, &t.Secret, &t.Events, &t.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook target: %w", err)
+		}
+		targets = append(targets, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return targets, nil
+}
+
+// DeleteWebhookTarget removes an endpoint and its queued deliveries
+func (s *Store) DeleteWebhookTarget(id int) error {
+	_, err := s.db.Exec(`DELETE FROM webhook_targets WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook target: %w", err)
+	}
+	return nil
+}
+
+// EnqueueWebhook adds a pending delivery to the outbox, due at the given time
+func (s *Store) EnqueueWebhook(targetID int, event, payload string, due time.Time) error {
+	payload, err := s.seal(payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(`
+		INSERT INTO webhook_outbox (target_id, event, payload, next_attempt_at)
+		VALUES (?, ?, ?, ?)
+	`, targetID, event, payload, due.Unix())
+	if err != nil {
+		return fmt.Errorf("failed to enqueue webhook: %w", err)
+	}
+	return nil
+}
+
+// GetDueWebhooks retrieves pending deliveries whose next attempt is due, oldest first
+func (s *Store) GetDueWebhooks(now time.Time, limit int) ([]*WebhookDelivery, error) {
+	return s.queryDeliveries(`
+		WHERE status = 'pending' AND next_attempt_at <= ?
+		ORDER BY next_attempt_at ASC, id ASC
+		LIMIT ?
+	`, now.Unix(), limit)
+}
+
+// GetRecentWebhooks retrieves the most recent deliveries in any status
+func (s *Store) GetRecentWebhooks(limit int) ([]*WebhookDelivery, error) {
+	return s.queryDeliveries(`
+		ORDER BY id DESC
+		LIMIT ?
+	`, limit)
+}
+
+// queryDeliveries selects outbox rows with the given WHERE/ORDER clause
+func (s *Store) queryDeliveries(clause string, args ...any) ([]*WebhookDelivery, error) {
+	rows, err := s.db.Query(`
+		SELECT id, target_id, event, payload, status, attempts, next_attempt_at,
+		       last_error, created_at, delivered_at
+		FROM webhook_outbox
+	`+clause, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook outbox: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []*WebhookDelivery
+	for rows.Next() {
+		var d WebhookDelivery
+		var next int64
+		err := rows.Scan(&d.ID, &d.TargetID, &d.Event, &d.Payload, &d.Status, &d.Attempts,
+			&next, &d.LastError, &d.CreatedAt, &d.DeliveredAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		if d.Payload, err = s.open(d.Payload); err != nil {
+			return nil, err
+		}
+		d.NextAttemptAt = time.Unix(next, 0)
+		deliveries = append(deliveries, &d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// MarkWebhookDelivered records a successful delivery
+func (s *Store) MarkWebhookDelivered(id int, at time.Time) error {
+	_, err := s.db.Exec(`
+		UPDATE webhook_outbox
+		SET status = 'delivered', attempts = attempts + 1, delivered_at = ?, last_error = NULL
+		WHERE id = ?
+	`, at, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark webhook delivered: %w", err)
+	}
+	return nil
+}
+
+// RecordWebhookFailure records a failed attempt. The delivery is retried at next,
+// or marked failed when giveUp is set.
+func (s *Store) RecordWebhookFailure(id int, message string, next time.Time, giveUp bool) error {
+	status := DeliveryPending
+	if giveUp {
+		status = DeliveryFailed
+	}
+	_, err := s.db.Exec(`
+		UPDATE webhook_outbox
+		SET status = ?, attempts = attempts + 1, next_attempt_at = ?, last_error = ?
+		WHERE id = ?
+	`, string(status), next.Unix(), message, id)
+	if err != nil {
+		return fmt.Errorf("failed to record webhook failure: %w", err)
+	}
+	return nil
+}
+
+// CountWebhooksByStatus counts outbox rows per target and status
+func (s *Store) CountWebhooksByStatus() (map[int]map[DeliveryStatus]int, error) {
+	rows, err := s.db.Query(`
+		SELECT target_id, status, COUNT(*)
+		FROM webhook_outbox
+		GROUP BY target_id, status
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[int]map[DeliveryStatus]int)
+	for rows.Next() {
+		var targetID, count int
+		var status string
+		if err := rows.Scan(&targetID, &status, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook count: %w", err)
+		}
+		if counts[targetID] == nil {
+			counts[targetID] = make(map[DeliveryStatus]int)
+		}
+		counts[targetID][DeliveryStatus(status)] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return counts, nil
+}
+
+// RequeueWebhooks resets deliveries to pending with a fresh attempt budget, due now.
+// With no ids, every failed delivery is requeued. Returns the number requeued.
+func (s *Store) RequeueWebhooks(ids []int, now time.Time) (int, error) {
+	query := `
+		UPDATE webhook_outbox
+		SET status = 'pending', attempts = 0, next_attempt_at = ?, delivered_at = NULL
+	`
+	args := []any{now.Unix()}
+	if len(ids) == 0 {
+		query += ` WHERE status = 'failed'`
+	} else {
+		placeholders := strings.TrimSuffix(strings.Repeat("?,", len(ids)), ",")
+		query += ` WHERE id IN (` + placeholders + `)`
+		for _, id := range ids {
+			args = append(args, id)
+		}
+	}
+
+	result, err := s.db.Exec(query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to requeue webhooks: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count requeued webhooks: %w", err)
+	}
+	return int(n), nil
+}
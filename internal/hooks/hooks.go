@@ -0,0 +1,252 @@
+// Package hooks runs user scripts when things happen in daylog: an entry is
+// logged, a win or intention is recorded, a drift alert is acknowledged, or
+// the day is signed off. Each hook receives a JSON payload on stdin, with
+// private entries' text redacted as for exports (see ConfigRedact).
+//
+// Hooks are executables in the hooks directory named after the event
+// ($XDG_CONFIG_HOME/daylog/hooks/on-entry, on-signoff, ...) and/or shell
+// shell commands configured as "hooks.<event>" (e.g. hooks.on-signoff) in the
+// config file, config table or environment (see Settings).
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aaryareddy/log_cli/internal/database"
+	"github.com/aaryareddy/log_cli/internal/output"
+	"github.com/aaryareddy/log_cli/internal/paths"
+)
+
+// DefaultTimeout is how long a hook may run before it is killed
+const DefaultTimeout = 10 * time.Second
+
+// ConfigTimeoutSeconds is the config key overriding DefaultTimeout
+const ConfigTimeoutSeconds = "hooks.timeout_seconds"
+
+// ConfigRedact is the config key for how much entry text payloads carry:
+// none, private (the default) or all (see output.Redaction)
+const ConfigRedact = "hooks.redact"
+
+// configPrefix prefixes per-event command keys
+const configPrefix = "hooks."
+
+// ConfigKey returns the config key holding an event's shell command
+func ConfigKey(event Event) string {
+	return configPrefix + string(event)
+}
+
+// Event identifies what happened
+type Event string
+
+const (
+	EventEntry     Event = "on-entry"     // An entry was logged
+	EventWin       Event = "on-win"       // A win was recorded
+	EventIntention Event = "on-intention" // The day's intention was set
+	EventDrift     Event = "on-drift"     // A drift alert was acknowledged
+	EventSignoff   Event = "on-signoff"   // The day was signed off
+)
+
+// Events lists every event, in the order a day usually produces them
+var Events = []Event{EventIntention, EventEntry, EventDrift, EventWin, EventSignoff}
+
+// Payload is the JSON written to a hook's stdin
+type Payload struct {
+	Event     Event           `json:"event"`
+	Timestamp time.Time       `json:"timestamp"`
+	Entry     *database.Entry `json:"entry,omitempty"`
+	Day       *database.Day   `json:"day,omitempty"`
+	Drift     *Drift          `json:"drift,omitempty"`
+}
+
+// Drift describes an acknowledged drift alert
+type Drift struct {
+	LastLog      time.Time `json:"last_log"`
+	SinceMinutes int       `json:"since_minutes"`
+}
+
+// NewPayload creates a payload for an event
+func NewPayload(event Event, day *database.Day, entry *database.Entry) Payload {
+	return Payload{Event: event, Timestamp: time.Now(), Day: day, Entry: entry}
+}
+
+// DriftPayload creates an EventDrift payload for a gap since lastLog
+func DriftPayload(day *database.Day, lastLog, now time.Time) Payload {
+	p := NewPayload(EventDrift, day, nil)
+	p.Timestamp = now
+	p.Drift = &Drift{LastLog: lastLog, SinceMinutes: int(now.Sub(lastLog).Minutes())}
+	return p
+}
+
+// Runner runs hooks in the background and logs failures
+type Runner struct {
+	dir       string
+	logPath   string
+	timeout   time.Duration
+	redaction output.Redaction   // Applied to payload entries and days
+	commands  map[Event][]string // Configured shell commands per event
+	wg        sync.WaitGroup
+	mu        sync.Mutex // Serializes writes to the failure log
+}
+
+// NewRunner creates a runner for executables in dir, logging failures to logPath
+// (see paths.Paths HooksDir and HooksLog). Either path may start with ~.
+func NewRunner(dir, logPath string) (*Runner, error) {
+	dir, err := paths.Expand(dir)
+	if err != nil {
+		return nil, err
+	}
+	logPath, err = paths.Expand(logPath)
+	if err != nil {
+		return nil, err
+	}
+	return &Runner{
+		dir:       dir,
+		logPath:   logPath,
+		timeout:   DefaultTimeout,
+		redaction: output.RedactPrivate,
+		commands:  make(map[Event][]string),
+	}, nil
+}
+
+// Settings is the layered configuration a runner reads (config.Config), so
+// hook keys resolve like any other setting: default < file < config table < env
+type Settings interface {
+	String(key string) string
+	Int(key string) int
+}
+
+// LoadSettings reads the timeout, redaction level and per-event commands
+func (r *Runner) LoadSettings(s Settings) error {
+	if seconds := s.Int(ConfigTimeoutSeconds); seconds > 0 {
+		r.timeout = time.Duration(seconds) * time.Second
+	}
+
+	level, err := output.ParseRedaction(s.String(ConfigRedact))
+	if err != nil {
+		return fmt.Errorf("%s: %w", ConfigRedact, err)
+	}
+	r.redaction = level
+
+	for _, event := range Events {
+		if command := s.String(ConfigKey(event)); strings.TrimSpace(command) != "" {
+			r.AddCommand(event, command)
+		}
+	}
+	return nil
+}
+
+// AddCommand registers a shell command (run with sh -c) for an event
+func (r *Runner) AddCommand(event Event, command string) {
+	r.commands[event] = append(r.commands[event], command)
+}
+
+// Fire starts every hook for the payload's event without waiting for them.
+// A nil runner does nothing, so callers don't need to check whether hooks are enabled.
+func (r *Runner) Fire(p Payload) {
+	if r == nil {
+		return
+	}
+
+	hooks := r.hooksFor(p.Event)
+	if len(hooks) == 0 {
+		return
+	}
+
+	if p.Entry != nil {
+		p.Entry = output.RedactEntry(p.Entry, r.redaction)
+	}
+	if p.Day != nil {
+		p.Day = output.RedactDay(p.Day, r.redaction)
+	}
+	data, err := json.Marshal(p)
+	if err != nil {
+		r.logFailure(p.Event, "", fmt.Errorf("failed to encode payload: %w", err), nil)
+		return
+	}
+
+	for _, args := range hooks {
+		r.wg.Add(1)
+		go func(args []string) {
+			defer r.wg.Done()
+			r.run(p.Event, args, data)
+		}(args)
+	}
+}
+
+// Wait blocks until running hooks finish (each is bounded by the timeout).
+// Short-lived commands call this before exiting so hooks aren't cut off.
+func (r *Runner) Wait() {
+	if r == nil {
+		return
+	}
+	r.wg.Wait()
+}
+
+// hooksFor returns the argv of each hook for an event: the executable in the
+// hooks directory (if any), then configured commands
+func (r *Runner) hooksFor(event Event) [][]string {
+	var hooks [][]string
+
+	path := filepath.Join(r.dir, string(event))
+	if info, err := os.Stat(path); err == nil && !info.IsDir() && info.Mode()&0111 != 0 {
+		hooks = append(hooks, []string{path})
+	}
+	for _, command := range r.commands[event] {
+		hooks = append(hooks, []string{"sh", "-c", command})
+	}
+
+	return hooks
+}
+
+// run executes one hook with the payload on stdin
+func (r *Runner) run(event Event, args []string, payload []byte) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Stderr = &stderr
+	cmd.Env = append(os.Environ(), "DAYLOG_EVENT="+string(event))
+	// Don't let grandchildren holding stderr open outlive the timeout
+	cmd.WaitDelay = time.Second
+
+	err := cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		err = fmt.Errorf("timed out after %s", r.timeout)
+	}
+	if err != nil {
+		r.logFailure(event, strings.Join(args, " "), err, stderr.Bytes())
+	}
+}
+
+// logFailure appends a failed hook run to the failure log. Hooks must never
+// break logging, so errors writing the log itself are dropped.
+func (r *Runner) logFailure(event Event, hook string, err error, stderr []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if mkErr := os.MkdirAll(filepath.Dir(r.logPath), 0755); mkErr != nil {
+		return
+	}
+	f, openErr := os.OpenFile(r.logPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if openErr != nil {
+		return
+	}
+	defer f.Close()
+
+	line := fmt.Sprintf("%s %s %s: %v", time.Now().Format(time.RFC3339), event, hook, err)
+	if msg := strings.TrimSpace(string(stderr)); msg != "" {
+		line += " | stderr: " + strings.ReplaceAll(msg, "\n", " ")
+	}
+	fmt.Fprintln(f, line)
+}
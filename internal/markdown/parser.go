@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/aaryareddy/log_cli/internal/database"
+	"github.com/aaryareddy/log_cli/internal/parser"
 )
 
 // Parser handles parsing markdown files back into database models
@@ -131,6 +132,13 @@ func (p *Parser) ParseFile(filePath string) (*database.Day, []*database.Entry, e
 				continue
 			}
 
+			// Private entries were written as a placeholder, without their text
+			private := false
+			if rest, ok := strings.CutPrefix(entryText, parser.PrivatePlaceholder); ok {
+				private = true
+				entryText = strings.TrimSpace(rest)
+			}
+
 			// Extract momentum
 			var momentum *string
 			if p.momentumPattern.MatchString(entryText) {
@@ -162,6 +170,7 @@ func (p *Parser) ParseFile(filePath string) (*database.Day, []*database.Entry, e
 				Timestamp: entryTime,
 				EntryText: cleanText,
 				Momentum:  momentum,
+				Private:   private,
 				Tags:      tags,
 			}
 
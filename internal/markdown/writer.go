@@ -8,22 +8,30 @@ import (
 	"time"
 
 	"github.com/aaryareddy/log_cli/internal/database"
+	"github.com/aaryareddy/log_cli/internal/parser"
+	"github.com/aaryareddy/log_cli/internal/paths"
 )
 
+// EncryptedExt is appended to the names of encrypted markdown files
+const EncryptedExt = ".enc"
+
+// FileCipher encrypts whole markdown files
+type FileCipher interface {
+	SealFile(plaintext []byte) ([]byte, error)
+	OpenFile(sealed []byte) ([]byte, error)
+}
+
 // Writer handles markdown file generation
 type Writer struct {
 	outputDir string
+	cipher    FileCipher // Writes YYYY-MM-DD.md.enc when set
 }
 
 // NewWriter creates a new markdown writer
 func NewWriter(outputDir string) (*Writer, error) {
-	// Expand ~ to home directory
-	if strings.HasPrefix(outputDir, "~") {
-		homeDir, err := os.UserHomeDir()
-		if err != nil {
-			return nil, fmt.Errorf("failed to get home directory: %w", err)
-		}
-		outputDir = filepath.Join(homeDir, outputDir[1:])
+	outputDir, err := paths.Expand(outputDir)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create output directory if it doesn't exist
@@ -34,14 +42,52 @@ func NewWriter(outputDir string) (*Writer, error) {
 	return &Writer{outputDir: outputDir}, nil
 }
 
+// WithCipher encrypts every file the writer produces
+func (w *Writer) WithCipher(cipher FileCipher) *Writer {
+	w.cipher = cipher
+	return w
+}
+
+// filename returns the path of a day's markdown file
+func (w *Writer) filename(day *database.Day) string {
+	name := filepath.Join(w.outputDir, day.Date.Format("2006-01-02")+".md")
+	if w.cipher != nil {
+		name += EncryptedExt
+	}
+	return name
+}
+
+// readFile reads a day's file, decrypting it if the writer is encrypted
+func (w *Writer) readFile(filename string) ([]byte, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil || w.cipher == nil {
+		return data, err
+	}
+	return w.cipher.OpenFile(data)
+}
+
+// writeFile writes a day's file, encrypting it if the writer is encrypted
+func (w *Writer) writeFile(filename, content string) error {
+	data := []byte(content)
+	perm := os.FileMode(0644)
+	if w.cipher != nil {
+		sealed, err := w.cipher.SealFile(data)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt markdown file: %w", err)
+		}
+		data, perm = sealed, 0600
+	}
+	return os.WriteFile(filename, data, perm)
+}
+
 // AppendEntry appends an entry to the day's markdown file
 func (w *Writer) AppendEntry(day *database.Day, entry *database.Entry) error {
-	filename := filepath.Join(w.outputDir, day.Date.Format("2006-01-02")+".md")
+	filename := w.filename(day)
 
 	// If day is completed (after-hours logging), handle specially
 	if day.Completed {
 		// Read existing file
-		existingContent, err := os.ReadFile(filename)
+		existingContent, err := w.readFile(filename)
 		if err != nil {
 			return fmt.Errorf("failed to read markdown file: %w", err)
 		}
@@ -59,7 +105,19 @@ func (w *Writer) AppendEntry(day *database.Day, entry *database.Entry) error {
 		}
 
 		// Write back complete file
-		return os.WriteFile(filename, []byte(content), 0644)
+		return w.writeFile(filename, content)
+	}
+
+	// Encrypted files can't be appended to, so rewrite the whole file
+	if w.cipher != nil {
+		content, err := w.readFile(filename)
+		if os.IsNotExist(err) {
+			content, err = []byte(w.formatHeader(day)), nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read markdown file: %w", err)
+		}
+		return w.writeFile(filename, string(content)+w.formatEntry(entry)+"\n")
 	}
 
 	// Normal append logic for non-completed days
@@ -92,6 +150,15 @@ func (w *Writer) AppendEntry(day *database.Day, entry *database.Entry) error {
 
 // writeHeader writes the markdown file header
 func (w *Writer) writeHeader(f *os.File, day *database.Day) error {
+	if _, err := f.WriteString(w.formatHeader(day)); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+
+	return nil
+}
+
+// formatHeader formats the title, intention and separator that open a day's file
+func (w *Writer) formatHeader(day *database.Day) string {
 	var header strings.Builder
 
 	// Title with formatted date
@@ -105,21 +172,23 @@ func (w *Writer) writeHeader(f *os.File, day *database.Day) error {
 	// Separator
 	header.WriteString("---\n\n")
 
-	if _, err := f.WriteString(header.String()); err != nil {
-		return fmt.Errorf("failed to write header: %w", err)
-	}
-
-	return nil
+	return header.String()
 }
 
-// formatEntry formats an entry as a markdown list item
+// formatEntry formats an entry as a markdown list item. Private entries keep
+// their time, momentum and tags but not their text.
 func (w *Writer) formatEntry(entry *database.Entry) string {
 	var line strings.Builder
 
+	text := entry.EntryText
+	if entry.Private {
+		text = parser.PrivatePlaceholder
+	}
+
 	// Time and entry text
 	line.WriteString(fmt.Sprintf("- %s | %s",
 		entry.Timestamp.Format("3:04pm"),
-		entry.EntryText))
+		text))
 
 	// Add momentum if present
 	if entry.Momentum != nil {
@@ -152,7 +221,7 @@ func (w *Writer) RegenerateFullDay(day *database.Day, entries []*database.Entry)
 // GenerateCompleteDaylog generates a complete daylog with sign-off reflections
 // This will be used for the sign-off ritual in Phase 3
 func (w *Writer) GenerateCompleteDaylog(day *database.Day, entries []*database.Entry) error {
-	filename := filepath.Join(w.outputDir, day.Date.Format("2006-01-02")+".md")
+	filename := w.filename(day)
 
 	var content strings.Builder
 
@@ -219,7 +288,7 @@ func (w *Writer) GenerateCompleteDaylog(day *database.Day, entries []*database.E
 	}
 
 	// Write complete file (overwrites existing)
-	if err := os.WriteFile(filename, []byte(content.String()), 0644); err != nil {
+	if err := w.writeFile(filename, content.String()); err != nil {
 		return fmt.Errorf("failed to write markdown file: %w", err)
 	}
 
@@ -0,0 +1,72 @@
+// Package output renders command results as styled text, plain text or JSON.
+// Commands build a typed result (see results.go) and render it as text with a
+// Renderer (see render.go); the format chosen on the command line decides
+// which one is written and, for text, whether the renderer styles it.
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Format selects how a read command writes its result
+type Format int
+
+const (
+	// FormatStyled is the default lipgloss-styled terminal output
+	FormatStyled Format = iota
+	// FormatPlain is the same text without colors or other ANSI styling
+	FormatPlain
+	// FormatJSON is the typed result encoded as JSON
+	FormatJSON
+)
+
+// ParseFlags extracts --json and --plain from args, returning the chosen format
+// and the remaining arguments
+func ParseFlags(args []string) (Format, []string, error) {
+	format := FormatStyled
+	var rest []string
+
+	for _, arg := range args {
+		switch arg {
+		case "--json":
+			if format == FormatPlain {
+				return format, nil, fmt.Errorf("--json and --plain cannot be used together")
+			}
+			format = FormatJSON
+		case "--plain":
+			if format == FormatJSON {
+				return format, nil, fmt.Errorf("--json and --plain cannot be used together")
+			}
+			format = FormatPlain
+		default:
+			rest = append(rest, arg)
+		}
+	}
+
+	return format, rest, nil
+}
+
+// Write writes result as JSON, or for styled and plain output the text render
+// produces with the format's renderer
+func Write(w io.Writer, format Format, result any, render func(r *Renderer) string) error {
+	if format == FormatJSON {
+		return WriteJSON(w, result)
+	}
+
+	if _, err := fmt.Fprintln(w, render(NewRenderer(format))); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
+
+// WriteJSON writes v as indented JSON
+func WriteJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	return nil
+}
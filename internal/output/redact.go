@@ -0,0 +1,139 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aaryareddy/log_cli/internal/analytics"
+	"github.com/aaryareddy/log_cli/internal/database"
+	"github.com/aaryareddy/log_cli/internal/parser"
+)
+
+// Redaction is how much free text an export includes
+type Redaction string
+
+const (
+	// RedactNone exports everything, including private entries
+	RedactNone Redaction = "none"
+	// RedactPrivate replaces private entries' text with a placeholder (the default)
+	RedactPrivate Redaction = "private"
+	// RedactAll drops all free text: times, momentum and tags only
+	RedactAll Redaction = "all"
+)
+
+// RedactedPlaceholder replaces an ordinary entry's text under RedactAll
+const RedactedPlaceholder = "[redacted]"
+
+// ParseRedaction parses a redaction level name
+func ParseRedaction(value string) (Redaction, error) {
+	switch r := Redaction(strings.ToLower(strings.TrimSpace(value))); r {
+	case RedactNone, RedactPrivate, RedactAll:
+		return r, nil
+	}
+	return "", fmt.Errorf("invalid redaction level: %q (use none, private or all)", value)
+}
+
+// ParseRedactFlag extracts --redact LEVEL (or --redact=LEVEL) from args,
+// returning def when the flag isn't given
+func ParseRedactFlag(args []string, def Redaction) (Redaction, []string, error) {
+	level := def
+	var rest []string
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		value, ok := strings.CutPrefix(arg, "--redact=")
+		if arg == "--redact" {
+			if i+1 >= len(args) {
+				return "", nil, fmt.Errorf("--redact requires a level (none, private or all)")
+			}
+			i++
+			value, ok = args[i], true
+		}
+		if !ok {
+			rest = append(rest, arg)
+			continue
+		}
+
+		parsed, err := ParseRedaction(value)
+		if err != nil {
+			return "", nil, err
+		}
+		level = parsed
+	}
+
+	return level, rest, nil
+}
+
+// RedactEntry returns the entry with its text removed as the level requires.
+// The original is never modified; an entry that needs no change is returned as is.
+func RedactEntry(entry *database.Entry, level Redaction) *database.Entry {
+	var placeholder string
+	switch {
+	case level == RedactNone:
+		return entry
+	case entry.Private:
+		placeholder = parser.PrivatePlaceholder
+	case level == RedactAll:
+		placeholder = RedactedPlaceholder
+	default:
+		return entry
+	}
+
+	redacted := *entry
+	redacted.EntryText = placeholder
+	return &redacted
+}
+
+// RedactEntries redacts each entry (see RedactEntry)
+func RedactEntries(entries []*database.Entry, level Redaction) []*database.Entry {
+	if level == RedactNone {
+		return entries
+	}
+	redacted := make([]*database.Entry, len(entries))
+	for i, entry := range entries {
+		redacted[i] = RedactEntry(entry, level)
+	}
+	return redacted
+}
+
+// RedactSummary returns a copy of a period review with its pattern, theme and
+// waste entries redacted as the level requires. Under RedactAll theme labels
+// and keywords go too, since they are drawn from entry text.
+func RedactSummary(summary *analytics.WeeklyPatternSummary, level Redaction) *analytics.WeeklyPatternSummary {
+	if level == RedactNone {
+		return summary
+	}
+	redacted := *summary
+	redacted.WastePatterns = RedactEntries(summary.WastePatterns, level)
+	redacted.PatternGroups = make(map[string][]*database.Entry, len(summary.PatternGroups))
+	for flagType, entries := range summary.PatternGroups {
+		redacted.PatternGroups[flagType] = RedactEntries(entries, level)
+	}
+	if summary.Themes != nil {
+		redacted.Themes = make(map[string][]analytics.Theme, len(summary.Themes))
+		for flagType, themes := range summary.Themes {
+			for _, theme := range themes {
+				theme.Entries = RedactEntries(theme.Entries, level)
+				if level == RedactAll {
+					theme.Label, theme.Keywords = RedactedPlaceholder, nil
+				}
+				redacted.Themes[flagType] = append(redacted.Themes[flagType], theme)
+			}
+		}
+	}
+	return &redacted
+}
+
+// RedactDay drops the intention, win and reflections under RedactAll
+func RedactDay(day *database.Day, level Redaction) *database.Day {
+	if level != RedactAll {
+		return day
+	}
+	redacted := *day
+	redacted.Intention = nil
+	redacted.Win = nil
+	redacted.PulledOffTrack = nil
+	redacted.KeptOnTrack = nil
+	redacted.TomorrowProtect = nil
+	return &redacted
+}
@@ -0,0 +1,51 @@
+package output
+
+import (
+	"strings"
+
+	"github.com/aaryareddy/log_cli/internal/theme"
+)
+
+// Renderer turns analytics results into terminal text. Analytics only
+// computes results; every color and text attribute is applied here, from
+// the styles the renderer was built with.
+type Renderer struct {
+	styles theme.Styles
+}
+
+// NewRenderer returns the renderer for a format: the current theme for
+// styled output, and no ANSI styling at all for plain (or JSON) output
+func NewRenderer(format Format) *Renderer {
+	if format == FormatStyled {
+		return ForTheme(theme.Current())
+	}
+	return ForTheme(theme.Plain())
+}
+
+// ForTheme returns a renderer using t's styles
+func ForTheme(t *theme.Theme) *Renderer {
+	return &Renderer{styles: t.Styles}
+}
+
+// Styles returns the styles the renderer draws with, for callers laying
+// out their own text around rendered results
+func (r *Renderer) Styles() theme.Styles {
+	return r.styles
+}
+
+// HeaderBar renders a title bar with the title on the left and date on the right
+func (r *Renderer) HeaderBar(title, date string) string {
+	// Format: ┌─ TITLE ──────────────── DATE ─┐
+	titlePart := "─ " + title + " "
+	datePart := " " + date + " ─"
+
+	// Calculate dashes needed to fill width
+	totalLen := len(titlePart) + len(datePart) + 2 // +2 for corners
+	dashesNeeded := 80 - totalLen
+	if dashesNeeded < 0 {
+		dashesNeeded = 0
+	}
+
+	dashes := strings.Repeat("─", dashesNeeded)
+	return r.styles.Header.Render("┌" + titlePart + dashes + datePart + "┐")
+}
@@ -0,0 +1,55 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aaryareddy/log_cli/internal/analytics"
+)
+
+// Comparison formats deltas with arrows, plus a sparkline of the ↑ trend if present
+func (r *Renderer) Comparison(cmp *analytics.Comparison) string {
+	var b strings.Builder
+
+	b.WriteString(r.styles.Subheader.Render(fmt.Sprintf("TRENDS - %s vs %s", cmp.CurrentLabel, cmp.BaselineLabel)))
+	b.WriteString("\n")
+	b.WriteString(r.styles.Dim.Render(strings.Repeat("━", 70)))
+	b.WriteString("\n\n")
+
+	if len(cmp.Trend) > 1 {
+		b.WriteString(fmt.Sprintf("%-20s %s\n\n", "↑ share trend", analytics.Sparkline(cmp.Trend)))
+	}
+
+	for _, d := range cmp.Deltas {
+		if d.Current == 0 && d.Baseline == 0 {
+			continue
+		}
+
+		arrow, style := "→", r.styles.Dim
+		if d.Change > 0.05 || d.Change < -0.05 {
+			improved := (d.Change > 0) != d.HigherIsBad
+			arrow = "↑"
+			if d.Change < 0 {
+				arrow = "↓"
+			}
+			if improved {
+				style = r.styles.Success
+			} else {
+				style = r.styles.Warning
+			}
+		}
+
+		b.WriteString(fmt.Sprintf("%-20s %8s → %-8s ", d.Metric, d.FormatValue(d.Baseline), d.FormatValue(d.Current)))
+		change := fmt.Sprintf("%s %+.1f", arrow, d.Change)
+		if d.IsShare {
+			change += "pp"
+		}
+		b.WriteString(style.Render(change))
+		if !d.Significant && arrow != "→" {
+			b.WriteString(r.styles.Dim.Render("  (within noise)"))
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
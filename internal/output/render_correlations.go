@@ -0,0 +1,115 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aaryareddy/log_cli/internal/analytics"
+)
+
+// CorrelationFindings formats the top findings for the weekly review
+func (r *Renderer) CorrelationFindings(report *analytics.CorrelationReport, limit int) string {
+	var b strings.Builder
+
+	b.WriteString(r.styles.Subheader.Render("CORRELATIONS"))
+	b.WriteString("\n")
+	b.WriteString(r.styles.Dim.Render(strings.Repeat("━", 70)))
+	b.WriteString("\n\n")
+
+	if report == nil || len(report.Findings) == 0 {
+		b.WriteString(r.styles.Dim.Render(fmt.Sprintf("  No strong correlations yet (need %d+ occurrences)", analytics.MinCorrelationSamples)))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	for i, f := range report.Findings {
+		if limit > 0 && i >= limit {
+			break
+		}
+		b.WriteString(r.styles.Dim.Render("  • "))
+		b.WriteString(f.Text)
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// TagMomentumTable formats the full tag × momentum table with lift scores
+func (r *Renderer) TagMomentumTable(table *analytics.TagMomentumTable) string {
+	var b strings.Builder
+
+	b.WriteString(r.styles.Subheader.Render("TAG × MOMENTUM"))
+	b.WriteString("\n")
+	b.WriteString(r.styles.Dim.Render(strings.Repeat("━", 70)))
+	b.WriteString("\n\n")
+
+	if table == nil || len(table.Rows) == 0 {
+		b.WriteString(r.styles.Dim.Render("  No tagged entries with momentum markers yet"))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	b.WriteString(r.styles.Dim.Render(fmt.Sprintf("  %-12s %5s", "tag", "n")))
+	for _, m := range analytics.MomentumOrder {
+		b.WriteString(r.styles.Dim.Render(fmt.Sprintf("  %-12s", analytics.MomentumArrow(m))))
+	}
+	b.WriteString("\n")
+
+	for _, row := range table.Rows {
+		b.WriteString(fmt.Sprintf("  %-12s %5d", row.Tag, row.Total))
+		for _, m := range analytics.MomentumOrder {
+			cell := fmt.Sprintf("%3d ×%.1f", row.Counts[m], row.Lift[m])
+			style := r.styles.Body
+			if row.Counts[m] >= analytics.MinCorrelationSamples && row.Lift[m] >= analytics.MinFindingLift {
+				if m == "up" {
+					style = r.styles.Success
+				} else if m == "down" || m == "back" {
+					style = r.styles.Warning
+				}
+			} else if row.Counts[m] == 0 {
+				style = r.styles.Dim
+			}
+			b.WriteString("  " + style.Render(fmt.Sprintf("%-12s", cell)))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(r.styles.Dim.Render("  count ×lift — lift > 1 means more likely than your overall baseline"))
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+// PrecedingTransitions formats what tends to come right before a flag
+// (nothing without a report)
+func (r *Renderer) PrecedingTransitions(report *analytics.CorrelationReport, target string, limit int) string {
+	if report == nil {
+		return ""
+	}
+
+	var b strings.Builder
+
+	b.WriteString(r.styles.Subheader.Render("WHAT PRECEDES " + target))
+	b.WriteString("\n")
+	b.WriteString(r.styles.Dim.Render(strings.Repeat("━", 70)))
+	b.WriteString("\n\n")
+
+	transitions := report.TransitionsInto(target)
+	if len(transitions) == 0 {
+		b.WriteString(r.styles.Dim.Render("  No sequences leading into " + target + " yet"))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	for i, t := range transitions {
+		if limit > 0 && i >= limit {
+			break
+		}
+		b.WriteString(fmt.Sprintf("  %-12s → %-8s %3.0f%%  ", t.From, target, t.Probability*100))
+		b.WriteString(r.styles.Dim.Render(fmt.Sprintf("(%d/%d, ×%.1f)", t.Count, t.FromCount, t.Lift)))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
@@ -0,0 +1,67 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aaryareddy/log_cli/internal/analytics"
+)
+
+// DriftReport formats drift and stepped-away totals, with a per-day breakdown
+func (r *Renderer) DriftReport(report *analytics.DriftReport) string {
+	var b strings.Builder
+
+	b.WriteString(r.styles.Subheader.Render("DRIFT & STEPPED AWAY"))
+	b.WriteString("\n")
+	b.WriteString(r.styles.Dim.Render(strings.Repeat("━", 70)))
+	b.WriteString("\n\n")
+
+	if report == nil || (report.DriftCount == 0 && report.SteppedAwayCount == 0 && report.BreakTime == 0) {
+		b.WriteString(r.styles.Dim.Render("  No long gaps between entries"))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	b.WriteString(r.styles.Warning.Render(fmt.Sprintf("Drift alerts:  %d", report.DriftCount)))
+	b.WriteString(r.styles.Dim.Render(fmt.Sprintf("  (%s unexplained)", analytics.FormatHours(report.DriftTime))))
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("Stepped away:  %d", report.SteppedAwayCount))
+	b.WriteString(r.styles.Dim.Render(fmt.Sprintf("  (%s acknowledged)", analytics.FormatHours(report.SteppedAwayTime))))
+	b.WriteString("\n")
+	if report.BreakTime > 0 {
+		b.WriteString(fmt.Sprintf("Long breaks:   %s\n", analytics.FormatHours(report.BreakTime)))
+	}
+
+	if len(report.Days) > 1 {
+		b.WriteString("\n")
+		for _, day := range report.Days {
+			date, err := time.Parse("2006-01-02", day.Date)
+			label := day.Date
+			if err == nil {
+				label = date.Format("Mon 1/2")
+			}
+			b.WriteString(fmt.Sprintf("  %-9s", label))
+			b.WriteString(fmt.Sprintf(" drift %d (%s)", day.DriftCount, analytics.FormatHours(day.DriftTime)))
+			b.WriteString(r.styles.Dim.Render(fmt.Sprintf("  away %d (%s)", day.SteppedAwayCount, analytics.FormatHours(day.SteppedAwayTime))))
+			b.WriteString("\n")
+		}
+	}
+
+	return b.String()
+}
+
+// GapMarker formats an inline marker for a gap in the day view
+func (r *Renderer) GapMarker(gap analytics.Gap) string {
+	span := fmt.Sprintf("%s–%s, %s", gap.Start.Format("3:04pm"), gap.End.Format("3:04pm"), analytics.FormatHours(gap.Duration()))
+	switch gap.Kind {
+	case analytics.GapDrift:
+		return r.styles.Warning.Render("  ⋮ drift ") + r.styles.Dim.Render(span)
+	case analytics.GapSteppedAway:
+		return r.styles.Dim.Render("  ⋮ stepped away " + span)
+	case analytics.GapBreak:
+		return r.styles.Dim.Render("  ⋮ break " + span)
+	default:
+		return r.styles.Dim.Render("  ⋮ " + span)
+	}
+}
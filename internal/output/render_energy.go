@@ -0,0 +1,87 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aaryareddy/log_cli/internal/analytics"
+)
+
+// EnergyHeatmap renders a colored weekday × hour grid for a metric
+func (r *Renderer) EnergyHeatmap(m *analytics.EnergyMap, metric string) string {
+	var b strings.Builder
+
+	b.WriteString(r.styles.Subheader.Render(fmt.Sprintf("ENERGY MAP - %s", analytics.MetricLabel(metric))))
+	b.WriteString("\n")
+	b.WriteString(r.styles.Dim.Render(strings.Repeat("━", 70)))
+	b.WriteString("\n\n")
+
+	first, last := m.ActiveHours()
+	if first == -1 {
+		b.WriteString(r.styles.Dim.Render("  No entries in this window yet"))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	// Find the busiest cell for intensity scaling
+	maxCount := 0
+	for wd := 0; wd < 7; wd++ {
+		for h := first; h <= last; h++ {
+			if c := m.Cells[wd][h].Count(metric); c > maxCount {
+				maxCount = c
+			}
+		}
+	}
+
+	// Hour header (label every other hour to keep columns aligned)
+	b.WriteString("      ")
+	for h := first; h <= last; h++ {
+		if (h-first)%2 == 0 {
+			b.WriteString(r.styles.Dim.Render(fmt.Sprintf("%-6s", analytics.FormatHour(h))))
+		}
+	}
+	b.WriteString("\n")
+
+	// Rows start on Monday
+	for i := 0; i < 7; i++ {
+		wd := time.Weekday((i + 1) % 7)
+		b.WriteString(r.styles.Dim.Render(fmt.Sprintf("%-6s", wd.String()[:3])))
+		for h := first; h <= last; h++ {
+			b.WriteString(r.heatCell(m.Cells[wd][h].Count(metric), maxCount, metric))
+		}
+		b.WriteString("\n")
+	}
+
+	// Legend
+	b.WriteString("\n")
+	b.WriteString(r.styles.Dim.Render(fmt.Sprintf("      ░░░ none   %s few   %s most (%d)",
+		r.heatCell(1, 10, metric), r.heatCell(10, 10, metric), maxCount)))
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+// heatCell renders a single 3-column heatmap cell scaled against maxCount
+func (r *Renderer) heatCell(count, maxCount int, metric string) string {
+	if count == 0 || maxCount == 0 {
+		return r.styles.Dim.Render("░░░")
+	}
+
+	style := r.styles.Success
+	switch metric {
+	case analytics.MetricDown, "back", "[LEAK]", "[STUCK]":
+		style = r.styles.Warning
+	case analytics.MetricAll, "@deep", "@admin", "@social", "@break", "@zone":
+		style = r.styles.Gold // The accent color
+	}
+
+	ratio := float64(count) / float64(maxCount)
+	block := "▒▒▒"
+	if ratio > 0.66 {
+		block = "███"
+	} else if ratio > 0.33 {
+		block = "▓▓▓"
+	}
+	return style.Render(block)
+}
@@ -0,0 +1,157 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aaryareddy/log_cli/internal/analytics"
+	"github.com/aaryareddy/log_cli/internal/database"
+)
+
+// FollowThrough formats intention follow-through rates
+func (r *Renderer) FollowThrough(f *analytics.FollowThrough) string {
+	var b strings.Builder
+
+	b.WriteString(r.styles.Subheader.Render("INTENTION FOLLOW-THROUGH"))
+	b.WriteString("\n")
+	b.WriteString(r.styles.Dim.Render(strings.Repeat("━", 70)))
+	b.WriteString("\n\n")
+
+	if f == nil || f.WithIntention == 0 {
+		b.WriteString(r.styles.Dim.Render("  No intentions set in this period"))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	if f.Rated() == 0 {
+		b.WriteString(r.styles.Dim.Render(fmt.Sprintf("  %d intentions set, none rated yet (rate them at sign-off)", f.WithIntention)))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	style := r.styles.Success
+	if f.Rate() < 50 {
+		style = r.styles.Error
+	} else if f.Rate() < 75 {
+		style = r.styles.Warning
+	}
+
+	b.WriteString(style.Render(fmt.Sprintf("%.0f%%", f.Rate())))
+	b.WriteString("  ")
+	b.WriteString(r.miniBar(f.Rate(), 20, style))
+	b.WriteString("\n\n")
+
+	for _, row := range []struct {
+		label string
+		count int
+	}{
+		{"Met:", f.Met},
+		{"Partly met:", f.Partial},
+		{"Missed:", f.Missed},
+	} {
+		pct := float64(row.count) / float64(f.Rated()) * 100
+		b.WriteString(fmt.Sprintf("%-12s %3d  (%3.0f%%)\n", row.label, row.count, pct))
+	}
+	if f.Unrated > 0 {
+		b.WriteString(r.styles.Dim.Render(fmt.Sprintf("Unrated:     %3d", f.Unrated)))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// ProtectPairings formats each "protect tomorrow" next to the following day
+func (r *Renderer) ProtectPairings(pairings []analytics.ProtectPairing) string {
+	var b strings.Builder
+
+	b.WriteString(r.styles.Subheader.Render("PROTECT → NEXT DAY"))
+	b.WriteString("\n")
+	b.WriteString(r.styles.Dim.Render(strings.Repeat("━", 70)))
+	b.WriteString("\n\n")
+
+	if len(pairings) == 0 {
+		b.WriteString(r.styles.Dim.Render("  No \"protect tomorrow\" answers in this period"))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	for _, p := range pairings {
+		b.WriteString(r.styles.Body.Render(p.Date.Format("Mon Jan 2")))
+		b.WriteString("\n")
+		b.WriteString(fmt.Sprintf("  Protect:   %s\n", p.Protect))
+
+		if p.Intention != "" {
+			b.WriteString(fmt.Sprintf("  Intention: %s", p.Intention))
+			if p.Carried {
+				b.WriteString(r.styles.Success.Render("  ✓ carried over"))
+			}
+			b.WriteString("\n")
+		} else {
+			b.WriteString(r.styles.Dim.Render("  Intention: (none)"))
+			b.WriteString("\n")
+		}
+
+		if p.Status != "" {
+			style := r.styles.Success
+			switch database.IntentionStatus(p.Status) {
+			case database.IntentionPartial:
+				style = r.styles.Warning
+			case database.IntentionMissed:
+				style = r.styles.Error
+			}
+			b.WriteString("  Rated:     ")
+			b.WriteString(style.Render(p.Status))
+			b.WriteString("\n")
+		}
+
+		switch {
+		case len(p.Entries) == 0:
+			b.WriteString(r.styles.Dim.Render("  No entries logged"))
+		case len(p.Mentions) > 0:
+			b.WriteString(r.styles.Dim.Render(fmt.Sprintf("  %d of %d entries mention it", len(p.Mentions), len(p.Entries))))
+			for _, entry := range p.Mentions {
+				b.WriteString("\n")
+				b.WriteString(r.styles.Dim.Render(fmt.Sprintf("    %s  %s", entry.Timestamp.Format("3:04pm"), entry.EntryText)))
+			}
+		default:
+			b.WriteString(r.styles.Warning.Render(fmt.Sprintf("  None of %d entries mention it", len(p.Entries))))
+		}
+		b.WriteString("\n\n")
+	}
+
+	return b.String()
+}
+
+// ReflectionThemes formats clustered "pulled off track" reasons, most frequent first
+func (r *Renderer) ReflectionThemes(themes []analytics.Theme) string {
+	var b strings.Builder
+
+	b.WriteString(r.styles.Leak.Render("WHAT PULLED YOU OFF TRACK"))
+	b.WriteString("\n")
+	b.WriteString(r.styles.Dim.Render(strings.Repeat("━", 70)))
+	b.WriteString("\n\n")
+
+	if len(themes) == 0 {
+		b.WriteString(r.styles.Dim.Render("  No sign-off reflections in this period"))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	for _, theme := range themes {
+		if theme.Count() > 1 {
+			b.WriteString(r.styles.Body.Render(fmt.Sprintf("%s (%dx)", theme.Label, theme.Count())))
+		} else {
+			b.WriteString(theme.Label)
+		}
+		b.WriteString("\n")
+
+		if theme.Count() > 1 {
+			for _, reason := range theme.Entries {
+				b.WriteString(r.styles.Dim.Render(fmt.Sprintf("    %s  %s", reason.Timestamp.Format("Mon 1/2"), reason.EntryText)))
+				b.WriteString("\n")
+			}
+		}
+	}
+
+	return b.String()
+}
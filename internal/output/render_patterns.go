@@ -0,0 +1,191 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aaryareddy/log_cli/internal/analytics"
+	"github.com/aaryareddy/log_cli/internal/database"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// PatternGroup formats a pattern group for display with colored box borders
+func (r *Renderer) PatternGroup(flagType string, entries []*database.Entry) string {
+	if len(entries) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+
+	// Header with colored box border and description
+	b.WriteString(r.patternTitle(flagType))
+	b.WriteString("\n")
+	b.WriteString(r.styles.Dim.Render("├" + strings.Repeat("─", 59)))
+	b.WriteString("\n")
+
+	// Sort entries by timestamp
+	sortedEntries := make([]*database.Entry, len(entries))
+	copy(sortedEntries, entries)
+	sort.Slice(sortedEntries, func(i, j int) bool {
+		return sortedEntries[i].Timestamp.Before(sortedEntries[j].Timestamp)
+	})
+
+	// Format each entry with box prefix
+	for _, entry := range sortedEntries {
+		// Date and time
+		dateStr := entry.Timestamp.Format("Mon 1/2")
+		timeStr := entry.Timestamp.Format("3:04pm")
+		b.WriteString(r.styles.Dim.Render("│ "))
+		b.WriteString(fmt.Sprintf("%s  %s | %s", dateStr, timeStr, entry.EntryText))
+
+		// Add momentum indicator if present
+		if entry.Momentum != nil {
+			switch *entry.Momentum {
+			case "up":
+				b.WriteString(" " + r.styles.Success.Render("↑"))
+			case "down":
+				b.WriteString(" " + r.styles.Warning.Render("↓"))
+			case "neutral":
+				b.WriteString(" " + r.styles.Body.Render("→"))
+			case "back":
+				b.WriteString(" " + r.styles.Error.Render("←"))
+			}
+		}
+
+		// Add context tags (exclude the current flag)
+		for _, tag := range entry.Tags {
+			if tag.TagType == "context" {
+				b.WriteString(" " + r.styles.Dim.Render(tag.TagValue))
+			}
+		}
+
+		b.WriteString("\n")
+	}
+
+	// Closing border
+	b.WriteString(r.styles.Dim.Render("└" + strings.Repeat("─", 59)))
+
+	return b.String()
+}
+
+// patternTitle renders the colored box title and description for a pattern flag
+func (r *Renderer) patternTitle(flagType string) string {
+	var title, description, styledTitle string
+
+	switch flagType {
+	case "[LEAK]":
+		title = "LEAK PATTERNS"
+		description = "What pulled you off track"
+		styledTitle = r.styles.Leak.Render("┌─ " + title)
+	case "[FLOW]":
+		title = "FLOW PATTERNS"
+		description = "When you were in the zone"
+		styledTitle = r.styles.Flow.Render("┌─ " + title)
+	case "[STUCK]":
+		title = "STUCK PATTERNS"
+		description = "Where you got blocked"
+		styledTitle = r.styles.Stuck.Render("┌─ " + title)
+	case "[GOLD]":
+		title = "GOLD PATTERNS"
+		description = "Wins and breakthroughs"
+		styledTitle = r.styles.Gold.Render("┌─ " + title)
+	default:
+		title = fmt.Sprintf("%s PATTERNS", flagType)
+		description = ""
+		styledTitle = r.styles.Body.Render("┌─ " + title)
+	}
+
+	if description == "" {
+		return styledTitle
+	}
+	return styledTitle + "\n" + r.styles.Dim.Render("│ "+description)
+}
+
+// MomentumStats formats momentum statistics for display with colors and bars
+func (r *Renderer) MomentumStats(stats *analytics.MomentumStats) string {
+	var b strings.Builder
+
+	// Colored header with double line divider
+	b.WriteString(r.styles.Subheader.Render("MOMENTUM DISTRIBUTION"))
+	b.WriteString("\n")
+	b.WriteString(r.styles.Dim.Render(strings.Repeat("━", 70)))
+	b.WriteString("\n\n")
+
+	// Calculate percentages
+	if stats.TotalCount > 0 {
+		upPct := float64(stats.UpCount) / float64(stats.TotalCount) * 100
+		downPct := float64(stats.DownCount) / float64(stats.TotalCount) * 100
+		neutralPct := float64(stats.NeutralCount) / float64(stats.TotalCount) * 100
+		backPct := float64(stats.BackCount) / float64(stats.TotalCount) * 100
+
+		// Color-coded momentum lines with visual bars
+		b.WriteString(r.styles.Success.Render("↑") + " Productive   ")
+		b.WriteString(fmt.Sprintf("%2d (%.0f%%)  %s\n", stats.UpCount, upPct, r.miniBar(upPct, 10, r.styles.Success)))
+
+		b.WriteString(r.styles.Body.Render("→") + " Neutral      ")
+		b.WriteString(fmt.Sprintf("%2d (%.0f%%)  %s\n", stats.NeutralCount, neutralPct, r.miniBar(neutralPct, 10, r.styles.Body)))
+
+		b.WriteString(r.styles.Warning.Render("↓") + " Dragging     ")
+		b.WriteString(fmt.Sprintf("%2d (%.0f%%)  %s\n", stats.DownCount, downPct, r.miniBar(downPct, 10, r.styles.Warning)))
+
+		b.WriteString(r.styles.Error.Render("←") + " Waste        ")
+		b.WriteString(fmt.Sprintf("%2d (%.0f%%)  %s\n", stats.BackCount, backPct, r.miniBar(backPct, 10, r.styles.Error)))
+	} else {
+		b.WriteString(r.styles.Dim.Render("  No entries with momentum markers"))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// miniBar creates a small visual bar chart with color-coded filled portion
+func (r *Renderer) miniBar(percentage float64, maxWidth int, filledStyle lipgloss.Style) string {
+	filled := int(percentage / 100.0 * float64(maxWidth))
+	if filled > maxWidth {
+		filled = maxWidth
+	}
+	if filled < 0 {
+		filled = 0
+	}
+
+	empty := maxWidth - filled
+	filledBar := filledStyle.Render(strings.Repeat("█", filled))
+	emptyBar := r.styles.Dim.Render(strings.Repeat("░", empty))
+	return filledBar + emptyBar
+}
+
+// WastePatterns formats waste pattern entries for display with warning colors
+func (r *Renderer) WastePatterns(entries []*database.Entry) string {
+	if len(entries) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+
+	// Warning-styled header with double line divider
+	b.WriteString("\n")
+	b.WriteString(r.styles.Warning.Render("WASTE PATTERNS"))
+	b.WriteString("\n")
+	b.WriteString(r.styles.Dim.Render(strings.Repeat("━", 70)))
+	b.WriteString("\n")
+	b.WriteString(r.styles.Dim.Render("Activities marked with ← (back arrow)"))
+	b.WriteString("\n\n")
+
+	// Sort by timestamp
+	sortedEntries := make([]*database.Entry, len(entries))
+	copy(sortedEntries, entries)
+	sort.Slice(sortedEntries, func(i, j int) bool {
+		return sortedEntries[i].Timestamp.Before(sortedEntries[j].Timestamp)
+	})
+
+	for _, entry := range sortedEntries {
+		dateStr := entry.Timestamp.Format("Mon 1/2")
+		timeStr := entry.Timestamp.Format("3:04pm")
+		b.WriteString(fmt.Sprintf("  %s  %s | %s ", dateStr, timeStr, entry.EntryText))
+		b.WriteString(r.styles.Error.Render("←"))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
@@ -0,0 +1,62 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aaryareddy/log_cli/internal/analytics"
+)
+
+// Streaks formats the streak report for display
+func (r *Renderer) Streaks(report *analytics.StreakReport) string {
+	var b strings.Builder
+
+	b.WriteString(r.styles.Subheader.Render("STREAKS"))
+	b.WriteString("\n")
+	b.WriteString(r.styles.Dim.Render(strings.Repeat("━", 70)))
+	b.WriteString("\n\n")
+
+	for _, row := range []struct {
+		label  string
+		streak analytics.Streak
+	}{
+		{"Logging", report.Logging},
+		{"Sign-off", report.Signoff},
+		{"Intention", report.Intention},
+	} {
+		b.WriteString(fmt.Sprintf("%-10s ", row.label))
+		b.WriteString(r.styles.Success.Render(fmt.Sprintf("%3d days", row.streak.Current)))
+		b.WriteString(r.styles.Dim.Render(fmt.Sprintf("   best %d", row.streak.Longest)))
+		if row.streak.Longest > 0 {
+			b.WriteString(r.styles.Dim.Render(fmt.Sprintf(" (%s → %s)", row.streak.LongestStart, row.streak.LongestEnd)))
+		}
+		if next := analytics.NextMilestone(row.streak.Current); next > 0 {
+			b.WriteString(r.styles.Dim.Render(fmt.Sprintf("   next: %d", next)))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(r.styles.Subheader.Render("CONSISTENCY"))
+	b.WriteString("\n")
+	b.WriteString(r.styles.Dim.Render(strings.Repeat("━", 70)))
+	b.WriteString("\n\n")
+
+	style := r.styles.Success
+	if report.Consistency < 50 {
+		style = r.styles.Error
+	} else if report.Consistency < 75 {
+		style = r.styles.Warning
+	}
+	b.WriteString(style.Render(fmt.Sprintf("%.0f/100", report.Consistency)))
+	b.WriteString("  ")
+	b.WriteString(r.miniBar(report.Consistency, 20, style))
+	b.WriteString("\n\n")
+
+	b.WriteString(fmt.Sprintf("Days logged:     %d of %d expected (last %d days)\n",
+		report.ActiveDays, report.ExpectedDays, analytics.DefaultConsistencyWindowDays))
+	b.WriteString(fmt.Sprintf("Avg entries/day: %.1f\n", report.AvgEntries))
+	b.WriteString(fmt.Sprintf("Drift gaps:      %d\n", report.DriftGaps))
+
+	return b.String()
+}
@@ -0,0 +1,45 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aaryareddy/log_cli/internal/analytics"
+)
+
+// Themes formats clustered themes for a pattern group. Singleton themes are
+// listed as-is; when expanded, the members of each multi-entry theme are shown too.
+func (r *Renderer) Themes(flagType string, themes []analytics.Theme, expanded bool) string {
+	if len(themes) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+
+	b.WriteString(r.patternTitle(flagType))
+	b.WriteString("\n")
+	b.WriteString(r.styles.Dim.Render("├" + strings.Repeat("─", 59)))
+	b.WriteString("\n")
+
+	for _, theme := range themes {
+		b.WriteString(r.styles.Dim.Render("│ "))
+		if theme.Count() > 1 {
+			b.WriteString(r.styles.Body.Render(fmt.Sprintf("%s (%dx)", theme.Label, theme.Count())))
+		} else {
+			b.WriteString(theme.Label)
+		}
+		b.WriteString("\n")
+
+		if expanded && theme.Count() > 1 {
+			for _, entry := range theme.Entries {
+				b.WriteString(r.styles.Dim.Render(fmt.Sprintf("│     %s  %s | %s",
+					entry.Timestamp.Format("Mon 1/2"), entry.Timestamp.Format("3:04pm"), entry.EntryText)))
+				b.WriteString("\n")
+			}
+		}
+	}
+
+	b.WriteString(r.styles.Dim.Render("└" + strings.Repeat("─", 59)))
+
+	return b.String()
+}
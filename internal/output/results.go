@@ -0,0 +1,256 @@
+package output
+
+import (
+	"time"
+
+	"github.com/aaryareddy/log_cli/internal/analytics"
+	"github.com/aaryareddy/log_cli/internal/database"
+)
+
+// EntryResult is a log entry as exposed to scripts
+type EntryResult struct {
+	ID        int       `json:"id"`
+	Timestamp time.Time `json:"timestamp"`
+	Text      string    `json:"text"`
+	Momentum  string    `json:"momentum,omitempty"`
+	Tags      []string  `json:"tags"`  // Context tags (e.g., "@deep")
+	Flags     []string  `json:"flags"` // Pattern flags (e.g., "[LEAK]")
+	Private   bool      `json:"private"`
+}
+
+// NewEntryResult converts a database entry
+func NewEntryResult(entry *database.Entry) EntryResult {
+	r := EntryResult{
+		ID:        entry.ID,
+		Timestamp: entry.Timestamp,
+		Text:      entry.EntryText,
+		Private:   entry.Private,
+		Tags:      []string{},
+		Flags:     []string{},
+	}
+	if entry.Momentum != nil {
+		r.Momentum = *entry.Momentum
+	}
+	for _, tag := range entry.Tags {
+		if tag.TagType == "flag" {
+			r.Flags = append(r.Flags, tag.TagValue)
+		} else {
+			r.Tags = append(r.Tags, tag.TagValue)
+		}
+	}
+	return r
+}
+
+// newEntryResults converts a slice of entries (never nil, so JSON shows [])
+func newEntryResults(entries []*database.Entry) []EntryResult {
+	results := make([]EntryResult, 0, len(entries))
+	for _, entry := range entries {
+		results = append(results, NewEntryResult(entry))
+	}
+	return results
+}
+
+// GapResult is a long gap between entries
+type GapResult struct {
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	Kind    string    `json:"kind"` // drift, stepped_away, break, after_hours
+	Minutes int       `json:"minutes"`
+}
+
+// DayResult is the result of `log view`
+type DayResult struct {
+	Date            string        `json:"date"`
+	Intention       string        `json:"intention,omitempty"`
+	IntentionStatus string        `json:"intention_status,omitempty"`
+	Completed       bool          `json:"completed"`
+	PulledOffTrack  string        `json:"pulled_off_track,omitempty"`
+	KeptOnTrack     string        `json:"kept_on_track,omitempty"`
+	TomorrowProtect string        `json:"tomorrow_protect,omitempty"`
+	Entries         []EntryResult `json:"entries"`
+	Gaps            []GapResult   `json:"gaps"`
+}
+
+// NewDayResult builds the day view result, including gaps detected with the given rules
+func NewDayResult(day *database.Day, entries []*database.Entry, away []database.AwayInterval, rules analytics.DriftRules) DayResult {
+	r := DayResult{
+		Date:            day.Date.Format("2006-01-02"),
+		Intention:       deref(day.Intention),
+		IntentionStatus: deref(day.IntentionStatus),
+		Completed:       day.Completed,
+		PulledOffTrack:  deref(day.PulledOffTrack),
+		KeptOnTrack:     deref(day.KeptOnTrack),
+		TomorrowProtect: deref(day.TomorrowProtect),
+		Entries:         newEntryResults(entries),
+		Gaps:            []GapResult{},
+	}
+	for _, gap := range analytics.DetectGaps(entries, away, rules) {
+		r.Gaps = append(r.Gaps, GapResult{
+			Start:   gap.Start,
+			End:     gap.End,
+			Kind:    string(gap.Kind),
+			Minutes: minutes(gap.Duration()),
+		})
+	}
+	return r
+}
+
+// TimeResult is time-weighted stats in minutes
+type TimeResult struct {
+	TotalMinutes      int            `json:"total_minutes"`
+	UntaggedMinutes   int            `json:"untagged_minutes"`
+	MinutesByTag      map[string]int `json:"minutes_by_tag"`
+	MinutesByMomentum map[string]int `json:"minutes_by_momentum"`
+	MinutesByFlag     map[string]int `json:"minutes_by_flag"`
+}
+
+// NewTimeResult converts time stats to minutes
+func NewTimeResult(stats *analytics.TimeStats) *TimeResult {
+	if stats == nil {
+		return nil
+	}
+	return &TimeResult{
+		TotalMinutes:      minutes(stats.Total),
+		UntaggedMinutes:   minutes(stats.Untagged),
+		MinutesByTag:      minutesMap(stats.ByTag),
+		MinutesByMomentum: minutesMap(stats.ByMomentum),
+		MinutesByFlag:     minutesMap(stats.ByFlag),
+	}
+}
+
+// StatsResult is the result of `log stats`
+type StatsResult struct {
+	*database.WeeklyStats
+	Momentum *analytics.MomentumStats `json:"momentum"`
+	Time     *TimeResult              `json:"time,omitempty"`
+}
+
+// NewStatsResult builds the stats result
+func NewStatsResult(stats *database.WeeklyStats, momentum *analytics.MomentumStats, timeStats *analytics.TimeStats) StatsResult {
+	return StatsResult{
+		WeeklyStats: stats,
+		Momentum:    momentum,
+		Time:        NewTimeResult(timeStats),
+	}
+}
+
+// ThemeResult is a cluster of similar entries within a pattern group
+type ThemeResult struct {
+	Label    string        `json:"label"`
+	Keywords []string      `json:"keywords"`
+	Count    int           `json:"count"`
+	Entries  []EntryResult `json:"entries"`
+}
+
+// DriftResult summarizes drift and stepped-away time
+type DriftResult struct {
+	DriftCount         int `json:"drift_count"`
+	DriftMinutes       int `json:"drift_minutes"`
+	SteppedAwayCount   int `json:"stepped_away_count"`
+	SteppedAwayMinutes int `json:"stepped_away_minutes"`
+	BreakMinutes       int `json:"break_minutes"`
+}
+
+// WeekResult is the result of `log week`, `log month` and other period reviews
+type WeekResult struct {
+	Kind          string                   `json:"kind"`
+	Label         string                   `json:"label,omitempty"`
+	StartDate     string                   `json:"start_date"`
+	EndDate       string                   `json:"end_date"`
+	TotalDays     int                      `json:"total_days"`
+	DaysLogged    int                      `json:"days_logged"`
+	TotalEntries  int                      `json:"total_entries"`
+	Momentum      *analytics.MomentumStats `json:"momentum"`
+	TagCounts     map[string]int           `json:"tag_counts"`
+	Time          *TimeResult              `json:"time,omitempty"`
+	Patterns      map[string][]ThemeResult `json:"patterns"`
+	Waste         []EntryResult            `json:"waste"`
+	Findings      []analytics.Finding      `json:"findings"`
+	Drift         *DriftResult             `json:"drift,omitempty"`
+	FollowThrough *analytics.FollowThrough `json:"follow_through,omitempty"`
+	Comparisons   []*analytics.Comparison  `json:"comparisons,omitempty"`
+	Insights      []string                 `json:"insights"`
+}
+
+// NewWeekResult builds the review result, redacted as the level requires (see
+// RedactSummary). Pattern groups are reported as themes, with one theme per
+// entry when clustering is unavailable.
+func NewWeekResult(summary *analytics.WeeklyPatternSummary, insights []string, level Redaction) WeekResult {
+	summary = RedactSummary(summary, level)
+	r := WeekResult{
+		Kind:          summary.Kind,
+		Label:         summary.Label,
+		StartDate:     summary.StartDate,
+		EndDate:       summary.EndDate,
+		TotalDays:     summary.TotalDays,
+		DaysLogged:    summary.DaysLogged,
+		TotalEntries:  summary.TotalEntries,
+		Momentum:      summary.MomentumStats,
+		TagCounts:     summary.TagCounts,
+		Time:          NewTimeResult(summary.TimeStats),
+		Patterns:      make(map[string][]ThemeResult),
+		Waste:         newEntryResults(summary.WastePatterns),
+		Findings:      []analytics.Finding{},
+		FollowThrough: summary.FollowThrough,
+		Comparisons:   summary.Comparisons,
+		Insights:      insights,
+	}
+	if r.Insights == nil {
+		r.Insights = []string{}
+	}
+
+	for flagType, entries := range summary.PatternGroups {
+		themes, ok := summary.Themes[flagType]
+		if !ok {
+			for _, entry := range entries {
+				themes = append(themes, analytics.Theme{Label: entry.EntryText, Entries: []*database.Entry{entry}})
+			}
+		}
+		for _, theme := range themes {
+			r.Patterns[flagType] = append(r.Patterns[flagType], ThemeResult{
+				Label:    theme.Label,
+				Keywords: theme.Keywords,
+				Count:    theme.Count(),
+				Entries:  newEntryResults(theme.Entries),
+			})
+		}
+	}
+
+	if summary.Correlations != nil {
+		r.Findings = append(r.Findings, summary.Correlations.Findings...)
+	}
+
+	if d := summary.Drift; d != nil {
+		r.Drift = &DriftResult{
+			DriftCount:         d.DriftCount,
+			DriftMinutes:       minutes(d.DriftTime),
+			SteppedAwayCount:   d.SteppedAwayCount,
+			SteppedAwayMinutes: minutes(d.SteppedAwayTime),
+			BreakMinutes:       minutes(d.BreakTime),
+		}
+	}
+
+	return r
+}
+
+// deref returns the string value or "" for nil
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// minutes rounds a duration to whole minutes
+func minutes(d time.Duration) int {
+	return int(d.Round(time.Minute).Minutes())
+}
+
+// minutesMap converts a duration map to minutes
+func minutesMap(m map[string]time.Duration) map[string]int {
+	result := make(map[string]int, len(m))
+	for k, d := range m {
+		result[k] = minutes(d)
+	}
+	return result
+}
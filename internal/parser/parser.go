@@ -1,14 +1,105 @@
 package parser
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
+	"sync"
 
 	"github.com/aaryareddy/log_cli/internal/database"
 )
 
-// ParseEntry parses an entry text and extracts momentum, tags, and clean text
+// BuiltinContextTags are the context tags every profile understands
+var BuiltinContextTags = []string{"deep", "social", "admin", "break", "zone", "signoff"}
+
+// contextVocabulary holds the active context tags (built-ins plus a profile's own)
+var contextVocabulary = struct {
+	sync.RWMutex
+	tags  []string
+	regex *regexp.Regexp
+}{
+	tags:  BuiltinContextTags,
+	regex: contextRegexFor(BuiltinContextTags),
+}
+
+// PrivateMarker marks an entry as private: counted in analytics, but its text
+// never reaches markdown or exports
+const PrivateMarker = "!private"
+
+// PrivatePlaceholder replaces a private entry's text where it would be shared
+const PrivatePlaceholder = "[private]"
+
+// privateRegex matches the private marker as a whole word
+var privateRegex = regexp.MustCompile(`(?i)(^|\s)!private(\s|$)`)
+
+// tagNameRegex matches a valid custom tag name (without the @)
+var tagNameRegex = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+
+// SetContextTags extends the built-in context tags with a profile's vocabulary
+// (names without @, e.g. "client", "billing"). Passing nil restores the built-ins.
+func SetContextTags(extra []string) error {
+	tags := append([]string{}, BuiltinContextTags...)
+	for _, tag := range extra {
+		tag = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(tag)), "@")
+		if tag == "" {
+			continue
+		}
+		if !ValidTagName(tag) {
+			return fmt.Errorf("invalid tag name: %q", tag)
+		}
+		if !containsTag(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+
+	contextVocabulary.Lock()
+	defer contextVocabulary.Unlock()
+	contextVocabulary.tags = tags
+	contextVocabulary.regex = contextRegexFor(tags)
+	return nil
+}
+
+// ValidTagName reports whether name (without @) can be used as a context tag
+func ValidTagName(name string) bool {
+	return tagNameRegex.MatchString(strings.ToLower(name))
+}
+
+// ContextTags returns the active context tags with their @ prefix
+func ContextTags() []string {
+	contextVocabulary.RLock()
+	defer contextVocabulary.RUnlock()
+	tags := make([]string, len(contextVocabulary.tags))
+	for i, tag := range contextVocabulary.tags {
+		tags[i] = "@" + tag
+	}
+	return tags
+}
+
+// contextRegexFor builds the @tag matcher for a vocabulary
+func contextRegexFor(tags []string) *regexp.Regexp {
+	quoted := make([]string, len(tags))
+	for i, tag := range tags {
+		quoted[i] = regexp.QuoteMeta(tag)
+	}
+	return regexp.MustCompile(`@(` + strings.Join(quoted, "|") + `)\b`)
+}
+
+// containsTag reports whether tags contains tag
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseEntry parses an entry text and extracts momentum, tags, and clean text.
+// The private marker is dropped from the clean text; check it with IsPrivate.
 func ParseEntry(text string) (cleanText string, momentum *string, tags []database.Tag) {
+	// Drop the private marker
+	text = StripPrivate(text)
+
 	// Convert shortcuts to arrows first
 	text = convertMomentumShortcuts(text)
 
@@ -24,6 +115,19 @@ func ParseEntry(text string) (cleanText string, momentum *string, tags []databas
 	return cleanText, momentum, tags
 }
 
+// IsPrivate reports whether text carries the private marker
+func IsPrivate(text string) bool {
+	return privateRegex.MatchString(text)
+}
+
+// StripPrivate removes the private marker from text
+func StripPrivate(text string) string {
+	for IsPrivate(text) {
+		text = privateRegex.ReplaceAllString(text, " ")
+	}
+	return strings.TrimSpace(text)
+}
+
 // convertMomentumShortcuts converts text shortcuts to arrow symbols
 // Only converts double characters to preserve single characters for normal text use
 // Supports: ++ → ↑, -- → ↓, == → →, << → ←, also -> and <-
@@ -108,7 +212,9 @@ func extractTags(text string) (tags []database.Tag, remaining string) {
 	remaining = text
 
 	// Extract context tags (@word)
-	contextRegex := regexp.MustCompile(`@(deep|social|admin|break|zone|signoff)\b`)
+	contextVocabulary.RLock()
+	contextRegex := contextVocabulary.regex
+	contextVocabulary.RUnlock()
 	contextMatches := contextRegex.FindAllStringSubmatch(text, -1)
 
 	for _, match := range contextMatches {
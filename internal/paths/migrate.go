@@ -0,0 +1,120 @@
+package paths
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Move records a file relocated by MigrateLegacy
+type Move struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// MigrateLegacy moves files from ~/.daylog to their XDG locations, once.
+// ~ is the home p was resolved with (p.LegacyDir), so migration and
+// resolution agree even under an overridden environment. It does nothing
+// when there is no legacy directory or the XDG database already exists, so
+// it is safe to call on every start. The legacy directory is removed if the
+// move leaves it empty.
+func MigrateLegacy(p Paths) ([]Move, error) {
+	legacy := p.LegacyDir
+	if legacy == "" {
+		return nil, nil
+	}
+	if _, err := os.Stat(legacy); os.IsNotExist(err) {
+		return nil, nil
+	}
+	if _, err := os.Stat(p.DB); err == nil {
+		return nil, nil
+	}
+
+	candidates := []Move{
+		{filepath.Join(legacy, "daylog.db"), p.DB},
+		{filepath.Join(legacy, "daylog.db-wal"), p.DB + "-wal"},
+		{filepath.Join(legacy, "daylog.db-shm"), p.DB + "-shm"},
+		{filepath.Join(legacy, "status.json"), p.StatusCache},
+		{filepath.Join(legacy, "hooks"), p.HooksDir},
+		{filepath.Join(legacy, "hooks.log"), p.HooksLog},
+	}
+
+	var moved []Move
+	for _, m := range candidates {
+		if _, err := os.Stat(m.From); os.IsNotExist(err) {
+			continue
+		}
+		if _, err := os.Stat(m.To); err == nil {
+			continue // Never overwrite something already at the new location
+		}
+		if err := move(m.From, m.To); err != nil {
+			return moved, fmt.Errorf("failed to move %s to %s: %w", m.From, m.To, err)
+		}
+		moved = append(moved, m)
+	}
+
+	// Only succeeds if nothing else was left behind
+	_ = os.Remove(legacy)
+
+	return moved, nil
+}
+
+// move renames from to to, copying when they are on different filesystems
+func move(from, to string) error {
+	if err := os.MkdirAll(filepath.Dir(to), 0755); err != nil {
+		return err
+	}
+	if err := os.Rename(from, to); err == nil {
+		return nil
+	}
+
+	if err := copyTree(from, to); err != nil {
+		os.RemoveAll(to)
+		return err
+	}
+	return os.RemoveAll(from)
+}
+
+// copyTree copies a file or directory, preserving permissions
+func copyTree(from, to string) error {
+	return filepath.WalkDir(from, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(from, path)
+		if err != nil {
+			return err
+		}
+		dest := filepath.Join(to, rel)
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return os.MkdirAll(dest, info.Mode().Perm())
+		}
+		return copyFile(path, dest, info.Mode().Perm())
+	})
+}
+
+// copyFile copies a single file
+func copyFile(from, to string, perm fs.FileMode) error {
+	src, err := os.Open(from)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(to, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
+}
@@ -0,0 +1,174 @@
+// Package paths resolves where daylog keeps its files, following the XDG base
+// directory spec: data (the database) in $XDG_DATA_HOME/daylog, config in
+// $XDG_CONFIG_HOME/daylog, state (status cache, hook log) in
+// $XDG_STATE_HOME/daylog, disposable files in $XDG_CACHE_HOME/daylog and the
+// unlock session in $XDG_RUNTIME_DIR/daylog.
+package paths
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// appName is the directory created under each XDG base directory
+const appName = "daylog"
+
+// DefaultMarkdownDir is where daily markdown logs go. They are documents the
+// user reads and edits, so they live outside the XDG data directory.
+const DefaultMarkdownDir = "~/Documents/daylogs"
+
+// DefaultProfile is the profile used when none is selected. Its files keep
+// the top-level locations, so single-profile setups never move.
+const DefaultProfile = "default"
+
+// profileNameRegex matches a valid profile name
+var profileNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,39}$`)
+
+// LegacyDir is the pre-XDG home of the database, status cache and hooks
+const LegacyDir = "~/.daylog"
+
+// Paths are the resolved locations of daylog's files
+type Paths struct {
+	Profile     string `json:"profile"`
+	DataDir     string `json:"data_dir"`
+	ConfigDir   string `json:"config_dir"`
+	StateDir    string `json:"state_dir"`
+	CacheDir    string `json:"cache_dir"`
+	RuntimeDir  string `json:"runtime_dir"`
+	DB          string `json:"db"`
+	ConfigFile  string `json:"config_file"`
+	ProfileFile string `json:"profile_file,omitempty"` // Per-profile overrides layered over ConfigFile
+	StatusCache string `json:"status_cache"`
+	HooksDir    string `json:"hooks_dir"`
+	HooksLog    string `json:"hooks_log"`
+	Synonyms    string `json:"synonyms"` // Extra words for clustering review themes
+	Session     string `json:"session"`  // Cached encryption key while unlocked
+	MarkdownDir string `json:"markdown_dir"`
+	LegacyDir   string `json:"legacy_dir"` // LegacyDir under the resolved home, for MigrateLegacy
+}
+
+// Resolve computes paths from environment variables ("KEY=value" pairs, as
+// from os.Environ). Unset or relative XDG variables fall back to the spec's defaults.
+func Resolve(environ []string) (Paths, error) {
+	env := make(map[string]string, len(environ))
+	for _, kv := range environ {
+		if key, value, ok := strings.Cut(kv, "="); ok {
+			env[key] = value
+		}
+	}
+
+	home := env["HOME"]
+	if home == "" {
+		var err error
+		if home, err = os.UserHomeDir(); err != nil {
+			return Paths{}, fmt.Errorf("failed to get home directory: %w", err)
+		}
+	}
+
+	base := func(name, fallback string) string {
+		// The spec says relative paths must be ignored
+		if dir := env[name]; filepath.IsAbs(dir) {
+			return filepath.Join(dir, appName)
+		}
+		return filepath.Join(home, fallback, appName)
+	}
+
+	p := Paths{
+		Profile:     DefaultProfile,
+		DataDir:     base("XDG_DATA_HOME", ".local/share"),
+		ConfigDir:   base("XDG_CONFIG_HOME", ".config"),
+		StateDir:    base("XDG_STATE_HOME", ".local/state"),
+		CacheDir:    base("XDG_CACHE_HOME", ".cache"),
+		MarkdownDir: filepath.Join(home, strings.TrimPrefix(DefaultMarkdownDir, "~/")),
+	}
+	// There is no fallback in the spec; state is the closest private location
+	p.RuntimeDir = p.StateDir
+	if dir := env["XDG_RUNTIME_DIR"]; filepath.IsAbs(dir) {
+		p.RuntimeDir = filepath.Join(dir, appName)
+	}
+	p.DB = filepath.Join(p.DataDir, "daylog.db")
+	p.ConfigFile = filepath.Join(p.ConfigDir, "config.toml")
+	p.StatusCache = filepath.Join(p.StateDir, "status.json")
+	p.HooksDir = filepath.Join(p.ConfigDir, "hooks")
+	p.HooksLog = filepath.Join(p.StateDir, "hooks.log")
+	p.Synonyms = filepath.Join(p.ConfigDir, "synonyms.txt")
+	p.Session = filepath.Join(p.RuntimeDir, "session")
+	p.LegacyDir = filepath.Join(home, strings.TrimPrefix(LegacyDir, "~/"))
+
+	return p, nil
+}
+
+// ForProfile returns the locations for a named profile: its own database,
+// markdown directory, status cache, unlock session and config overrides under
+// profiles/<name>
+func (p Paths) ForProfile(name string) (Paths, error) {
+	if err := ValidateProfile(name); err != nil {
+		return Paths{}, err
+	}
+	if name == DefaultProfile {
+		return p, nil
+	}
+
+	p.Profile = name
+	p.DB = filepath.Join(p.DataDir, "profiles", name, "daylog.db")
+	p.MarkdownDir = filepath.Join(p.MarkdownDir, name)
+	p.StatusCache = filepath.Join(p.StateDir, "profiles", name, "status.json")
+	p.ProfileFile = filepath.Join(p.ConfigDir, "profiles", name+".toml")
+	p.Session = filepath.Join(p.RuntimeDir, "profiles", name, "session")
+	return p, nil
+}
+
+// ValidateProfile checks that a profile name is safe to use in file paths
+func ValidateProfile(name string) error {
+	if !profileNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q (use lowercase letters, digits, - and _)", name)
+	}
+	return nil
+}
+
+// Default resolves paths from the process environment
+func Default() (Paths, error) {
+	return Resolve(os.Environ())
+}
+
+// Expand expands a leading ~ to the home directory
+func Expand(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, path[1:]), nil
+}
+
+// Format renders the resolved locations for `log paths`
+func Format(p Paths) string {
+	var b strings.Builder
+
+	rows := []struct{ label, path string }{
+		{"Profile", p.Profile},
+		{"Database", p.DB},
+		{"Markdown logs", p.MarkdownDir},
+		{"Config file", p.ConfigFile},
+		{"Profile file", p.ProfileFile},
+		{"Hooks", p.HooksDir},
+		{"Synonyms", p.Synonyms},
+		{"Status cache", p.StatusCache},
+		{"Hook failures", p.HooksLog},
+		{"Unlock session", p.Session},
+		{"Cache", p.CacheDir},
+	}
+	for _, row := range rows {
+		if row.path == "" {
+			continue
+		}
+		b.WriteString(fmt.Sprintf("%-16s %s\n", row.label+":", row.path))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,176 @@
+package profile
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aaryareddy/log_cli/internal/analytics"
+	"github.com/aaryareddy/log_cli/internal/config"
+	"github.com/aaryareddy/log_cli/internal/database"
+)
+
+// Totals is one profile's share of an aggregate report
+type Totals struct {
+	Profile        string         `json:"profile"`
+	DaysLogged     int            `json:"days_logged"`
+	Entries        int            `json:"entries"`
+	TrackedMinutes int            `json:"tracked_minutes"`
+	TagMinutes     map[string]int `json:"tag_minutes"`
+	Error          string         `json:"error,omitempty"` // Why the profile couldn't be read
+}
+
+// Report combines tracked time across profiles for a period
+type Report struct {
+	Label          string         `json:"label"`
+	StartDate      string         `json:"start_date"`
+	EndDate        string         `json:"end_date"`
+	Profiles       []Totals       `json:"profiles"`
+	TrackedMinutes int            `json:"tracked_minutes"`
+	TagMinutes     map[string]int `json:"tag_minutes"`
+}
+
+// Aggregate reads each profile's database read-only and combines time per
+// profile and per tag. Profiles without a database are skipped; unreadable
+// ones are reported with an error instead of failing the whole report.
+func Aggregate(environ []string, names []string, period analytics.Period) (*Report, error) {
+	report := &Report{
+		Label:      period.Label,
+		StartDate:  period.StartDate(),
+		EndDate:    period.EndDate(),
+		Profiles:   []Totals{},
+		TagMinutes: make(map[string]int),
+	}
+
+	for _, name := range names {
+		c, err := config.LoadProfile(name, environ, nil)
+		if err != nil {
+			return nil, fmt.Errorf("profile %s: %w", name, err)
+		}
+
+		totals, err := profileTotals(c, period)
+		if err != nil {
+			report.Profiles = append(report.Profiles, Totals{Profile: name, Error: err.Error()})
+			continue
+		}
+		if totals == nil {
+			continue
+		}
+
+		report.Profiles = append(report.Profiles, *totals)
+		report.TrackedMinutes += totals.TrackedMinutes
+		for tag, minutes := range totals.TagMinutes {
+			report.TagMinutes[tag] += minutes
+		}
+	}
+
+	return report, nil
+}
+
+// profileTotals computes one profile's totals (nil if it has no database yet)
+func profileTotals(c *config.Config, period analytics.Period) (*Totals, error) {
+	store, err := database.OpenReadOnly(c.DBPath())
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer store.Close()
+
+	// The profile's settings in the config table decide its drift threshold
+	if err := c.WithStore(store); err != nil {
+		return nil, err
+	}
+
+	entries, err := store.GetEntriesForDateRange(period.StartDate(), period.EndDate())
+	if err != nil {
+		return nil, err
+	}
+
+	stats := analytics.CalculateTimeStats(entries, c.DriftThreshold())
+	days := make(map[string]bool)
+	for _, entry := range entries {
+		days[entry.Timestamp.Format("2006-01-02")] = true
+	}
+
+	totals := &Totals{
+		Profile:        c.Profile(),
+		DaysLogged:     len(days),
+		Entries:        len(entries),
+		TrackedMinutes: int(stats.Total.Minutes()),
+		TagMinutes:     make(map[string]int, len(stats.ByTag)),
+	}
+	for tag, d := range stats.ByTag {
+		totals.TagMinutes[tag] = int(d.Minutes())
+	}
+	if stats.Untagged > 0 {
+		totals.TagMinutes["(untagged)"] = int(stats.Untagged.Minutes())
+	}
+
+	return totals, nil
+}
+
+// FormatReport renders an aggregate report
+func FormatReport(r *Report) string {
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("ALL PROFILES · %s\n", r.Label))
+	b.WriteString(strings.Repeat("━", 70))
+	b.WriteString("\n\n")
+
+	if len(r.Profiles) == 0 {
+		b.WriteString("  No profiles have logs yet\n")
+		return b.String()
+	}
+
+	for _, t := range r.Profiles {
+		if t.Error != "" {
+			b.WriteString(fmt.Sprintf("%-16s unavailable: %s\n", t.Profile, t.Error))
+			continue
+		}
+		b.WriteString(fmt.Sprintf("%-16s %7s  %s  %d entries over %d days\n",
+			t.Profile, analytics.FormatHours(minutesDuration(t.TrackedMinutes)),
+			bar(t.TrackedMinutes, r.TrackedMinutes), t.Entries, t.DaysLogged))
+	}
+	b.WriteString(fmt.Sprintf("%-16s %7s\n", "Total", analytics.FormatHours(minutesDuration(r.TrackedMinutes))))
+
+	if len(r.TagMinutes) > 0 {
+		b.WriteString("\nBY TAG (all profiles)\n")
+		tags := make([]string, 0, len(r.TagMinutes))
+		for tag := range r.TagMinutes {
+			tags = append(tags, tag)
+		}
+		sort.Slice(tags, func(i, j int) bool {
+			if r.TagMinutes[tags[i]] != r.TagMinutes[tags[j]] {
+				return r.TagMinutes[tags[i]] > r.TagMinutes[tags[j]]
+			}
+			return tags[i] < tags[j]
+		})
+		for _, tag := range tags {
+			b.WriteString(fmt.Sprintf("  %-14s %7s  %s\n", tag,
+				analytics.FormatHours(minutesDuration(r.TagMinutes[tag])),
+				bar(r.TagMinutes[tag], r.TrackedMinutes)))
+		}
+	}
+
+	return b.String()
+}
+
+// bar draws a 20-character share bar
+func bar(part, total int) string {
+	const width = 20
+	filled := 0
+	if total > 0 {
+		filled = part * width / total
+	}
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
+
+// minutesDuration converts minutes to a duration
+func minutesDuration(minutes int) time.Duration {
+	return time.Duration(minutes) * time.Minute
+}
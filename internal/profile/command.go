@@ -0,0 +1,128 @@
+package profile
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/aaryareddy/log_cli/internal/analytics"
+)
+
+// RunCommand handles `log profile <subcommand>`:
+//
+//	list                            known profiles, * marks the default
+//	use <name>                      make name the default profile
+//	report [week|month|--from/--to] combined time across profiles (read-only)
+//	       [--profiles a,b] [--json]
+func RunCommand(environ []string, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		args = []string{"list"}
+	}
+
+	switch args[0] {
+	case "list":
+		infos, err := List(environ)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(out, FormatList(infos))
+		return nil
+
+	case "use":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: log profile use <name>")
+		}
+		if err := SetDefault(environ, args[1]); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Default profile is now %s\n", args[1])
+		return nil
+
+	case "report":
+		return runReport(environ, args[1:], out)
+	}
+
+	return fmt.Errorf("unknown profile command: %q (use list, use or report)", args[0])
+}
+
+// runReport parses report arguments and prints the aggregate
+func runReport(environ []string, args []string, out io.Writer) error {
+	var (
+		kind, from, to, only string
+		asJSON               bool
+	)
+
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; arg {
+		case "week", "month":
+			kind = arg
+		case "--json":
+			asJSON = true
+		case "--from", "--to", "--profiles":
+			if i+1 >= len(args) {
+				return fmt.Errorf("%s requires a value", arg)
+			}
+			i++
+			switch arg {
+			case "--from":
+				from = args[i]
+			case "--to":
+				to = args[i]
+			default:
+				only = args[i]
+			}
+		default:
+			return fmt.Errorf("unknown report argument: %q", arg)
+		}
+	}
+
+	now := time.Now()
+	var (
+		period analytics.Period
+		err    error
+	)
+	switch {
+	case from != "" || to != "":
+		period, err = analytics.ParseRangeArgs(from, to, now)
+	case kind == "month":
+		period = analytics.MonthPeriod(now)
+	default:
+		// Profiles may disagree on week.start, so the report uses the default
+		period = analytics.WeekPeriod(now, analytics.DefaultWeekStart)
+	}
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	if only != "" {
+		names = strings.Split(only, ",")
+	} else {
+		infos, err := List(environ)
+		if err != nil {
+			return err
+		}
+		for _, info := range infos {
+			names = append(names, info.Name)
+		}
+	}
+
+	report, err := Aggregate(environ, names, period)
+	if err != nil {
+		return err
+	}
+
+	if asJSON {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode report: %w", err)
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	}
+
+	fmt.Fprint(out, FormatReport(report))
+	return nil
+}
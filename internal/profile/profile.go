@@ -0,0 +1,187 @@
+// Package profile keeps separate logs for separate parts of life (work,
+// personal, a client). Each profile has its own database, markdown directory,
+// tag vocabulary and ritual settings; see paths.Paths.ForProfile for the layout.
+package profile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aaryareddy/log_cli/internal/config"
+	"github.com/aaryareddy/log_cli/internal/parser"
+	"github.com/aaryareddy/log_cli/internal/paths"
+)
+
+// EnvProfile selects a profile for one shell session
+const EnvProfile = "DAYLOG_PROFILE"
+
+// defaultFile holds the name chosen with `log profile use`
+const defaultFile = "default-profile"
+
+// ParseFlag extracts --profile NAME (or --profile=NAME) from args
+func ParseFlag(args []string) (string, []string, error) {
+	name := ""
+	var rest []string
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--profile":
+			if i+1 >= len(args) {
+				return "", nil, fmt.Errorf("--profile requires a name")
+			}
+			i++
+			name = args[i]
+		case strings.HasPrefix(arg, "--profile="):
+			name = strings.TrimPrefix(arg, "--profile=")
+		default:
+			rest = append(rest, arg)
+		}
+	}
+
+	if name != "" {
+		if err := paths.ValidateProfile(name); err != nil {
+			return "", nil, err
+		}
+	}
+	return name, rest, nil
+}
+
+// Select picks the active profile: the --profile flag, then DAYLOG_PROFILE,
+// then the default chosen with `log profile use`, then "default"
+func Select(flag string, environ []string) (string, error) {
+	if flag != "" {
+		return flag, nil
+	}
+	for _, kv := range environ {
+		if name, ok := strings.CutPrefix(kv, EnvProfile+"="); ok && name != "" {
+			return name, paths.ValidateProfile(name)
+		}
+	}
+
+	base, err := paths.Resolve(environ)
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(filepath.Join(base.ConfigDir, defaultFile))
+	if os.IsNotExist(err) {
+		return paths.DefaultProfile, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read default profile: %w", err)
+	}
+	name := strings.TrimSpace(string(data))
+	if name == "" {
+		return paths.DefaultProfile, nil
+	}
+	return name, paths.ValidateProfile(name)
+}
+
+// SetDefault makes name the profile used when none is given
+func SetDefault(environ []string, name string) error {
+	if err := paths.ValidateProfile(name); err != nil {
+		return err
+	}
+	base, err := paths.Resolve(environ)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(base.ConfigDir, 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	path := filepath.Join(base.ConfigDir, defaultFile)
+	if err := os.WriteFile(path, []byte(name+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to save default profile: %w", err)
+	}
+	return nil
+}
+
+// Load resolves a profile's settings and activates its tag vocabulary
+func Load(name string, environ []string, flags map[string]string) (*config.Config, error) {
+	c, err := config.LoadProfile(name, environ, flags)
+	if err != nil {
+		return nil, err
+	}
+	if err := parser.SetContextTags(c.ContextTags()); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Info describes a profile for `log profile list`
+type Info struct {
+	Name    string `json:"name"`
+	DB      string `json:"db"`
+	Exists  bool   `json:"exists"`  // The database has been created
+	Default bool   `json:"default"` // Used when no profile is given
+}
+
+// List returns every known profile: "default", plus any with a database or a
+// profiles/<name>.toml file
+func List(environ []string) ([]Info, error) {
+	base, err := paths.Resolve(environ)
+	if err != nil {
+		return nil, err
+	}
+	current, err := Select("", environ)
+	if err != nil {
+		current = paths.DefaultProfile
+	}
+
+	names := map[string]bool{paths.DefaultProfile: true, current: true}
+	if dirs, err := os.ReadDir(filepath.Join(base.DataDir, "profiles")); err == nil {
+		for _, d := range dirs {
+			if d.IsDir() && paths.ValidateProfile(d.Name()) == nil {
+				names[d.Name()] = true
+			}
+		}
+	}
+	if files, err := os.ReadDir(filepath.Join(base.ConfigDir, "profiles")); err == nil {
+		for _, f := range files {
+			name, ok := strings.CutSuffix(f.Name(), ".toml")
+			if ok && paths.ValidateProfile(name) == nil {
+				names[name] = true
+			}
+		}
+	}
+
+	var infos []Info
+	for name := range names {
+		c, err := config.LoadProfile(name, environ, nil)
+		if err != nil {
+			return nil, fmt.Errorf("profile %s: %w", name, err)
+		}
+		_, statErr := os.Stat(c.DBPath())
+		infos = append(infos, Info{
+			Name:    name,
+			DB:      c.DBPath(),
+			Exists:  statErr == nil,
+			Default: name == current,
+		})
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Name < infos[j].Name
+	})
+
+	return infos, nil
+}
+
+// FormatList renders profiles, marking the default
+func FormatList(infos []Info) string {
+	var b strings.Builder
+	for _, info := range infos {
+		marker := "  "
+		if info.Default {
+			marker = "* "
+		}
+		state := ""
+		if !info.Exists {
+			state = "  (not created yet)"
+		}
+		b.WriteString(fmt.Sprintf("%s%-16s %s%s\n", marker, info.Name, info.DB, state))
+	}
+	return b.String()
+}
@@ -0,0 +1,557 @@
+// Package quicklog records entries without the interactive TUI, for use from
+// scripts, editor keybindings, cron and non-TTY sessions (`log -m` and `log -`).
+// Entries go through the same parser, store and markdown writer as `log`;
+// rituals that need a prompt are deferred to the next interactive session.
+package quicklog
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/aaryareddy/log_cli/internal/analytics"
+	"github.com/aaryareddy/log_cli/internal/config"
+	"github.com/aaryareddy/log_cli/internal/database"
+	"github.com/aaryareddy/log_cli/internal/hooks"
+	"github.com/aaryareddy/log_cli/internal/markdown"
+	"github.com/aaryareddy/log_cli/internal/output"
+	"github.com/aaryareddy/log_cli/internal/parser"
+	"github.com/aaryareddy/log_cli/internal/paths"
+	"github.com/aaryareddy/log_cli/internal/status"
+	"github.com/aaryareddy/log_cli/internal/webhooks"
+)
+
+// Exit codes for non-interactive commands
+const (
+	ExitOK    = 0 // Entry logged
+	ExitError = 1 // Database or markdown failure
+	ExitUsage = 2 // Bad arguments or empty entry
+)
+
+// ConfigDeferredRituals is the config key listing rituals skipped by non-interactive logging
+const ConfigDeferredRituals = "rituals.deferred"
+
+// Ritual is a prompt that the interactive flow shows at certain points in the day
+type Ritual string
+
+const (
+	RitualIntention Ritual = "intention" // First log of the day
+	RitualWin       Ritual = "win"       // 10th log of the day
+	RitualSignoff   Ritual = "signoff"   // @signoff entry
+)
+
+// ErrEmptyEntry is returned when there is nothing to log
+var ErrEmptyEntry = errors.New("can't log emptiness: entry text is empty")
+
+// UsageError marks errors caused by bad arguments (exit code ExitUsage)
+type UsageError struct {
+	msg string
+}
+
+func (e *UsageError) Error() string {
+	return e.msg
+}
+
+// ParseArgs detects non-interactive logging arguments. It returns ok=false when
+// the arguments don't request non-interactive mode (-m/--message or "-").
+// With "-", each non-empty line of stdin becomes its own entry.
+func ParseArgs(args []string, stdin io.Reader) (texts []string, ok bool, err error) {
+	if len(args) == 0 {
+		return nil, false, nil
+	}
+
+	switch {
+	case args[0] == "-":
+		if len(args) > 1 {
+			return nil, true, &UsageError{"unexpected arguments after '-'"}
+		}
+		texts, err := readLines(stdin)
+		if err != nil {
+			return nil, true, err
+		}
+		if len(texts) == 0 {
+			return nil, true, ErrEmptyEntry
+		}
+		return texts, true, nil
+
+	case args[0] == "-m" || args[0] == "--message":
+		if len(args) < 2 {
+			return nil, true, &UsageError{fmt.Sprintf("%s requires the entry text, e.g. log -m \"Reviewing PR ++ @deep\"", args[0])}
+		}
+		return []string{strings.Join(args[1:], " ")}, true, nil
+
+	case strings.HasPrefix(args[0], "-m=") || strings.HasPrefix(args[0], "--message="):
+		text := args[0][strings.Index(args[0], "=")+1:]
+		return []string{strings.Join(append([]string{text}, args[1:]...), " ")}, true, nil
+	}
+
+	return nil, false, nil
+}
+
+// readLines reads non-empty, trimmed lines
+func readLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read stdin: %w", err)
+	}
+	return lines, nil
+}
+
+// Result describes a logged entry
+type Result struct {
+	Entry    *database.Entry
+	Day      *database.Day
+	Count    int      // Entries logged today, including this one
+	Deferred []Ritual // Rituals skipped because there is no terminal
+}
+
+// Logger records entries non-interactively
+type Logger struct {
+	store      *database.Store
+	writer     *markdown.Writer
+	statusPath string // `log status` cache, refreshed after each insert ("" disables)
+	hooks      *hooks.Runner
+	webhooks   *webhooks.Dispatcher
+	settings   *config.Config // Ritual toggles and thresholds (nil uses the config table only)
+	now        func() time.Time
+}
+
+// NewLogger creates a logger writing to the store and markdown directory.
+// The status cache is the active profile's (p from config.Config.Paths), so
+// profiles never overwrite each other's; a zero Paths disables the cache.
+func NewLogger(store *database.Store, writer *markdown.Writer, p paths.Paths) *Logger {
+	return &Logger{store: store, writer: writer, statusPath: p.StatusCache, now: time.Now}
+}
+
+// WithStatusCache sets the `log status` cache path ("" disables the cache)
+func (l *Logger) WithStatusCache(path string) *Logger {
+	l.statusPath = path
+	return l
+}
+
+// WithHooks fires on-entry hooks after each insert (nil disables hooks)
+func (l *Logger) WithHooks(runner *hooks.Runner) *Logger {
+	l.hooks = runner
+	return l
+}
+
+// WithConfig applies resolved settings: which rituals to defer, the win prompt
+// entry and the drift threshold recorded in the status cache
+func (l *Logger) WithConfig(settings *config.Config) *Logger {
+	l.settings = settings
+	return l
+}
+
+// WithWebhooks queues entry.created deliveries after each insert (nil disables webhooks)
+func (l *Logger) WithWebhooks(dispatcher *webhooks.Dispatcher) *Logger {
+	l.webhooks = dispatcher
+	return l
+}
+
+// Log parses and records a single entry, deferring any ritual it would trigger
+func (l *Logger) Log(text string) (*Result, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, ErrEmptyEntry
+	}
+
+	day, err := l.store.GetOrCreateToday()
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := l.store.GetTodayEntries(day.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	cleanText, momentum, tags := parser.ParseEntry(text)
+	if cleanText == "" && len(tags) == 0 {
+		return nil, ErrEmptyEntry
+	}
+
+	entry := &database.Entry{
+		DayID:     day.ID,
+		Timestamp: l.now(),
+		EntryText: cleanText,
+		Momentum:  momentum,
+		Private:   parser.IsPrivate(text),
+		Tags:      tags,
+	}
+	if err := l.store.InsertEntry(entry); err != nil {
+		return nil, err
+	}
+	if err := l.writer.AppendEntry(day, entry); err != nil {
+		return nil, fmt.Errorf("failed to append entry to markdown: %w", err)
+	}
+
+	result := &Result{Entry: entry, Day: day, Count: len(existing) + 1}
+	l.refreshStatus(append(existing, entry))
+	l.announce(day, entry)
+	if len(existing) == 0 {
+		// The day's first log may open a new week. Like entry.created, a
+		// failed announcement must not fail the insert.
+		_ = l.announceLastWeek(entry.Timestamp)
+	}
+
+	// Rituals the interactive flow would prompt for
+	if l.ritualEnabled(config.KeyRitualIntention) && len(existing) == 0 && (day.Intention == nil || *day.Intention == "") {
+		result.Deferred = append(result.Deferred, RitualIntention)
+	}
+	if l.ritualEnabled(config.KeyRitualWin) && result.Count == l.winPromptEntry() && (day.Win == nil || *day.Win == "") {
+		result.Deferred = append(result.Deferred, RitualWin)
+	}
+	if l.ritualEnabled(config.KeyRitualSignoff) && !day.Completed && hasSignoff(tags) {
+		result.Deferred = append(result.Deferred, RitualSignoff)
+	}
+
+	if len(result.Deferred) > 0 {
+		if err := l.deferRituals(result.Deferred); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// Insert records an already parsed entry on day, such as a missed entry
+// added later from the day view. Unlike Log it may land anywhere in the day,
+// so the day's markdown is rewritten rather than appended to.
+func (l *Logger) Insert(day *database.Day, entry *database.Entry) error {
+	entry.DayID = day.ID
+	if err := l.store.InsertEntry(entry); err != nil {
+		return err
+	}
+	err := l.regenerate(day)
+	l.announce(day, entry)
+	return err
+}
+
+// Update saves an edited entry on day and rewrites the day's markdown
+func (l *Logger) Update(day *database.Day, entry *database.Entry) error {
+	if err := l.store.UpdateEntry(entry); err != nil {
+		return err
+	}
+	return l.regenerate(day)
+}
+
+// Delete removes an entry from day and rewrites the day's markdown
+func (l *Logger) Delete(day *database.Day, entryID int) error {
+	if err := l.store.DeleteEntry(entryID); err != nil {
+		return err
+	}
+	return l.regenerate(day)
+}
+
+// SetIntention saves the day's intention, rewrites its markdown and fires
+// on-intention hooks. It completes a deferred intention ritual.
+func (l *Logger) SetIntention(day *database.Day, intention string) error {
+	if err := l.store.UpdateDayIntention(day.ID, intention); err != nil {
+		return err
+	}
+	day.Intention = &intention
+	if err := l.regenerate(day); err != nil {
+		return err
+	}
+	l.hooks.Fire(hooks.NewPayload(hooks.EventIntention, day, nil))
+	return ClearRitual(l.store, RitualIntention)
+}
+
+// RecordWin saves the day's win and fires on-win hooks. It completes a
+// deferred win ritual.
+func (l *Logger) RecordWin(day *database.Day, win string) error {
+	if err := l.store.UpdateDayWin(day.ID, win); err != nil {
+		return err
+	}
+	day.Win = &win
+	l.hooks.Fire(hooks.NewPayload(hooks.EventWin, day, nil))
+	return ClearRitual(l.store, RitualWin)
+}
+
+// SignOff records the intention's follow-through (status may be empty when it
+// wasn't rated) and the reflections, completes the day, rewrites its markdown
+// fires on-signoff hooks and queues the day.completed webhook. It completes
+// a deferred sign-off ritual.
+func (l *Logger) SignOff(day *database.Day, status database.IntentionStatus, pulledOff, keptOn, protect string) error {
+	if status != "" {
+		if err := l.store.UpdateIntentionStatus(day.ID, status); err != nil {
+			return err
+		}
+		value := string(status)
+		day.IntentionStatus = &value
+	}
+	if err := l.store.CompleteDaySignoff(day.ID, pulledOff, keptOn, protect); err != nil {
+		return err
+	}
+	day.PulledOffTrack, day.KeptOnTrack, day.TomorrowProtect = &pulledOff, &keptOn, &protect
+	day.Completed = true
+
+	if err := l.regenerate(day); err != nil {
+		return err
+	}
+	l.hooks.Fire(hooks.NewPayload(hooks.EventSignoff, day, nil))
+	_ = l.announceDay(day)
+	return ClearRitual(l.store, RitualSignoff)
+}
+
+// AcknowledgeDrift records a drift gap (lastLog until now) as stepped-away
+// time and fires on-drift hooks
+func (l *Logger) AcknowledgeDrift(day *database.Day, lastLog, now time.Time) error {
+	interval := &database.AwayInterval{DayID: day.ID, StartTime: lastLog, EndTime: now}
+	if err := l.store.InsertAwayInterval(interval); err != nil {
+		return err
+	}
+	l.hooks.Fire(hooks.DriftPayload(day, lastLog, now))
+	return nil
+}
+
+// regenerate rewrites day's markdown from the store after a change, and the
+// status cache too when day is today
+func (l *Logger) regenerate(day *database.Day) error {
+	entries, err := l.store.GetTodayEntries(day.ID)
+	if err != nil {
+		return err
+	}
+	if day.Date.Format("2006-01-02") == l.now().Format("2006-01-02") {
+		l.refreshStatus(entries)
+	}
+	if err := l.writer.RegenerateFullDay(day, entries); err != nil {
+		return fmt.Errorf("failed to regenerate markdown: %w", err)
+	}
+	return nil
+}
+
+// announce fires on-entry hooks and queues the entry.created webhook for a
+// new entry
+func (l *Logger) announce(day *database.Day, entry *database.Entry) {
+	l.hooks.Fire(hooks.NewPayload(hooks.EventEntry, day, entry))
+	// Like the status cache, a failed enqueue must not fail the insert. Webhooks
+	// leave the machine, so private text never goes with them.
+	_ = l.webhooks.Enqueue(webhooks.EventEntryCreated, output.NewEntryResult(output.RedactEntry(entry, output.RedactPrivate)))
+}
+
+// announceDay queues the day.completed webhook for a signed-off day, with
+// private entries redacted
+func (l *Logger) announceDay(day *database.Day) error {
+	if l.webhooks == nil {
+		return nil
+	}
+	date := day.Date.Format("2006-01-02")
+	entries, err := l.store.GetTodayEntries(day.ID)
+	if err != nil {
+		return err
+	}
+	away, err := l.store.GetAwayIntervalsForRange(date, date)
+	if err != nil {
+		return err
+	}
+	return l.webhooks.Enqueue(webhooks.EventDayCompleted, output.NewDayResult(day,
+		output.RedactEntries(entries, output.RedactPrivate), away, l.driftRules()))
+}
+
+// announceLastWeek queues the summary.weekly webhook for the week before
+// now's. The dispatcher sends each week once, so this runs on every day's
+// first log and only the first of a new week announces anything.
+func (l *Logger) announceLastWeek(now time.Time) error {
+	if l.webhooks == nil {
+		return nil
+	}
+	weekStart := l.weekStart()
+	week := analytics.WeekPeriod(now, weekStart).Previous(weekStart)
+	entries, err := l.store.GetEntriesForDateRange(week.StartDate(), week.EndDate())
+	if err != nil || len(entries) == 0 {
+		return err
+	}
+	days, err := l.store.GetDaysInRange(week.StartDate(), week.EndDate())
+	if err != nil {
+		return err
+	}
+	away, err := l.store.GetAwayIntervalsForRange(week.StartDate(), week.EndDate())
+	if err != nil {
+		return err
+	}
+
+	summary := analytics.AnalyzePeriod(entries, week)
+	summary.ApplyAwayIntervals(away, l.driftRules())
+	summary.ApplyDays(days)
+	return l.webhooks.EnqueueWeeklySummary(week.Label,
+		output.NewWeekResult(summary, analytics.WeekInsights(summary), output.RedactPrivate))
+}
+
+// refreshStatus rewrites the `log status` cache. The cache is advisory, so failures
+// never fail the insert; the next successful insert repairs it.
+func (l *Logger) refreshStatus(entries []*database.Entry) {
+	if l.statusPath == "" {
+		return
+	}
+	_ = status.WriteCache(l.statusPath, status.FromEntries(entries, l.driftThreshold()))
+}
+
+// driftThreshold returns the configured drift threshold
+func (l *Logger) driftThreshold() time.Duration {
+	if l.settings != nil {
+		return l.settings.DriftThreshold()
+	}
+	minutes, err := l.store.GetConfigInt(analytics.ConfigDriftThresholdMinutes, int(analytics.DefaultDriftThreshold.Minutes()))
+	if err != nil {
+		return analytics.DefaultDriftThreshold
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// driftRules returns the configured drift rules
+func (l *Logger) driftRules() analytics.DriftRules {
+	if l.settings != nil {
+		return l.settings.DriftRules()
+	}
+	rules := analytics.DefaultDriftRules()
+	rules.Threshold = l.driftThreshold()
+	return rules
+}
+
+// weekStart returns the configured first day of the week
+func (l *Logger) weekStart() time.Weekday {
+	if l.settings != nil {
+		return l.settings.WeekStart()
+	}
+	value, _, err := l.store.GetConfig(analytics.ConfigWeekStart)
+	if err != nil {
+		return analytics.DefaultWeekStart
+	}
+	weekStart, err := analytics.ParseWeekStart(value)
+	if err != nil {
+		return analytics.DefaultWeekStart
+	}
+	return weekStart
+}
+
+// winPromptEntry returns the entry count that triggers the win prompt in the interactive flow
+func (l *Logger) winPromptEntry() int {
+	if l.settings != nil {
+		return l.settings.WinPromptEntries()
+	}
+	return 10
+}
+
+// ritualEnabled reports whether a ritual is switched on (all are without settings)
+func (l *Logger) ritualEnabled(key string) bool {
+	return l.settings == nil || l.settings.RitualEnabled(key)
+}
+
+// deferRituals adds rituals to the deferred list so the next interactive `log` can run them
+func (l *Logger) deferRituals(rituals []Ritual) error {
+	pending, err := PendingRituals(l.store)
+	if err != nil {
+		return err
+	}
+	for _, r := range rituals {
+		if !containsRitual(pending, r) {
+			pending = append(pending, r)
+		}
+	}
+	return setPending(l.store, pending)
+}
+
+// PendingRituals returns rituals deferred by non-interactive logging today
+// (stored as "YYYY-MM-DD|intention,signoff"; deferrals from earlier days have lapsed)
+func PendingRituals(store *database.Store) ([]Ritual, error) {
+	value, ok, err := store.GetConfig(ConfigDeferredRituals)
+	if err != nil || !ok {
+		return nil, err
+	}
+	date, list, found := strings.Cut(value, "|")
+	if !found || date != time.Now().Format("2006-01-02") {
+		return nil, nil
+	}
+	var rituals []Ritual
+	for _, part := range strings.Split(list, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			rituals = append(rituals, Ritual(part))
+		}
+	}
+	return rituals, nil
+}
+
+// ClearRitual removes a ritual from the deferred list once it has been run
+func ClearRitual(store *database.Store, ritual Ritual) error {
+	pending, err := PendingRituals(store)
+	if err != nil {
+		return err
+	}
+	var remaining []Ritual
+	for _, r := range pending {
+		if r != ritual {
+			remaining = append(remaining, r)
+		}
+	}
+	return setPending(store, remaining)
+}
+
+// setPending stores the deferred ritual list
+func setPending(store *database.Store, rituals []Ritual) error {
+	parts := make([]string, len(rituals))
+	for i, r := range rituals {
+		parts[i] = string(r)
+	}
+	value := time.Now().Format("2006-01-02") + "|" + strings.Join(parts, ",")
+	return store.SetConfig(ConfigDeferredRituals, value)
+}
+
+// containsRitual reports whether list contains r
+func containsRitual(list []Ritual, r Ritual) bool {
+	for _, item := range list {
+		if item == r {
+			return true
+		}
+	}
+	return false
+}
+
+// hasSignoff reports whether tags include @signoff
+func hasSignoff(tags []database.Tag) bool {
+	for _, tag := range tags {
+		if tag.TagValue == string(database.TagSignoff) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExitCode maps an error from ParseArgs or Log to a process exit code
+func ExitCode(err error) int {
+	var usage *UsageError
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, ErrEmptyEntry), errors.As(err, &usage):
+		return ExitUsage
+	default:
+		return ExitError
+	}
+}
+
+// Summary returns a one-line confirmation for stdout
+func (r *Result) Summary() string {
+	text := parser.ReconstructEntryText(r.Entry.EntryText, r.Entry.Momentum, r.Entry.Tags)
+	if r.Entry.Private {
+		text += " " + parser.PrivateMarker
+	}
+	summary := fmt.Sprintf("logged #%d at %s: %s", r.Count, r.Entry.Timestamp.Format("3:04pm"), text)
+	if len(r.Deferred) > 0 {
+		names := make([]string, len(r.Deferred))
+		for i, ritual := range r.Deferred {
+			names[i] = string(ritual)
+		}
+		summary += fmt.Sprintf(" (deferred: %s - run `log` to complete)", strings.Join(names, ", "))
+	}
+	return summary
+}
@@ -0,0 +1,451 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aaryareddy/log_cli/internal/analytics"
+	"github.com/aaryareddy/log_cli/internal/config"
+	"github.com/aaryareddy/log_cli/internal/database"
+	"github.com/aaryareddy/log_cli/internal/output"
+	"github.com/aaryareddy/log_cli/internal/parser"
+	"github.com/aaryareddy/log_cli/internal/quicklog"
+)
+
+// routes registers the API endpoints (described in openapi.go)
+func (s *Server) routes() {
+	s.mux.HandleFunc("GET /openapi.json", s.handleOpenAPI)
+	s.mux.HandleFunc("POST /v1/entries", s.handleCreateEntry)
+	s.mux.HandleFunc("GET /v1/entries", s.handleListEntries)
+	s.mux.HandleFunc("GET /v1/entries/{id}", s.handleGetEntry)
+	s.mux.HandleFunc("PATCH /v1/entries/{id}", s.handleUpdateEntry)
+	s.mux.HandleFunc("DELETE /v1/entries/{id}", s.handleDeleteEntry)
+	s.mux.HandleFunc("GET /v1/days/{date}", s.handleGetDay)
+	s.mux.HandleFunc("GET /v1/stats", s.handleStats)
+	s.mux.HandleFunc("GET /v1/summary/{kind}", s.handleSummary)
+}
+
+// entryRequest is the body for creating or editing an entry
+type entryRequest struct {
+	Text string `json:"text"` // Raw entry text, e.g. "Reviewing PR ++ @deep"
+}
+
+// createResponse is returned after creating an entry
+type createResponse struct {
+	Entry    output.EntryResult `json:"entry"`
+	Count    int                `json:"count"`
+	Deferred []quicklog.Ritual  `json:"deferred_rituals"`
+}
+
+// handleOpenAPI serves the API description
+func (s *Server) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(openAPISpec))
+}
+
+// handleCreateEntry logs an entry through the same path as `log -m`
+func (s *Server) handleCreateEntry(w http.ResponseWriter, r *http.Request) {
+	var req entryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	result, err := s.logger.Log(req.Text)
+	if err != nil {
+		if errors.Is(err, quicklog.ErrEmptyEntry) {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	deferred := result.Deferred
+	if deferred == nil {
+		deferred = []quicklog.Ritual{}
+	}
+	writeJSON(w, http.StatusCreated, createResponse{
+		Entry:    output.NewEntryResult(result.Entry),
+		Count:    result.Count,
+		Deferred: deferred,
+	})
+}
+
+// handleListEntries returns entries in ?from=YYYY-MM-DD&to=YYYY-MM-DD (default: today),
+// redacted per ?redact=none|private|all (default: export.redact)
+func (s *Server) handleListEntries(w http.ResponseWriter, r *http.Request) {
+	period, err := rangeFromQuery(r, true)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	level, err := s.redactionFromQuery(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entries, err := s.store.GetEntriesForDateRange(period.StartDate(), period.EndDate())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	results := make([]output.EntryResult, 0, len(entries))
+	for _, entry := range output.RedactEntries(entries, level) {
+		results = append(results, output.NewEntryResult(entry))
+	}
+	writeJSON(w, http.StatusOK, results)
+}
+
+// handleGetEntry returns a single entry, redacted per ?redact (default: export.redact)
+func (s *Server) handleGetEntry(w http.ResponseWriter, r *http.Request) {
+	level, err := s.redactionFromQuery(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	entry, ok := s.lookupEntry(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, output.NewEntryResult(output.RedactEntry(entry, level)))
+}
+
+// handleUpdateEntry re-parses the entry text and regenerates the day's markdown
+func (s *Server) handleUpdateEntry(w http.ResponseWriter, r *http.Request) {
+	entry, ok := s.lookupEntry(w, r)
+	if !ok {
+		return
+	}
+
+	var req entryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	cleanText, momentum, tags := parser.ParseEntry(strings.TrimSpace(req.Text))
+	if cleanText == "" && len(tags) == 0 {
+		writeError(w, http.StatusBadRequest, quicklog.ErrEmptyEntry.Error())
+		return
+	}
+	entry.EntryText = cleanText
+	entry.Momentum = momentum
+	entry.Private = parser.IsPrivate(req.Text)
+	entry.Tags = tags
+
+	if err := s.store.UpdateEntry(entry); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if err := s.regenerateDay(entry.DayID); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, output.NewEntryResult(entry))
+}
+
+// handleDeleteEntry deletes an entry and regenerates the day's markdown
+func (s *Server) handleDeleteEntry(w http.ResponseWriter, r *http.Request) {
+	entry, ok := s.lookupEntry(w, r)
+	if !ok {
+		return
+	}
+
+	if err := s.store.DeleteEntry(entry.ID); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if err := s.regenerateDay(entry.DayID); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleGetDay returns a day with its entries, reflections and gaps, redacted
+// per ?redact=none|private|all (default: export.redact)
+func (s *Server) handleGetDay(w http.ResponseWriter, r *http.Request) {
+	level, err := s.redactionFromQuery(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	date := r.PathValue("date")
+	if date == "today" {
+		date = time.Now().Format("2006-01-02")
+	}
+	if _, err := time.ParseInLocation("2006-01-02", date, time.Local); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid date (use YYYY-MM-DD or today)")
+		return
+	}
+
+	day, err := s.store.GetDayByDate(date)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if day == nil {
+		writeError(w, http.StatusNotFound, "no log for "+date)
+		return
+	}
+
+	entries, err := s.store.GetTodayEntries(day.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	away, err := s.store.GetAwayIntervalsForRange(date, date)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, output.NewDayResult(output.RedactDay(day, level),
+		output.RedactEntries(entries, level), away, s.driftRules()))
+}
+
+// handleStats returns stats for ?from&to (default: the last 7 days)
+func (s *Server) handleStats(w http.ResponseWriter, r *http.Request) {
+	period, err := rangeFromQuery(r, false)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	stats, err := s.store.GetStatsForRange(period.StartDate(), period.EndDate())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	entries, err := s.store.GetEntriesForDateRange(period.StartDate(), period.EndDate())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, output.NewStatsResult(stats,
+		analytics.CalculateMomentumStats(entries),
+		analytics.CalculateTimeStats(entries, s.driftRules().Threshold)))
+}
+
+// handleSummary returns a week or month review (?when= accepts the same values as `log week`/`log month`),
+// redacted per ?redact=none|private|all (default: export.redact)
+func (s *Server) handleSummary(w http.ResponseWriter, r *http.Request) {
+	level, err := s.redactionFromQuery(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	weekStart := s.weekStart()
+	when := r.URL.Query().Get("when")
+
+	var period analytics.Period
+	switch r.PathValue("kind") {
+	case analytics.PeriodWeek:
+		period, err = analytics.ParseWeekArg(when, time.Now(), weekStart)
+	case analytics.PeriodMonth:
+		period, err = analytics.ParseMonthArg(when, time.Now())
+	default:
+		writeError(w, http.StatusNotFound, "unknown summary kind (use week or month)")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	summary, err := s.buildSummary(period, weekStart, level)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, output.NewWeekResult(summary, analytics.WeekInsights(summary), level))
+}
+
+// buildSummary analyzes a period with drift, follow-through and comparisons to
+// the previous analytics.DefaultComparisonHistory periods. Entries are redacted
+// before analysis so their text can't surface in themes or patterns.
+func (s *Server) buildSummary(period analytics.Period, weekStart time.Weekday, level output.Redaction) (*analytics.WeeklyPatternSummary, error) {
+	entries, err := s.store.GetEntriesForDateRange(period.StartDate(), period.EndDate())
+	if err != nil {
+		return nil, err
+	}
+	entries = output.RedactEntries(entries, level)
+	days, err := s.store.GetDaysInRange(period.StartDate(), period.EndDate())
+	if err != nil {
+		return nil, err
+	}
+	away, err := s.store.GetAwayIntervalsForRange(period.StartDate(), period.EndDate())
+	if err != nil {
+		return nil, err
+	}
+
+	summary := analytics.AnalyzePeriod(entries, period)
+	if s.config.Settings != nil {
+		synonyms, err := s.config.Settings.Synonyms()
+		if err != nil {
+			return nil, err
+		}
+		summary.ClusterThemes(synonyms)
+	}
+	summary.ApplyAwayIntervals(away, s.driftRules())
+	summary.ApplyDays(days)
+
+	window := analytics.EnergyWindow(period, s.energyWindowDays())
+	energyEntries := entries
+	if window.StartDate() != period.StartDate() {
+		if energyEntries, err = s.store.GetEntriesForDateRange(window.StartDate(), window.EndDate()); err != nil {
+			return nil, err
+		}
+	}
+	summary.ApplyEnergy(energyEntries, window)
+
+	// Previous periods, oldest first
+	var history []*analytics.PeriodSnapshot
+	prev := period
+	for i := 0; i < analytics.DefaultComparisonHistory; i++ {
+		prev = prev.Previous(weekStart)
+		prevEntries, err := s.store.GetEntriesForDateRange(prev.StartDate(), prev.EndDate())
+		if err != nil {
+			return nil, err
+		}
+		prevDays, err := s.store.GetDaysInRange(prev.StartDate(), prev.EndDate())
+		if err != nil {
+			return nil, err
+		}
+		snap := analytics.SnapshotPeriod(prev.Label, prevEntries, prevDays, prev.CalendarDays())
+		history = append([]*analytics.PeriodSnapshot{snap}, history...)
+	}
+	current := analytics.SnapshotPeriod("this "+period.Kind, entries, days, period.CalendarDays())
+	summary.Comparisons = analytics.BuildComparisons(current, history, period.Kind)
+
+	return summary, nil
+}
+
+// lookupEntry parses the {id} path value and loads the entry, writing an error if missing
+func (s *Server) lookupEntry(w http.ResponseWriter, r *http.Request) (*database.Entry, bool) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid entry id")
+		return nil, false
+	}
+
+	entry, err := s.store.GetEntryByID(id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return nil, false
+	}
+	if entry == nil {
+		writeError(w, http.StatusNotFound, "entry not found")
+		return nil, false
+	}
+	return entry, true
+}
+
+// regenerateDay rewrites a day's markdown from the database after an edit or delete
+func (s *Server) regenerateDay(dayID int) error {
+	day, err := s.store.GetDayByID(dayID)
+	if err != nil || day == nil {
+		return err
+	}
+	entries, err := s.store.GetTodayEntries(day.ID)
+	if err != nil {
+		return err
+	}
+	if err := s.writer.RegenerateFullDay(day, entries); err != nil {
+		return err
+	}
+	s.refreshStatus()
+	return nil
+}
+
+// driftRules reads drift settings from the resolved config, or the config table
+func (s *Server) driftRules() analytics.DriftRules {
+	if s.config.Settings != nil {
+		return s.config.Settings.DriftRules()
+	}
+	rules := analytics.DefaultDriftRules()
+	if minutes, err := s.store.GetConfigInt(analytics.ConfigDriftThresholdMinutes, 0); err == nil && minutes > 0 {
+		rules.Threshold = time.Duration(minutes) * time.Minute
+	}
+	if hour, err := s.store.GetConfigInt(analytics.ConfigAfterHoursStart, rules.AfterHoursStart); err == nil {
+		rules.AfterHoursStart = hour
+	}
+	return rules
+}
+
+// energyWindowDays reads how far back energy patterns look from the resolved
+// config, or the config table
+func (s *Server) energyWindowDays() int {
+	if s.config.Settings != nil {
+		return s.config.Settings.EnergyWindowDays()
+	}
+	days, err := s.store.GetConfigInt(analytics.ConfigEnergyWindowDays, analytics.DefaultEnergyWindowDays)
+	if err != nil || days <= 0 {
+		return analytics.DefaultEnergyWindowDays
+	}
+	return days
+}
+
+// weekStart reads the first day of the week from the resolved config, or the config table
+func (s *Server) weekStart() time.Weekday {
+	if s.config.Settings != nil {
+		return s.config.Settings.WeekStart()
+	}
+	value, _, err := s.store.GetConfig(analytics.ConfigWeekStart)
+	if err != nil {
+		return analytics.DefaultWeekStart
+	}
+	weekStart, err := analytics.ParseWeekStart(value)
+	if err != nil {
+		return analytics.DefaultWeekStart
+	}
+	return weekStart
+}
+
+// redactionFromQuery parses ?redact, defaulting to the export.redact setting
+// (private unless configured) so private text needs an explicit ?redact=none
+func (s *Server) redactionFromQuery(r *http.Request) (output.Redaction, error) {
+	value := r.URL.Query().Get("redact")
+	if value == "" {
+		return s.defaultRedaction(), nil
+	}
+	return output.ParseRedaction(value)
+}
+
+// defaultRedaction reads export.redact from the resolved config, or the config table
+func (s *Server) defaultRedaction() output.Redaction {
+	if s.config.Settings != nil {
+		return s.config.Settings.ExportRedaction()
+	}
+	value, ok, err := s.store.GetConfig(config.KeyExportRedact)
+	if err != nil || !ok {
+		return output.RedactPrivate
+	}
+	level, err := output.ParseRedaction(value)
+	if err != nil {
+		return output.RedactPrivate
+	}
+	return level
+}
+
+// rangeFromQuery parses ?from and ?to; with todayDefault an empty range means today only
+func rangeFromQuery(r *http.Request, todayDefault bool) (analytics.Period, error) {
+	from, to := r.URL.Query().Get("from"), r.URL.Query().Get("to")
+	if todayDefault && from == "" && to == "" {
+		now := time.Now()
+		return analytics.RangePeriod(now, now), nil
+	}
+	return analytics.ParseRangeArgs(from, to, time.Now())
+}
@@ -0,0 +1,160 @@
+package server
+
+// openAPISpec describes the API served by `log serve` (OpenAPI 3.0)
+const openAPISpec = `{
+  "openapi": "3.0.3",
+  "info": {
+    "title": "daylog local API",
+    "version": "1.0.0",
+    "description": "Local HTTP/JSON API served by ` + "`log serve`" + `. All endpoints except /openapi.json require 'Authorization: Bearer <token>'."
+  },
+  "servers": [{"url": "http://127.0.0.1:7878"}],
+  "security": [{"bearer": []}],
+  "paths": {
+    "/v1/entries": {
+      "post": {
+        "summary": "Log an entry (parsed like interactive log: ++ @deep [FLOW])",
+        "requestBody": {"required": true, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/EntryRequest"}}}},
+        "responses": {
+          "201": {"description": "Created", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/CreateResponse"}}}},
+          "400": {"$ref": "#/components/responses/Error"}
+        }
+      },
+      "get": {
+        "summary": "List entries in a date range (default: today)",
+        "parameters": [
+          {"name": "from", "in": "query", "schema": {"type": "string", "format": "date"}},
+          {"name": "to", "in": "query", "schema": {"type": "string", "format": "date"}},
+          {"$ref": "#/components/parameters/Redact"}
+        ],
+        "responses": {
+          "200": {"description": "Entries", "content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Entry"}}}}},
+          "400": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/entries/{id}": {
+      "parameters": [{"name": "id", "in": "path", "required": true, "schema": {"type": "integer"}}],
+      "get": {
+        "summary": "Get an entry",
+        "parameters": [{"$ref": "#/components/parameters/Redact"}],
+        "responses": {
+          "200": {"description": "Entry", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Entry"}}}},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      },
+      "patch": {
+        "summary": "Replace an entry's text (momentum and tags are re-parsed)",
+        "requestBody": {"required": true, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/EntryRequest"}}}},
+        "responses": {
+          "200": {"description": "Updated entry", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Entry"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      },
+      "delete": {
+        "summary": "Delete an entry",
+        "responses": {
+          "204": {"description": "Deleted"},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/days/{date}": {
+      "get": {
+        "summary": "Get a day with entries, reflections and gaps",
+        "parameters": [
+          {"name": "date", "in": "path", "required": true, "description": "YYYY-MM-DD or 'today'", "schema": {"type": "string"}},
+          {"$ref": "#/components/parameters/Redact"}
+        ],
+        "responses": {
+          "200": {"description": "Day", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Day"}}}},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/stats": {
+      "get": {
+        "summary": "Stats for a date range (default: the last 7 days)",
+        "parameters": [
+          {"name": "from", "in": "query", "schema": {"type": "string", "format": "date"}},
+          {"name": "to", "in": "query", "schema": {"type": "string", "format": "date"}}
+        ],
+        "responses": {
+          "200": {"description": "Stats", "content": {"application/json": {"schema": {"type": "object"}}}},
+          "400": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/summary/{kind}": {
+      "get": {
+        "summary": "Weekly or monthly review, as shown by log week / log month",
+        "parameters": [
+          {"name": "kind", "in": "path", "required": true, "schema": {"type": "string", "enum": ["week", "month"]}},
+          {"name": "when", "in": "query", "description": "-N, YYYY-Www, YYYY-MM-DD (week) or YYYY-MM (month)", "schema": {"type": "string"}},
+          {"$ref": "#/components/parameters/Redact"}
+        ],
+        "responses": {
+          "200": {"description": "Summary", "content": {"application/json": {"schema": {"type": "object"}}}},
+          "400": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    }
+  },
+  "components": {
+    "securitySchemes": {"bearer": {"type": "http", "scheme": "bearer"}},
+    "parameters": {
+      "Redact": {"name": "redact", "in": "query", "description": "Hide free text: private entries only, or all of it (default: the export.redact setting, normally private)", "schema": {"type": "string", "enum": ["none", "private", "all"]}}
+    },
+    "responses": {
+      "Error": {"description": "Error", "content": {"application/json": {"schema": {"type": "object", "properties": {"error": {"type": "string"}}}}}}
+    },
+    "schemas": {
+      "EntryRequest": {
+        "type": "object",
+        "required": ["text"],
+        "properties": {"text": {"type": "string", "example": "Reviewing PR ++ @deep", "description": "Add !private to keep the text out of markdown and webhooks"}}
+      },
+      "Entry": {
+        "type": "object",
+        "properties": {
+          "id": {"type": "integer"},
+          "timestamp": {"type": "string", "format": "date-time"},
+          "text": {"type": "string"},
+          "momentum": {"type": "string", "enum": ["up", "neutral", "down", "back"]},
+          "tags": {"type": "array", "items": {"type": "string"}},
+          "flags": {"type": "array", "items": {"type": "string"}},
+          "private": {"type": "boolean", "description": "Logged with !private: kept out of markdown and webhooks"}
+        }
+      },
+      "CreateResponse": {
+        "type": "object",
+        "properties": {
+          "entry": {"$ref": "#/components/schemas/Entry"},
+          "count": {"type": "integer", "description": "Entries logged today"},
+          "deferred_rituals": {"type": "array", "items": {"type": "string", "enum": ["intention", "win", "signoff"]}}
+        }
+      },
+      "Day": {
+        "type": "object",
+        "properties": {
+          "date": {"type": "string", "format": "date"},
+          "intention": {"type": "string"},
+          "intention_status": {"type": "string", "enum": ["met", "partial", "missed"]},
+          "completed": {"type": "boolean"},
+          "pulled_off_track": {"type": "string"},
+          "kept_on_track": {"type": "string"},
+          "tomorrow_protect": {"type": "string"},
+          "entries": {"type": "array", "items": {"$ref": "#/components/schemas/Entry"}},
+          "gaps": {"type": "array", "items": {"type": "object", "properties": {
+            "start": {"type": "string", "format": "date-time"},
+            "end": {"type": "string", "format": "date-time"},
+            "kind": {"type": "string", "enum": ["drift", "stepped_away", "break", "after_hours"]},
+            "minutes": {"type": "integer"}
+          }}}
+        }
+      }
+    }
+  }
+}
+`
@@ -0,0 +1,226 @@
+// Package server implements `log serve`, a local HTTP/JSON API for launchers,
+// editor plugins and menubar apps. It reuses the Store, parser and markdown
+// writer, so entries created over HTTP are identical to ones typed into `log`.
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aaryareddy/log_cli/internal/config"
+	"github.com/aaryareddy/log_cli/internal/database"
+	"github.com/aaryareddy/log_cli/internal/hooks"
+	"github.com/aaryareddy/log_cli/internal/markdown"
+	"github.com/aaryareddy/log_cli/internal/paths"
+	"github.com/aaryareddy/log_cli/internal/quicklog"
+	"github.com/aaryareddy/log_cli/internal/status"
+	"github.com/aaryareddy/log_cli/internal/webhooks"
+)
+
+// DefaultAddr is the loopback address `log serve` listens on
+const DefaultAddr = "127.0.0.1:7878"
+
+// ConfigToken is the config key holding the API token
+const ConfigToken = config.KeyServerToken
+
+// Config controls where the server listens and how it authenticates
+type Config struct {
+	Addr       string               // TCP address (default DefaultAddr); ignored when Socket is set
+	Socket     string               // Unix socket path
+	Token      string               // Bearer token required on every request except /openapi.json
+	StatusPath string               // `log status` cache to refresh on changes ("" disables)
+	Hooks      *hooks.Runner        // Fired for entries created over the API (nil disables)
+	Webhooks   *webhooks.Dispatcher // Queues events and delivers the outbox while serving (nil disables)
+	Settings   *config.Config       // Resolved settings (nil reads the config table)
+}
+
+// Server serves the daylog API
+type Server struct {
+	store  *database.Store
+	writer *markdown.Writer
+	logger *quicklog.Logger
+	config Config
+	mux    *http.ServeMux
+}
+
+// New creates a server. The token must be set (see EnsureToken).
+func New(store *database.Store, writer *markdown.Writer, config Config) (*Server, error) {
+	if config.Token == "" {
+		return nil, fmt.Errorf("server token is required")
+	}
+	if config.Addr == "" {
+		config.Addr = DefaultAddr
+	}
+
+	s := &Server{
+		store:  store,
+		writer: writer,
+		logger: quicklog.NewLogger(store, writer, paths.Paths{StatusCache: config.StatusPath}).WithHooks(config.Hooks).WithWebhooks(config.Webhooks).WithConfig(config.Settings),
+		config: config,
+		mux:    http.NewServeMux(),
+	}
+	s.routes()
+	return s, nil
+}
+
+// EnsureToken returns the stored API token, generating and saving one on first use
+func EnsureToken(store *database.Store) (string, error) {
+	token, ok, err := store.GetConfig(ConfigToken)
+	if err != nil {
+		return "", err
+	}
+	if ok && token != "" {
+		return token, nil
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	token = hex.EncodeToString(buf)
+	if err := store.SetConfig(ConfigToken, token); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// Handler returns the HTTP handler (useful for in-process servers)
+func (s *Server) Handler() http.Handler {
+	return s.authenticate(s.mux)
+}
+
+// ListenAndServe serves until ctx is cancelled
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	listener, err := s.listen()
+	if err != nil {
+		return err
+	}
+
+	srv := &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	if s.config.Webhooks != nil {
+		go s.config.Webhooks.Run(ctx, webhooks.DefaultInterval)
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.Serve(listener)
+	}()
+
+	select {
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			return fmt.Errorf("failed to shut down server: %w", err)
+		}
+		return nil
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return fmt.Errorf("server failed: %w", err)
+	}
+}
+
+// Address describes where the server listens (for the startup message)
+func (s *Server) Address() string {
+	if s.config.Socket != "" {
+		return "unix:" + s.config.Socket
+	}
+	return "http://" + s.config.Addr
+}
+
+// listen opens the Unix socket (owner-only) or TCP listener
+func (s *Server) listen() (net.Listener, error) {
+	if s.config.Socket == "" {
+		listener, err := net.Listen("tcp", s.config.Addr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to listen on %s: %w", s.config.Addr, err)
+		}
+		return listener, nil
+	}
+
+	// Remove a stale socket from a previous run
+	if err := os.Remove(s.config.Socket); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to remove stale socket: %w", err)
+	}
+	listener, err := net.Listen("unix", s.config.Socket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", s.config.Socket, err)
+	}
+	if err := os.Chmod(s.config.Socket, 0600); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to restrict socket permissions: %w", err)
+	}
+	return listener, nil
+}
+
+// authenticate requires "Authorization: Bearer <token>" on everything but the API description
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/openapi.json" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !found || subtle.ConstantTimeCompare([]byte(token), []byte(s.config.Token)) != 1 {
+			writeError(w, http.StatusUnauthorized, "missing or invalid bearer token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// apiError is the JSON body of every error response
+type apiError struct {
+	Error string `json:"error"`
+}
+
+// writeJSON writes v with the given status code
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+// writeError writes a JSON error
+func writeError(w http.ResponseWriter, code int, msg string) {
+	writeJSON(w, code, apiError{Error: msg})
+}
+
+// refreshStatus rewrites the `log status` cache after an edit or delete
+func (s *Server) refreshStatus() {
+	if s.config.StatusPath == "" {
+		return
+	}
+	day, err := s.store.GetDayByDate(time.Now().Format("2006-01-02"))
+	if err != nil || day == nil {
+		return
+	}
+	entries, err := s.store.GetTodayEntries(day.ID)
+	if err != nil {
+		return
+	}
+	cache, err := status.ReadCache(s.config.StatusPath)
+	if err != nil {
+		return
+	}
+	_ = status.WriteCache(s.config.StatusPath, status.FromEntries(entries, cache.Threshold()))
+}
@@ -0,0 +1,402 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aaryareddy/log_cli/internal/database"
+	"github.com/aaryareddy/log_cli/internal/markdown"
+	"github.com/aaryareddy/log_cli/internal/output"
+	"github.com/aaryareddy/log_cli/internal/parser"
+)
+
+const testToken = "test-token"
+
+// testServer is an in-process API over a temporary database and markdown directory
+type testServer struct {
+	*httptest.Server
+	store       *database.Store
+	markdownDir string
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	dir := t.TempDir()
+	store, err := database.NewStore(filepath.Join(dir, "daylog.db"))
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	markdownDir := filepath.Join(dir, "logs")
+	writer, err := markdown.NewWriter(markdownDir)
+	if err != nil {
+		t.Fatalf("NewWriter: %v", err)
+	}
+
+	s, err := New(store, writer, Config{Token: testToken})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	srv := httptest.NewServer(s.Handler())
+	t.Cleanup(srv.Close)
+
+	return &testServer{Server: srv, store: store, markdownDir: markdownDir}
+}
+
+// do sends a request with the test token and returns the status and body
+func (ts *testServer) do(t *testing.T, method, path string, body any) (int, []byte) {
+	t.Helper()
+	return ts.doWithToken(t, method, path, "Bearer "+testToken, body)
+}
+
+func (ts *testServer) doWithToken(t *testing.T, method, path, auth string, body any) (int, []byte) {
+	t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, ts.URL+path, reader)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	if auth != "" {
+		req.Header.Set("Authorization", auth)
+	}
+
+	resp, err := ts.Client().Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	return resp.StatusCode, data
+}
+
+// create logs an entry and returns it
+func (ts *testServer) create(t *testing.T, text string) output.EntryResult {
+	t.Helper()
+
+	code, body := ts.do(t, http.MethodPost, "/v1/entries", entryRequest{Text: text})
+	if code != http.StatusCreated {
+		t.Fatalf("POST /v1/entries = %d: %s", code, body)
+	}
+	var created createResponse
+	decode(t, body, &created)
+	return created.Entry
+}
+
+// markdown returns today's markdown file
+func (ts *testServer) markdown(t *testing.T) string {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join(ts.markdownDir, time.Now().Format("2006-01-02")+".md"))
+	if err != nil {
+		t.Fatalf("read markdown: %v", err)
+	}
+	return string(data)
+}
+
+func decode(t *testing.T, body []byte, v any) {
+	t.Helper()
+	if err := json.Unmarshal(body, v); err != nil {
+		t.Fatalf("decode %s: %v", body, err)
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	ts := newTestServer(t)
+
+	tests := []struct {
+		name string
+		path string
+		auth string
+		want int
+	}{
+		{"missing token", "/v1/entries", "", http.StatusUnauthorized},
+		{"wrong token", "/v1/entries", "Bearer nope", http.StatusUnauthorized},
+		{"not a bearer token", "/v1/entries", testToken, http.StatusUnauthorized},
+		{"valid token", "/v1/entries", "Bearer " + testToken, http.StatusOK},
+		{"API description needs no token", "/openapi.json", "", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, body := ts.doWithToken(t, http.MethodGet, tt.path, tt.auth, nil)
+			if code != tt.want {
+				t.Errorf("GET %s = %d, want %d: %s", tt.path, code, tt.want, body)
+			}
+		})
+	}
+}
+
+func TestNewRequiresToken(t *testing.T) {
+	if _, err := New(nil, nil, Config{}); err == nil {
+		t.Error("New without a token succeeded")
+	}
+}
+
+func TestEntryRoundTrip(t *testing.T) {
+	ts := newTestServer(t)
+
+	created := ts.create(t, "Reviewing PR ++ @deep")
+	if created.Text != "Reviewing PR" || created.Momentum != "up" {
+		t.Errorf("created = %+v, want text %q and momentum up", created, "Reviewing PR")
+	}
+
+	stored, err := ts.store.GetEntryByID(created.ID)
+	if err != nil || stored == nil {
+		t.Fatalf("GetEntryByID(%d) = %v, %v", created.ID, stored, err)
+	}
+	if stored.EntryText != "Reviewing PR" || len(stored.Tags) != 1 || stored.Tags[0].TagValue != "@deep" {
+		t.Errorf("stored = %q %v, want %q [@deep]", stored.EntryText, stored.Tags, "Reviewing PR")
+	}
+	if md := ts.markdown(t); !strings.Contains(md, "Reviewing PR") {
+		t.Errorf("markdown after create lacks the entry:\n%s", md)
+	}
+
+	path := "/v1/entries/" + strconv.Itoa(created.ID)
+	code, body := ts.do(t, http.MethodPatch, path, entryRequest{Text: "Writing docs -- [STUCK]"})
+	if code != http.StatusOK {
+		t.Fatalf("PATCH %s = %d: %s", path, code, body)
+	}
+	var updated output.EntryResult
+	decode(t, body, &updated)
+	if updated.Text != "Writing docs" || updated.Momentum != "down" || len(updated.Flags) != 1 {
+		t.Errorf("updated = %+v", updated)
+	}
+
+	stored, err = ts.store.GetEntryByID(created.ID)
+	if err != nil || stored == nil || stored.EntryText != "Writing docs" {
+		t.Fatalf("stored after PATCH = %v, %v", stored, err)
+	}
+	md := ts.markdown(t)
+	if !strings.Contains(md, "Writing docs") || strings.Contains(md, "Reviewing PR") {
+		t.Errorf("markdown after edit not regenerated:\n%s", md)
+	}
+
+	if code, body := ts.do(t, http.MethodPatch, path, entryRequest{Text: "  "}); code != http.StatusBadRequest {
+		t.Errorf("PATCH with empty text = %d, want 400: %s", code, body)
+	}
+
+	if code, body := ts.do(t, http.MethodDelete, path, nil); code != http.StatusNoContent {
+		t.Fatalf("DELETE %s = %d: %s", path, code, body)
+	}
+	if stored, err := ts.store.GetEntryByID(created.ID); err != nil || stored != nil {
+		t.Errorf("entry still stored after DELETE: %v, %v", stored, err)
+	}
+	if md := ts.markdown(t); strings.Contains(md, "Writing docs") {
+		t.Errorf("markdown after delete still has the entry:\n%s", md)
+	}
+
+	if code, _ := ts.do(t, http.MethodGet, path, nil); code != http.StatusNotFound {
+		t.Errorf("GET deleted entry = %d, want 404", code)
+	}
+	if code, _ := ts.do(t, http.MethodGet, "/v1/entries/abc", nil); code != http.StatusBadRequest {
+		t.Errorf("GET /v1/entries/abc = %d, want 400", code)
+	}
+}
+
+func TestCreateRejectsEmptyEntry(t *testing.T) {
+	ts := newTestServer(t)
+
+	if code, body := ts.do(t, http.MethodPost, "/v1/entries", entryRequest{Text: " "}); code != http.StatusBadRequest {
+		t.Errorf("POST empty entry = %d, want 400: %s", code, body)
+	}
+	if code, body := ts.do(t, http.MethodPost, "/v1/entries", "not an object"); code != http.StatusBadRequest {
+		t.Errorf("POST invalid body = %d, want 400: %s", code, body)
+	}
+}
+
+func TestDayEndpoint(t *testing.T) {
+	ts := newTestServer(t)
+	ts.create(t, "Standup ++ @social")
+	private := ts.create(t, "Therapy session !private @break")
+
+	code, body := ts.do(t, http.MethodGet, "/v1/days/today", nil)
+	if code != http.StatusOK {
+		t.Fatalf("GET /v1/days/today = %d: %s", code, body)
+	}
+	var day output.DayResult
+	decode(t, body, &day)
+	if day.Date != time.Now().Format("2006-01-02") || len(day.Entries) != 2 {
+		t.Fatalf("day = %s with %d entries, want today with 2", day.Date, len(day.Entries))
+	}
+	// Private text is redacted unless asked for (export.redact defaults to private)
+	if got := day.Entries[1].Text; got != parser.PrivatePlaceholder {
+		t.Errorf("private entry text = %q, want %q", got, parser.PrivatePlaceholder)
+	}
+
+	_, body = ts.do(t, http.MethodGet, "/v1/days/today?redact=none", nil)
+	decode(t, body, &day)
+	if got := day.Entries[1].Text; got != "Therapy session" {
+		t.Errorf("private entry text with redact=none = %q, want %q", got, "Therapy session")
+	}
+
+	_, body = ts.do(t, http.MethodGet, "/v1/entries/"+strconv.Itoa(private.ID), nil)
+	var entry output.EntryResult
+	decode(t, body, &entry)
+	if entry.Text != parser.PrivatePlaceholder {
+		t.Errorf("GET private entry text = %q, want %q", entry.Text, parser.PrivatePlaceholder)
+	}
+
+	tests := []struct {
+		path string
+		want int
+	}{
+		{"/v1/days/2000-01-01", http.StatusNotFound},
+		{"/v1/days/yesterday", http.StatusBadRequest},
+		{"/v1/days/today?redact=some", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		if code, body := ts.do(t, http.MethodGet, tt.path, nil); code != tt.want {
+			t.Errorf("GET %s = %d, want %d: %s", tt.path, code, tt.want, body)
+		}
+	}
+}
+
+func TestSummaryRedaction(t *testing.T) {
+	ts := newTestServer(t)
+	ts.create(t, "Doomscrolling about the divorce -- [LEAK] !private")
+	ts.create(t, "Refactoring the parser ++ @deep [FLOW]")
+
+	tests := []struct {
+		query string
+		leaks bool
+	}{
+		{"", false},
+		{"?redact=private", false},
+		{"?redact=none", true},
+	}
+	for _, tt := range tests {
+		code, body := ts.do(t, http.MethodGet, "/v1/summary/week"+tt.query, nil)
+		if code != http.StatusOK {
+			t.Fatalf("GET /v1/summary/week%s = %d: %s", tt.query, code, body)
+		}
+		if got := bytes.Contains(body, []byte("divorce")); got != tt.leaks {
+			t.Errorf("GET /v1/summary/week%s contains private text = %v, want %v: %s", tt.query, got, tt.leaks, body)
+		}
+	}
+
+	// Under all, theme labels go too: they are drawn from entry text
+	if _, body := ts.do(t, http.MethodGet, "/v1/summary/week?redact=all", nil); bytes.Contains(body, []byte("parser")) {
+		t.Errorf("GET /v1/summary/week?redact=all contains entry text: %s", body)
+	}
+
+	if code, body := ts.do(t, http.MethodGet, "/v1/summary/week?redact=some", nil); code != http.StatusBadRequest {
+		t.Errorf("GET with an invalid redaction = %d, want 400: %s", code, body)
+	}
+}
+
+func TestRangeEndpoint(t *testing.T) {
+	ts := newTestServer(t)
+	ts.create(t, "Writing ++ @deep")
+	ts.create(t, "Email @admin")
+
+	today := time.Now().Format("2006-01-02")
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{"", 2},
+		{"?from=" + today + "&to=" + today, 2},
+		{"?from=2000-01-01&to=2000-01-07", 0},
+	}
+	for _, tt := range tests {
+		code, body := ts.do(t, http.MethodGet, "/v1/entries"+tt.query, nil)
+		if code != http.StatusOK {
+			t.Fatalf("GET /v1/entries%s = %d: %s", tt.query, code, body)
+		}
+		var entries []output.EntryResult
+		decode(t, body, &entries)
+		if len(entries) != tt.want {
+			t.Errorf("GET /v1/entries%s returned %d entries, want %d", tt.query, len(entries), tt.want)
+		}
+	}
+
+	if code, body := ts.do(t, http.MethodGet, "/v1/entries?from=tomorrowish", nil); code != http.StatusBadRequest {
+		t.Errorf("GET with an invalid range = %d, want 400: %s", code, body)
+	}
+}
+
+func TestStatsAndWeekly(t *testing.T) {
+	ts := newTestServer(t)
+	ts.create(t, "Writing ++ @deep [FLOW]")
+	ts.create(t, "Scrolling news -- [LEAK]")
+	ts.create(t, "Standup @social")
+
+	code, body := ts.do(t, http.MethodGet, "/v1/stats", nil)
+	if code != http.StatusOK {
+		t.Fatalf("GET /v1/stats = %d: %s", code, body)
+	}
+	var stats struct {
+		TotalEntries int            `json:"total_entries"`
+		DaysLogged   int            `json:"days_logged"`
+		TagCounts    map[string]int `json:"tag_counts"`
+		Momentum     struct {
+			UpCount   int `json:"up_count"`
+			DownCount int `json:"down_count"`
+		} `json:"momentum"`
+	}
+	decode(t, body, &stats)
+	if stats.TotalEntries != 3 || stats.DaysLogged != 1 {
+		t.Errorf("stats = %d entries over %d days, want 3 over 1", stats.TotalEntries, stats.DaysLogged)
+	}
+	if stats.TagCounts["@deep"] != 1 || stats.TagCounts["@social"] != 1 {
+		t.Errorf("tag counts = %v", stats.TagCounts)
+	}
+	if stats.Momentum.UpCount != 1 || stats.Momentum.DownCount != 1 {
+		t.Errorf("momentum = %+v, want one up and one down", stats.Momentum)
+	}
+
+	code, body = ts.do(t, http.MethodGet, "/v1/summary/week", nil)
+	if code != http.StatusOK {
+		t.Fatalf("GET /v1/summary/week = %d: %s", code, body)
+	}
+	var week struct {
+		Kind         string                          `json:"kind"`
+		TotalEntries int                             `json:"total_entries"`
+		Patterns     map[string][]output.ThemeResult `json:"patterns"`
+	}
+	decode(t, body, &week)
+	if week.Kind != "week" || week.TotalEntries != 3 {
+		t.Errorf("week = %s with %d entries, want week with 3", week.Kind, week.TotalEntries)
+	}
+	if len(week.Patterns["[LEAK]"]) == 0 || len(week.Patterns["[FLOW]"]) == 0 {
+		t.Errorf("week patterns = %v, want [LEAK] and [FLOW]", week.Patterns)
+	}
+
+	tests := []struct {
+		path string
+		want int
+	}{
+		{"/v1/summary/month", http.StatusOK},
+		{"/v1/summary/week?when=-1", http.StatusOK},
+		{"/v1/summary/year", http.StatusNotFound},
+		{"/v1/summary/week?when=soon", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		if code, body := ts.do(t, http.MethodGet, tt.path, nil); code != tt.want {
+			t.Errorf("GET %s = %d, want %d: %s", tt.path, code, tt.want, body)
+		}
+	}
+}
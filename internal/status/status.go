@@ -0,0 +1,264 @@
+// Package status answers `log status` from a small cache file written on each
+// insert, so it is cheap enough to run from every shell prompt or status bar.
+package status
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aaryareddy/log_cli/internal/analytics"
+	"github.com/aaryareddy/log_cli/internal/database"
+	"github.com/aaryareddy/log_cli/internal/paths"
+)
+
+// DefaultFormat is the --format used when none is given
+const DefaultFormat = "{since} {momentum} {count}"
+
+// Escalation levels, from calm to overdue
+const (
+	LevelNone  = "none"  // Nothing logged today
+	LevelOK    = "ok"    // Logged recently
+	LevelWarn  = "warn"  // Two thirds of the way to a drift alert
+	LevelDrift = "drift" // Past the drift threshold
+)
+
+// Cache is the state written after each insert
+type Cache struct {
+	Date             string    `json:"date"` // YYYY-MM-DD of the last entry
+	LastLog          time.Time `json:"last_log"`
+	Momentum         string    `json:"momentum,omitempty"`
+	Count            int       `json:"count"` // Entries logged that day
+	ThresholdMinutes int       `json:"threshold_minutes"`
+}
+
+// Threshold returns the drift threshold recorded in the cache
+func (c Cache) Threshold() time.Duration {
+	if c.ThresholdMinutes <= 0 {
+		return analytics.DefaultDriftThreshold
+	}
+	return time.Duration(c.ThresholdMinutes) * time.Minute
+}
+
+// FromEntries builds the cache from a day's entries (e.g., after an edit or delete)
+func FromEntries(entries []*database.Entry, threshold time.Duration) Cache {
+	c := Cache{ThresholdMinutes: int(threshold.Minutes())}
+	for _, entry := range entries {
+		if entry.Timestamp.After(c.LastLog) {
+			c.LastLog = entry.Timestamp
+			c.Date = entry.Timestamp.Format("2006-01-02")
+			c.Momentum = ""
+			if entry.Momentum != nil {
+				c.Momentum = *entry.Momentum
+			}
+		}
+	}
+	c.Count = len(entries)
+	return c
+}
+
+// WriteCache atomically replaces the cache file
+func WriteCache(path string, c Cache) error {
+	path, err := paths.Expand(path)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create status directory: %w", err)
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to encode status cache: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write status cache: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to replace status cache: %w", err)
+	}
+	return nil
+}
+
+// ReadCache reads the cache file. A missing file means nothing has been logged yet.
+func ReadCache(path string) (Cache, error) {
+	path, err := paths.Expand(path)
+	if err != nil {
+		return Cache{}, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return Cache{}, nil
+	}
+	if err != nil {
+		return Cache{}, fmt.Errorf("failed to read status cache: %w", err)
+	}
+
+	var c Cache
+	if err := json.Unmarshal(data, &c); err != nil {
+		return Cache{}, fmt.Errorf("failed to parse status cache: %w", err)
+	}
+	return c, nil
+}
+
+// Status is the cache evaluated at a point in time
+type Status struct {
+	Since    time.Duration // Time since the last entry (0 if none today)
+	Momentum string
+	Count    int
+	Level    string
+	LastLog  time.Time
+}
+
+// Evaluate computes the status at now. Entries from previous days count as nothing logged.
+func Evaluate(c Cache, now time.Time) Status {
+	if c.LastLog.IsZero() || c.Date != now.Format("2006-01-02") {
+		return Status{Level: LevelNone}
+	}
+
+	s := Status{
+		Since:    now.Sub(c.LastLog),
+		Momentum: c.Momentum,
+		Count:    c.Count,
+		LastLog:  c.LastLog,
+		Level:    LevelOK,
+	}
+
+	threshold := c.Threshold()
+	switch {
+	case s.Since >= threshold:
+		s.Level = LevelDrift
+	case s.Since*3 >= threshold*2:
+		s.Level = LevelWarn
+	}
+
+	return s
+}
+
+// Format expands placeholders in format:
+// {since} (e.g. "42m", "1h5m"), {since_min}, {momentum} (arrow), {count}, {level}, {last} (e.g. "2:15pm")
+func Format(format string, s Status) string {
+	since, sinceMin, last := "-", "", "-"
+	if s.Level != LevelNone {
+		since = formatSince(s.Since)
+		sinceMin = fmt.Sprintf("%d", int(s.Since.Minutes()))
+		last = s.LastLog.Format("3:04pm")
+	}
+
+	replacer := strings.NewReplacer(
+		"{since}", since,
+		"{since_min}", sinceMin,
+		"{momentum}", momentumArrow(s.Momentum),
+		"{count}", fmt.Sprintf("%d", s.Count),
+		"{level}", s.Level,
+		"{last}", last,
+	)
+	// Collapse the gaps left by empty placeholders (e.g., no momentum)
+	return strings.Join(strings.Fields(replacer.Replace(format)), " ")
+}
+
+// Presets are ready-made outputs for status bars and prompts
+var Presets = map[string]func(Status) string{
+	"tmux":     tmuxStatus,
+	"starship": starshipStatus,
+	"prompt":   promptStatus,
+}
+
+// Render reads the cache at path and renders it with a preset (if given) or a format string
+func Render(path, format, preset string, now time.Time) (string, error) {
+	c, err := ReadCache(path)
+	if err != nil {
+		return "", err
+	}
+	s := Evaluate(c, now)
+
+	if preset != "" {
+		render, ok := Presets[preset]
+		if !ok {
+			return "", fmt.Errorf("unknown status preset: %q (use tmux, starship or prompt)", preset)
+		}
+		return render(s), nil
+	}
+
+	if format == "" {
+		format = DefaultFormat
+	}
+	return Format(format, s), nil
+}
+
+// tmuxStatus renders a tmux status-line segment, colored by level
+// (status-right '#(log status --preset tmux)')
+func tmuxStatus(s Status) string {
+	colors := map[string]string{
+		LevelNone:  "colour244",
+		LevelOK:    "colour114",
+		LevelWarn:  "colour221",
+		LevelDrift: "colour203",
+	}
+	text := Format("⏱ {since} {momentum} ({count})", s)
+	if s.Level == LevelNone {
+		text = "⏱ no logs"
+	}
+	return fmt.Sprintf("#[fg=%s]%s#[default]", colors[s.Level], text)
+}
+
+// starshipStatus renders text for a starship custom module. Starship styles the
+// module itself, so escalation is shown with a marker rather than color.
+func starshipStatus(s Status) string {
+	switch s.Level {
+	case LevelNone:
+		return ""
+	case LevelDrift:
+		return Format("⚠ {since} {momentum}", s)
+	case LevelWarn:
+		return Format("⏳ {since} {momentum}", s)
+	default:
+		return Format("⏱ {since} {momentum}", s)
+	}
+}
+
+// promptStatus renders an ANSI-colored segment for shell prompts (PS1, fish_prompt)
+func promptStatus(s Status) string {
+	codes := map[string]string{
+		LevelNone:  "90",
+		LevelOK:    "32",
+		LevelWarn:  "33",
+		LevelDrift: "1;31",
+	}
+	if s.Level == LevelNone {
+		return ""
+	}
+	return fmt.Sprintf("\033[%sm%s\033[0m", codes[s.Level], Format("{since} {momentum}", s))
+}
+
+// formatSince formats a duration compactly for status bars ("42m", "1h5m")
+func formatSince(d time.Duration) string {
+	hours := int(d.Hours())
+	mins := int(d.Minutes()) % 60
+	if hours > 0 {
+		return fmt.Sprintf("%dh%dm", hours, mins)
+	}
+	return fmt.Sprintf("%dm", mins)
+}
+
+// momentumArrow returns the arrow for a momentum value
+func momentumArrow(momentum string) string {
+	switch database.Momentum(momentum) {
+	case database.MomentumUp:
+		return "↑"
+	case database.MomentumNeutral:
+		return "→"
+	case database.MomentumDown:
+		return "↓"
+	case database.MomentumBack:
+		return "←"
+	default:
+		return ""
+	}
+}
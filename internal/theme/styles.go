@@ -0,0 +1,79 @@
+package theme
+
+import "github.com/charmbracelet/lipgloss"
+
+// Styles are a theme's text and box styles, named by what they're for
+type Styles struct {
+	Header    lipgloss.Style // Main titles
+	Subheader lipgloss.Style // Sections
+	Bold      lipgloss.Style // Emphasis
+	Body      lipgloss.Style // Regular text
+	Dim       lipgloss.Style // Helper and meta info
+	Accent    lipgloss.Style // Interactive elements
+	Success   lipgloss.Style // Wins and positives
+	Warning   lipgloss.Style // Alerts and drift
+	Error     lipgloss.Style // Problems
+	Metadata  lipgloss.Style // Labels
+	Prompt    lipgloss.Style // Input prompts
+	Selected  lipgloss.Style // The selected item in a list
+
+	// Pattern flags
+	Flow  lipgloss.Style
+	Gold  lipgloss.Style
+	Stuck lipgloss.Style
+	Leak  lipgloss.Style
+
+	Box          lipgloss.Style // Main container, bordered and padded
+	Panel        lipgloss.Style // Bordered inset (e.g. an entry preview)
+	Input        lipgloss.Style
+	InputFocused lipgloss.Style
+}
+
+// newStyles builds the styles for a palette. Without color, dim text is
+// faint so helper text still recedes.
+func newStyles(p Palette, noColor bool) Styles {
+	s := Styles{
+		Header:    lipgloss.NewStyle().Foreground(p.Primary).Bold(true),
+		Subheader: lipgloss.NewStyle().Foreground(p.Metadata).Bold(true),
+		Bold:      lipgloss.NewStyle().Foreground(p.Text).Bold(true),
+		Body:      lipgloss.NewStyle().Foreground(p.Text),
+		Dim:       lipgloss.NewStyle().Foreground(p.Dim).Faint(noColor),
+		Accent:    lipgloss.NewStyle().Foreground(p.Accent).Bold(true),
+		Success:   lipgloss.NewStyle().Foreground(p.Success).Bold(true),
+		Warning:   lipgloss.NewStyle().Foreground(p.Warning).Bold(true),
+		Error:     lipgloss.NewStyle().Foreground(p.Error).Bold(true),
+		Metadata:  lipgloss.NewStyle().Foreground(p.Metadata),
+		Prompt:    lipgloss.NewStyle().Foreground(p.Accent),
+		Selected:  lipgloss.NewStyle().Foreground(p.Accent).Bold(true),
+
+		Panel: lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(p.Border),
+		Input:        lipgloss.NewStyle().Foreground(p.Text),
+		InputFocused: lipgloss.NewStyle().Foreground(p.Accent),
+	}
+
+	s.Flow = s.Success
+	s.Gold = s.Accent
+	s.Stuck = s.Warning
+	s.Leak = s.Error
+	s.Box = s.Panel.Padding(2, 3)
+	return s
+}
+
+// plainStyles keep the layout (borders and padding) and drop every color
+// and text attribute, so rendered text carries no ANSI sequences
+func plainStyles() Styles {
+	plain := lipgloss.NewStyle()
+	s := Styles{
+		Header: plain, Subheader: plain, Bold: plain, Body: plain, Dim: plain,
+		Accent: plain, Success: plain, Warning: plain, Error: plain,
+		Metadata: plain, Prompt: plain, Selected: plain,
+		Flow: plain, Gold: plain, Stuck: plain, Leak: plain,
+		Panel:        plain.Border(lipgloss.RoundedBorder()),
+		Input:        plain,
+		InputFocused: plain,
+	}
+	s.Box = s.Panel.Padding(2, 3)
+	return s
+}
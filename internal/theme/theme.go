@@ -0,0 +1,270 @@
+// Package theme is the single source of daylog's colors. A Theme is a
+// Palette of semantic colors plus the Styles built from it; renderers use
+// the styles by role (Header, Dim, Success...) and never name a color.
+//
+// Packages that keep styles in variables Register to be told when the
+// theme changes. Call Use once at startup, before any program renders.
+package theme
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Built-in theme names
+const (
+	Auto         = "auto" // Dark or Light, by the terminal's background
+	NameDark     = "dark"
+	NameLight    = "light"
+	HighContrast = "high-contrast"
+)
+
+// Palette is a theme's colors by role
+type Palette struct {
+	Primary  lipgloss.TerminalColor // Headers
+	Accent   lipgloss.TerminalColor // Interactive elements and selections
+	Success  lipgloss.TerminalColor // Wins and positive momentum
+	Warning  lipgloss.TerminalColor // Alerts and drift
+	Error    lipgloss.TerminalColor // Errors and leaks
+	Metadata lipgloss.TerminalColor // Labels and section titles
+	Dim      lipgloss.TerminalColor // Helper text
+	Border   lipgloss.TerminalColor // Box borders
+	Text     lipgloss.TerminalColor // Body text
+}
+
+// Theme is a named palette and the styles built from it
+type Theme struct {
+	Name    string
+	Palette Palette
+	Styles  Styles
+	NoColor bool // Colors stripped for NO_COLOR
+}
+
+// New builds a theme's styles from its palette
+func New(name string, p Palette) *Theme {
+	return &Theme{Name: name, Palette: p, Styles: newStyles(p, false)}
+}
+
+// Dark is the default palette (Dracula)
+func Dark() *Theme {
+	return New(NameDark, Palette{
+		Primary:  lipgloss.Color("#FF79C6"), // Magenta
+		Accent:   lipgloss.Color("#8BE9FD"), // Cyan
+		Success:  lipgloss.Color("#50FA7B"), // Green
+		Warning:  lipgloss.Color("#FFB86C"), // Orange
+		Error:    lipgloss.Color("#FF5555"), // Red
+		Metadata: lipgloss.Color("#BD93F9"), // Purple
+		Dim:      lipgloss.Color("#6272A4"), // Gray
+		Border:   lipgloss.Color("#44475A"),
+		Text:     lipgloss.Color("#D0D0D0"), // Softer off-white (less harsh)
+	})
+}
+
+// Light is Dark's counterpart for light backgrounds (Alucard)
+func Light() *Theme {
+	return New(NameLight, Palette{
+		Primary:  lipgloss.Color("#A3144D"),
+		Accent:   lipgloss.Color("#036A96"),
+		Success:  lipgloss.Color("#14710A"),
+		Warning:  lipgloss.Color("#A34D14"),
+		Error:    lipgloss.Color("#CB3A2A"),
+		Metadata: lipgloss.Color("#644AC9"),
+		Dim:      lipgloss.Color("#6C664B"),
+		Border:   lipgloss.Color("#CFCFDE"),
+		Text:     lipgloss.Color("#1F1F1F"),
+	})
+}
+
+// HighContrastTheme uses the terminal's own bright ANSI colors and default
+// foreground, so it follows whatever scheme the terminal is set to
+func HighContrastTheme() *Theme {
+	return New(HighContrast, Palette{
+		Primary:  lipgloss.Color("13"),
+		Accent:   lipgloss.Color("14"),
+		Success:  lipgloss.Color("10"),
+		Warning:  lipgloss.Color("11"),
+		Error:    lipgloss.Color("9"),
+		Metadata: lipgloss.Color("12"),
+		Dim:      lipgloss.NoColor{},
+		Border:   lipgloss.NoColor{},
+		Text:     lipgloss.NoColor{},
+	})
+}
+
+// builtins returns the built-in themes by name
+func builtins() map[string]func() *Theme {
+	return map[string]func() *Theme{
+		NameDark:     Dark,
+		NameLight:    Light,
+		HighContrast: HighContrastTheme,
+	}
+}
+
+// IsBuiltin reports whether name is auto or a built-in theme
+func IsBuiltin(name string) bool {
+	_, ok := builtins()[name]
+	return ok || name == Auto
+}
+
+// ValidName reports whether name can name a theme: lowercase letters,
+// digits, - and _
+func ValidName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+// Resolve returns the named theme: auto, a built-in, or one of user
+func Resolve(name string, user map[string]Palette) (*Theme, error) {
+	if name == "" || name == Auto {
+		if lipgloss.HasDarkBackground() {
+			return Dark(), nil
+		}
+		return Light(), nil
+	}
+	if builtin, ok := builtins()[name]; ok {
+		return builtin(), nil
+	}
+	if p, ok := user[name]; ok {
+		return New(name, p), nil
+	}
+
+	names := []string{Auto, NameDark, NameLight, HighContrast}
+	for userName := range user {
+		names = append(names, userName)
+	}
+	sort.Strings(names[4:])
+	return nil, fmt.Errorf("unknown theme %q (use %s)", name, strings.Join(names, ", "))
+}
+
+// ParsePalette reads a user theme's colors. "base" names the built-in theme
+// to start from (dark by default); the other keys are palette roles
+// (primary, accent, success, warning, error, metadata, dim, border, text)
+// set to "#RRGGBB", "#RGB", an ANSI color number or "default" for the
+// terminal's own color.
+func ParsePalette(values map[string]string) (Palette, error) {
+	base := NameDark
+	if value, ok := values["base"]; ok {
+		base = strings.ToLower(strings.TrimSpace(value))
+	}
+	builtin, ok := builtins()[base]
+	if !ok {
+		return Palette{}, fmt.Errorf("invalid base theme %q (use dark, light or high-contrast)", values["base"])
+	}
+	p := builtin().Palette
+
+	roles := map[string]*lipgloss.TerminalColor{
+		"primary":  &p.Primary,
+		"accent":   &p.Accent,
+		"success":  &p.Success,
+		"warning":  &p.Warning,
+		"error":    &p.Error,
+		"metadata": &p.Metadata,
+		"dim":      &p.Dim,
+		"border":   &p.Border,
+		"text":     &p.Text,
+	}
+	for key, value := range values {
+		if key == "base" {
+			continue
+		}
+		role, ok := roles[key]
+		if !ok {
+			return Palette{}, fmt.Errorf("unknown color %q", key)
+		}
+		color, err := ParseColor(value)
+		if err != nil {
+			return Palette{}, fmt.Errorf("%s: %w", key, err)
+		}
+		*role = color
+	}
+
+	return p, nil
+}
+
+// ParseColor reads "#RRGGBB", "#RGB", an ANSI color number (0-255) or
+// "default" for the terminal's own color
+func ParseColor(value string) (lipgloss.TerminalColor, error) {
+	value = strings.TrimSpace(value)
+	if strings.EqualFold(value, "default") {
+		return lipgloss.NoColor{}, nil
+	}
+	if hex, ok := strings.CutPrefix(value, "#"); ok {
+		if _, err := strconv.ParseUint(hex, 16, 32); err == nil && (len(hex) == 3 || len(hex) == 6) {
+			return lipgloss.Color(value), nil
+		}
+	} else if n, err := strconv.Atoi(value); err == nil && n >= 0 && n <= 255 {
+		return lipgloss.Color(value), nil
+	}
+	return nil, fmt.Errorf("invalid color %q (use #RRGGBB, an ANSI number 0-255 or default)", value)
+}
+
+// NoColor reports whether environ sets NO_COLOR (see no-color.org)
+func NoColor(environ []string) bool {
+	for _, kv := range environ {
+		if name, value, _ := strings.Cut(kv, "="); name == "NO_COLOR" && value != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// WithoutColor returns the theme with every color removed. Bold, faint and
+// reverse text still set things apart.
+func (t *Theme) WithoutColor() *Theme {
+	none := lipgloss.NoColor{}
+	p := Palette{none, none, none, none, none, none, none, none, none}
+	return &Theme{Name: t.Name, Palette: p, Styles: newStyles(p, true), NoColor: true}
+}
+
+// Plain is the theme for --plain output: the same layout with no colors or
+// text attributes at all
+func Plain() *Theme {
+	none := lipgloss.NoColor{}
+	p := Palette{none, none, none, none, none, none, none, none, none}
+	return &Theme{Name: "plain", Palette: p, Styles: plainStyles(), NoColor: true}
+}
+
+var (
+	current   = startupTheme()
+	listeners []func(*Theme)
+)
+
+// startupTheme is Dark (without color under NO_COLOR) until Use is called
+func startupTheme() *Theme {
+	if NoColor(os.Environ()) {
+		return Dark().WithoutColor()
+	}
+	return Dark()
+}
+
+// Current returns the theme in use
+func Current() *Theme {
+	return current
+}
+
+// Use switches every registered package to t
+func Use(t *Theme) {
+	current = t
+	for _, apply := range listeners {
+		apply(t)
+	}
+}
+
+// Register calls apply with the current theme now and again on every Use.
+// Packages holding styles in variables register from init.
+func Register(apply func(*Theme)) {
+	listeners = append(listeners, apply)
+	apply(current)
+}
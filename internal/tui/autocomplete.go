@@ -1,22 +1,24 @@
 package tui
 
 import (
+	"sort"
 	"strings"
 	"unicode/utf8"
 
-	"github.com/charmbracelet/lipgloss"
+	"github.com/aaryareddy/log_cli/internal/parser"
 )
 
 // AutocompleteState tracks the state of autocomplete suggestions
 type AutocompleteState struct {
-	Active         bool
-	TriggerChar    string   // "@" or "["
-	TriggerPos     int      // Position in text where trigger was typed (in runes)
-	TriggerPosBytes int     // Position in bytes for string slicing
-	FilterText     string   // Text after trigger for filtering
-	Suggestions    []string // Filtered list of suggestions
-	SelectedIndex  int      // Currently selected suggestion
-	AllSuggestions map[string][]string
+	Active          bool
+	TriggerChar     string   // "@", "[" or "" for a whole-entry completion
+	TriggerPos      int      // Position in text where trigger was typed (in runes)
+	TriggerPosBytes int      // Position in bytes for string slicing
+	FilterText      string   // Text after trigger for filtering
+	Suggestions     []string // Filtered list of suggestions
+	SelectedIndex   int      // Currently selected suggestion
+	AllSuggestions  map[string][]string
+	History         *CompletionHistory // Ranks tags and completes past entries; nil for none
 }
 
 // NewAutocompleteState creates a new autocomplete state
@@ -25,12 +27,24 @@ func NewAutocompleteState() AutocompleteState {
 		Active:        false,
 		SelectedIndex: 0,
 		AllSuggestions: map[string][]string{
-			"@": {"@deep", "@social", "@admin", "@break", "@zone", "@signoff"},
+			"@": parser.ContextTags(),
 			"[": {"[LEAK]", "[FLOW]", "[STUCK]", "[GOLD]", "[DRIFT]", "[ANCHOR]"},
 		},
 	}
 }
 
+// WithHistory ranks tag suggestions by past use and completes past activities
+func (a AutocompleteState) WithHistory(history *CompletionHistory) AutocompleteState {
+	a.History = history
+	return a
+}
+
+// IsEntryCompletion reports whether the suggestions complete the whole entry
+// rather than a tag
+func (a *AutocompleteState) IsEntryCompletion() bool {
+	return a.Active && a.TriggerChar == ""
+}
+
 // runeIndexToByte converts a rune index to a byte index in a string
 func runeIndexToByte(s string, runeIndex int) int {
 	if runeIndex <= 0 {
@@ -80,9 +94,9 @@ func (a *AutocompleteState) Update(text string, cursorPosRunes int) {
 		}
 	}
 
-	// If no trigger found, deactivate
+	// If no trigger found, offer past entries instead
 	if triggerPosBytes == -1 {
-		a.Active = false
+		a.updateEntryCompletions(text, cursorPosBytes)
 		return
 	}
 
@@ -117,30 +131,58 @@ func (a *AutocompleteState) Update(text string, cursorPosRunes int) {
 	}
 }
 
-// filterSuggestions returns suggestions matching the current filter text
+// updateEntryCompletions suggests past activities completing the text,
+// while the cursor is at its end
+func (a *AutocompleteState) updateEntryCompletions(text string, cursorPosBytes int) {
+	a.Active = false
+	if cursorPosBytes != len(text) {
+		return
+	}
+
+	a.Suggestions = a.History.complete(text)
+	if len(a.Suggestions) == 0 {
+		return
+	}
+
+	a.Active = true
+	a.TriggerChar = ""
+	a.TriggerPosBytes = 0
+	a.TriggerPos = 0
+	a.FilterText = text
+	if a.SelectedIndex >= len(a.Suggestions) {
+		a.SelectedIndex = 0
+	}
+}
+
+// filterSuggestions returns the suggestions fuzzily matching the filter text,
+// best match first; ties (and everything, with no filter) go to the tags used
+// most around this time of day and week
 func (a *AutocompleteState) filterSuggestions() []string {
-	allSuggestions := a.AllSuggestions[a.TriggerChar]
-	if a.FilterText == "" {
-		return allSuggestions
+	type ranked struct {
+		suggestion string
+		match      int
+		score      float64
 	}
 
-	var filtered []string
-	lowerFilter := strings.ToLower(a.FilterText)
-
-	for _, suggestion := range allSuggestions {
-		// For context tags, check if it starts with @filterText
-		if a.TriggerChar == "@" {
-			if strings.HasPrefix(strings.ToLower(suggestion), "@"+lowerFilter) {
-				filtered = append(filtered, suggestion)
-			}
-		} else if a.TriggerChar == "[" {
-			// For flag tags, check if it starts with [FILTERTEXT
-			if strings.HasPrefix(strings.ToLower(suggestion), "["+lowerFilter) {
-				filtered = append(filtered, suggestion)
-			}
+	var candidates []ranked
+	for _, suggestion := range a.AllSuggestions[a.TriggerChar] {
+		match, ok := fuzzyScore(a.FilterText, strings.TrimPrefix(suggestion, a.TriggerChar))
+		if ok {
+			candidates = append(candidates, ranked{suggestion, match, a.History.tagScore(suggestion)})
 		}
 	}
 
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].match != candidates[j].match {
+			return candidates[i].match > candidates[j].match
+		}
+		return candidates[i].score > candidates[j].score
+	})
+
+	filtered := make([]string, len(candidates))
+	for i, candidate := range candidates {
+		filtered[i] = candidate.suggestion
+	}
 	return filtered
 }
 
@@ -232,20 +274,12 @@ func (a *AutocompleteState) View() string {
 
 	var b strings.Builder
 
-	// Minimalistic styles using project colors
-	itemStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#6272A4")) // Dim gray
-
-	selectedItemStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#8BE9FD")). // Cyan accent
-		Bold(true)
-
 	// Build dropdown content - clean, no border
 	for i, suggestion := range a.Suggestions {
 		if i == a.SelectedIndex {
-			b.WriteString(selectedItemStyle.Render("› " + suggestion))
+			b.WriteString(SelectedStyle.Render("› " + suggestion))
 		} else {
-			b.WriteString(itemStyle.Render("  " + suggestion))
+			b.WriteString(DimStyle.Render("  " + suggestion))
 		}
 		if i < len(a.Suggestions)-1 {
 			b.WriteString("\n")
@@ -0,0 +1,224 @@
+package tui
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/aaryareddy/log_cli/internal/database"
+)
+
+const (
+	historyDays         = 60 // Days of entries the autocomplete learns from
+	historyHalfLife     = 14 // Days after which a past use counts half as much
+	minEntryQuery       = 2  // Runes typed before past entries are suggested
+	maxEntrySuggestions = 5
+)
+
+// CompletionHistory is what autocomplete has learned from past entries: how
+// often each tag is used around the current hour and weekday, and the
+// activities logged before with the momentum and tags that usually go with
+// them. Private entries, wins and thoughts are left out.
+type CompletionHistory struct {
+	tagScores  map[string]float64 // Tag value → weighted uses
+	activities []*pastActivity
+}
+
+// pastActivity is one entry text as logged, possibly many times
+type pastActivity struct {
+	text     string // As most recently written
+	score    float64
+	uses     int
+	momentum map[string]int
+	tags     map[string]int
+	tagOrder []string // Tags in first-seen order
+}
+
+// LoadCompletionHistory reads the last historyDays days of entries
+func LoadCompletionHistory(store *database.Store, now time.Time) (*CompletionHistory, error) {
+	entries, err := store.GetEntriesForDateRange(now.AddDate(0, 0, -(historyDays-1)).Format("2006-01-02"), now.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	return NewCompletionHistory(entries, now), nil
+}
+
+// NewCompletionHistory learns from entries (oldest first), weighting each use
+// by how recent it is and how close it was to now's hour and weekday
+func NewCompletionHistory(entries []*database.Entry, now time.Time) *CompletionHistory {
+	h := &CompletionHistory{tagScores: make(map[string]float64)}
+	byText := make(map[string]*pastActivity)
+
+	for _, entry := range entries {
+		if entry.Private || strings.HasPrefix(entry.EntryText, "🌟") || strings.HasPrefix(entry.EntryText, "💭") {
+			continue
+		}
+		weight := historyWeight(entry.Timestamp, now)
+		for _, tag := range entry.Tags {
+			h.tagScores[tag.TagValue] += weight
+		}
+
+		text := strings.Join(strings.Fields(entry.EntryText), " ")
+		if text == "" {
+			continue
+		}
+		key := strings.ToLower(text)
+		activity, ok := byText[key]
+		if !ok {
+			activity = &pastActivity{momentum: make(map[string]int), tags: make(map[string]int)}
+			byText[key] = activity
+			h.activities = append(h.activities, activity)
+		}
+		activity.text = text
+		activity.score += weight
+		activity.uses++
+		if entry.Momentum != nil {
+			activity.momentum[*entry.Momentum]++
+		}
+		for _, tag := range entry.Tags {
+			// Signing off runs the sign-off ritual, so it is never suggested
+			if tag.TagValue == string(database.TagSignoff) {
+				continue
+			}
+			if activity.tags[tag.TagValue] == 0 {
+				activity.tagOrder = append(activity.tagOrder, tag.TagValue)
+			}
+			activity.tags[tag.TagValue]++
+		}
+	}
+
+	return h
+}
+
+// historyWeight scores one past use: recent uses count more, and uses within
+// an hour of now or on the same weekday count extra
+func historyWeight(at, now time.Time) float64 {
+	age := now.Sub(at).Hours() / 24
+	weight := math.Pow(0.5, max(age, 0)/historyHalfLife)
+
+	hours := at.Hour() - now.Hour()
+	if hours < 0 {
+		hours = -hours
+	}
+	switch min(hours, 24-hours) {
+	case 0:
+		weight *= 3
+	case 1:
+		weight *= 2
+	}
+	if at.Weekday() == now.Weekday() {
+		weight *= 1.5
+	}
+	return weight
+}
+
+// tagScore returns the weighted uses of a tag value (zero without history)
+func (h *CompletionHistory) tagScore(tag string) float64 {
+	if h == nil {
+		return 0
+	}
+	return h.tagScores[tag]
+}
+
+// complete returns whole-entry completions for the typed text: past
+// activities matching it fuzzily, best match first, each with its usual
+// tags and momentum
+func (h *CompletionHistory) complete(typed string) []string {
+	query := strings.Join(strings.Fields(typed), " ")
+	if h == nil || utf8.RuneCountInString(query) < minEntryQuery {
+		return nil
+	}
+
+	type ranked struct {
+		completion string
+		match      int
+		score      float64
+	}
+	var candidates []ranked
+	for _, activity := range h.activities {
+		match, ok := fuzzyScore(query, activity.text)
+		// Letters scattered across a long entry aren't a match
+		if !ok || match < 2*utf8.RuneCountInString(query) {
+			continue
+		}
+		completion := activity.completion()
+		if strings.EqualFold(completion, query) {
+			continue
+		}
+		candidates = append(candidates, ranked{completion, match, activity.score})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].match != candidates[j].match {
+			return candidates[i].match > candidates[j].match
+		}
+		return candidates[i].score > candidates[j].score
+	})
+
+	var completions []string
+	for _, candidate := range candidates[:min(len(candidates), maxEntrySuggestions)] {
+		completions = append(completions, candidate.completion)
+	}
+	return completions
+}
+
+// completion returns the activity's text with the tags and momentum used
+// on more than half of its entries, e.g. "Standup with team @social →"
+func (a *pastActivity) completion() string {
+	parts := []string{a.text}
+	for _, tag := range a.tagOrder {
+		if 2*a.tags[tag] > a.uses {
+			parts = append(parts, tag)
+		}
+	}
+
+	usual, count := "", 0
+	for momentum, n := range a.momentum {
+		if n > count || n == count && momentum < usual {
+			usual, count = momentum, n
+		}
+	}
+	if 2*count > a.uses {
+		parts = append(parts, formatMomentum(usual))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// fuzzyScore scores how well query matches candidate as a case-insensitive
+// subsequence. Consecutive letters, word starts and a matching prefix score
+// higher; ok is false when query isn't a subsequence of candidate.
+func fuzzyScore(query, candidate string) (score int, ok bool) {
+	q := []rune(strings.ToLower(query))
+	c := []rune(strings.ToLower(candidate))
+	if len(q) == 0 {
+		return 0, true
+	}
+
+	qi, previous := 0, -2
+	for ci := 0; ci < len(c) && qi < len(q); ci++ {
+		if c[ci] != q[qi] {
+			continue
+		}
+		score++
+		if ci == previous+1 {
+			score += 2
+		}
+		if ci == 0 || !unicode.IsLetter(c[ci-1]) && !unicode.IsDigit(c[ci-1]) {
+			score += 3
+		}
+		previous = ci
+		qi++
+	}
+	if qi < len(q) {
+		return 0, false
+	}
+
+	if strings.HasPrefix(string(c), string(q)) {
+		score += 5
+	}
+	return score, true
+}
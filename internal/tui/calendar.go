@@ -0,0 +1,439 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aaryareddy/log_cli/internal/analytics"
+	"github.com/aaryareddy/log_cli/internal/config"
+	"github.com/aaryareddy/log_cli/internal/database"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// calendarCellWidth is the width of one day in the month grid
+const calendarCellWidth = 9
+
+// calendarPane is what the calendar is showing
+type calendarPane int
+
+const (
+	paneGrid calendarPane = iota // The month grid
+	paneDay                      // The selected day's entries
+	paneWeek                     // The selected day's week review
+)
+
+// calendarDay summarizes one day in the month grid
+type calendarDay struct {
+	entries   int
+	momentum  int // Ups minus downs
+	completed bool
+}
+
+// CalendarModel is a month grid for browsing history. Each logged day shows
+// its entry count, net momentum and sign-off; Enter opens the day and w its
+// week review.
+type CalendarModel struct {
+	store           *database.Store
+	rules           analytics.DriftRules
+	synonyms        analytics.Synonyms
+	energyDays      int
+	statsMode       analytics.StatsMode
+	weekStart       time.Weekday
+	maxCollapsedLen int
+
+	month    time.Time              // First day of the displayed month
+	selected time.Time              // Selected day (midnight)
+	days     map[string]calendarDay // Logged days in the month, by YYYY-MM-DD
+	err      error
+	notice   string // Shown under the grid until the next key
+	pane     calendarPane
+	day      ViewModel
+	week     WeekModel
+	width    int
+	height   int
+}
+
+// NewCalendarModel creates a calendar showing the month of date, with date selected
+func NewCalendarModel(store *database.Store, date time.Time) CalendarModel {
+	m := CalendarModel{
+		store:           store,
+		rules:           analytics.DefaultDriftRules(),
+		synonyms:        analytics.DefaultSynonyms,
+		energyDays:      analytics.DefaultEnergyWindowDays,
+		weekStart:       analytics.DefaultWeekStart,
+		maxCollapsedLen: 100,
+		selected:        time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.Local),
+	}
+	return m.loadMonth()
+}
+
+// WithConfig applies resolved settings: drift rules, synonyms for review
+// themes, the energy window, the review's tag breakdown, the first day of the
+// week and when long entries collapse
+func (m CalendarModel) WithConfig(c *config.Config) CalendarModel {
+	m.rules = c.DriftRules()
+	m.energyDays = c.EnergyWindowDays()
+	m.statsMode = c.StatsMode()
+	m.weekStart = c.WeekStart()
+	m.maxCollapsedLen = c.MaxCollapsedLen()
+	if synonyms, err := c.Synonyms(); err != nil {
+		m.notice = err.Error()
+	} else {
+		m.synonyms = synonyms
+	}
+	return m
+}
+
+// loadMonth reads the selected day's month from the store
+func (m CalendarModel) loadMonth() CalendarModel {
+	period := analytics.MonthPeriod(m.selected)
+	m.month = period.Start
+	m.days = make(map[string]calendarDay)
+
+	days, err := m.store.GetDaysInRange(period.StartDate(), period.EndDate())
+	if err != nil {
+		m.err = err
+		return m
+	}
+	entries, err := m.store.GetEntriesForDateRange(period.StartDate(), period.EndDate())
+	if err != nil {
+		m.err = err
+		return m
+	}
+	m.err = nil
+
+	dates := make(map[int]string, len(days))
+	for _, day := range days {
+		date := day.Date.Format("2006-01-02")
+		dates[day.ID] = date
+		m.days[date] = calendarDay{completed: day.Completed}
+	}
+	for _, entry := range entries {
+		date, ok := dates[entry.DayID]
+		if !ok {
+			continue
+		}
+		summary := m.days[date]
+		summary.entries++
+		if entry.Momentum != nil {
+			switch database.Momentum(*entry.Momentum) {
+			case database.MomentumUp:
+				summary.momentum++
+			case database.MomentumDown:
+				summary.momentum--
+			}
+		}
+		m.days[date] = summary
+	}
+
+	return m
+}
+
+// Init initializes the model
+func (m CalendarModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m CalendarModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		switch m.pane {
+		case paneDay:
+			m.day = sized(m.day, m.paneSize())
+		case paneWeek:
+			m.week = sized(m.week, m.paneSize())
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		m.notice = ""
+
+		if m.pane != paneGrid {
+			switch msg.String() {
+			case "ctrl+c":
+				return m, tea.Quit
+			case "esc", "backspace":
+				m.pane = paneGrid
+				return m, nil
+			case "w":
+				if m.pane == paneDay {
+					return m.openWeek(), nil
+				}
+			}
+			return m.updatePane(msg)
+		}
+
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		case "left", "h":
+			return m.moveTo(m.selected.AddDate(0, 0, -1)), nil
+		case "right", "l":
+			return m.moveTo(m.selected.AddDate(0, 0, 1)), nil
+		case "up", "k":
+			return m.moveTo(m.selected.AddDate(0, 0, -7)), nil
+		case "down", "j":
+			return m.moveTo(m.selected.AddDate(0, 0, 7)), nil
+		case "pgup", "[":
+			return m.moveTo(addMonths(m.selected, -1)), nil
+		case "pgdown", "]":
+			return m.moveTo(addMonths(m.selected, 1)), nil
+		case "t":
+			return m.moveTo(time.Now()), nil
+		case "enter":
+			return m.openDay(), nil
+		case "w":
+			return m.openWeek(), nil
+		}
+	}
+
+	if m.pane != paneGrid {
+		return m.updatePane(msg)
+	}
+	return m, nil
+}
+
+// updatePane passes a message to the open day or week review
+func (m CalendarModel) updatePane(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var updated tea.Model
+	var cmd tea.Cmd
+	switch m.pane {
+	case paneDay:
+		updated, cmd = m.day.Update(msg)
+		m.day = updated.(ViewModel)
+	case paneWeek:
+		updated, cmd = m.week.Update(msg)
+		m.week = updated.(WeekModel)
+	}
+	return m, cmd
+}
+
+// moveTo selects a day, loading its month if it isn't the one displayed
+func (m CalendarModel) moveTo(date time.Time) CalendarModel {
+	m.selected = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.Local)
+	if m.selected.Year() != m.month.Year() || m.selected.Month() != m.month.Month() {
+		m = m.loadMonth()
+	}
+	return m
+}
+
+// addMonths moves date by n months, staying within the target month
+// (Jan 31 + 1 month is Feb 28, not Mar 3)
+func addMonths(date time.Time, n int) time.Time {
+	first := time.Date(date.Year(), date.Month()+time.Month(n), 1, 0, 0, 0, 0, time.Local)
+	lastDay := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(date.Day(), lastDay)-1)
+}
+
+// paneSize is the size given to the day and week panes. Their viewports
+// leave room below for the calendar's help line.
+func (m CalendarModel) paneSize() tea.WindowSizeMsg {
+	return tea.WindowSizeMsg{Width: m.width, Height: m.height}
+}
+
+// openDay shows the selected day's entries
+func (m CalendarModel) openDay() CalendarModel {
+	date := m.selected.Format("2006-01-02")
+
+	day, err := m.store.GetDayByDate(date)
+	if err != nil {
+		m.err = err
+		return m
+	}
+	if day == nil {
+		m.notice = "No log for " + m.selected.Format("Monday, January 2")
+		return m
+	}
+	entries, err := m.store.GetTodayEntries(day.ID)
+	if err != nil {
+		m.err = err
+		return m
+	}
+	away, err := m.store.GetAwayIntervalsForRange(date, date)
+	if err != nil {
+		m.err = err
+		return m
+	}
+
+	m.day = sized(NewViewModel(day, entries).
+		WithAwayIntervals(away, m.rules).
+		WithMaxCollapsedLen(m.maxCollapsedLen), m.paneSize())
+	m.pane = paneDay
+	return m
+}
+
+// openWeek shows the review of the selected day's week
+func (m CalendarModel) openWeek() CalendarModel {
+	summary, err := loadPeriodSummary(m.store, analytics.WeekPeriod(m.selected, m.weekStart), m.rules, m.synonyms, m.energyDays)
+	if err != nil {
+		m.err = err
+		return m
+	}
+
+	m.week = sized(NewWeekModel(summary).WithStatsMode(m.statsMode), m.paneSize())
+	m.pane = paneWeek
+	return m
+}
+
+// View renders the UI
+func (m CalendarModel) View() string {
+	switch m.pane {
+	case paneDay:
+		if !m.day.ready {
+			return "Loading..."
+		}
+		help := "↑/↓ or j/k to scroll • Shift+R to toggle text • w week review • Esc back to calendar • q to quit"
+		if hasPrivate(m.day.entries) {
+			help = "↑/↓ or j/k to scroll • Shift+R to toggle text • p to reveal private • w week review • Esc back to calendar • q to quit"
+		}
+		return m.day.viewport.View() + "\n" + DimStyle.Render(help)
+	case paneWeek:
+		if !m.week.ready {
+			return "Loading..."
+		}
+		return m.week.viewport.View() + "\n" +
+			DimStyle.Render("↑/↓ or j/k to scroll • t time/count • e to expand themes • Esc back to calendar • q to quit")
+	}
+
+	var b strings.Builder
+
+	b.WriteString(RenderHeaderBar("CALENDAR", m.month.Format("January 2006")))
+	b.WriteString("\n\n")
+
+	if m.err != nil {
+		b.WriteString(ErrorStyle.Render(m.err.Error()))
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(m.renderGrid())
+	b.WriteString("\n")
+	b.WriteString(m.renderSelected())
+	b.WriteString("\n\n")
+
+	if m.notice != "" {
+		b.WriteString(WarningStyle.Render(m.notice))
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(DimStyle.Render("←/→ day • ↑/↓ week • [/] month • t today • Enter open day • w week review • q/esc to exit"))
+	return b.String()
+}
+
+// renderGrid renders the month as weeks of two-line cells: the date with ✓
+// when signed off, then the entry count and net momentum
+func (m CalendarModel) renderGrid() string {
+	var b strings.Builder
+
+	// Weekday names, starting on the configured first day
+	for i := 0; i < 7; i++ {
+		name := time.Weekday((int(m.weekStart) + i) % 7).String()[:3]
+		b.WriteString(SubheaderStyle.Render(fmt.Sprintf(" %-*s", calendarCellWidth-1, name)))
+	}
+	b.WriteString("\n")
+
+	today := time.Now().Format("2006-01-02")
+	selectedStyle := lipgloss.NewStyle().Reverse(true)
+
+	offset := (int(m.month.Weekday()) - int(m.weekStart) + 7) % 7
+	start := m.month.AddDate(0, 0, -offset)
+	for week := start; week.Before(m.month.AddDate(0, 1, 0)); week = week.AddDate(0, 0, 7) {
+		var dates, counts strings.Builder
+		for i := 0; i < 7; i++ {
+			date := week.AddDate(0, 0, i)
+			if date.Month() != m.month.Month() {
+				dates.WriteString(strings.Repeat(" ", calendarCellWidth))
+				counts.WriteString(strings.Repeat(" ", calendarCellWidth))
+				continue
+			}
+
+			key := date.Format("2006-01-02")
+			summary, logged := m.days[key]
+
+			label := fmt.Sprintf(" %2d", date.Day())
+			if summary.completed {
+				label += " ✓"
+			}
+			label = fmt.Sprintf("%-*s", calendarCellWidth-1, label)
+
+			detail := fmt.Sprintf(" %-*s", calendarCellWidth-1, "  ·")
+			style := DimStyle
+			if logged && summary.entries > 0 {
+				detail = fmt.Sprintf(" %-*s", calendarCellWidth-1, fmt.Sprintf("%3d %s", summary.entries, netMomentumArrow(summary.momentum)))
+				style = momentumStyle(summary.momentum)
+			}
+
+			switch {
+			case date.Equal(m.selected):
+				label = selectedStyle.Render(label)
+			case key == today:
+				label = AccentStyle.Render(label)
+			default:
+				label = style.Render(label)
+			}
+			dates.WriteString(label + " ")
+			counts.WriteString(style.Render(detail))
+		}
+		b.WriteString(dates.String())
+		b.WriteString("\n")
+		b.WriteString(counts.String())
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// renderSelected describes the selected day and the month under the grid
+func (m CalendarModel) renderSelected() string {
+	var b strings.Builder
+
+	b.WriteString(BoldStyle.Render(m.selected.Format("Monday, January 2")))
+	summary, ok := m.days[m.selected.Format("2006-01-02")]
+	switch {
+	case !ok || summary.entries == 0:
+		b.WriteString(DimStyle.Render(" · nothing logged"))
+	default:
+		b.WriteString(DimStyle.Render(fmt.Sprintf(" · %d entries · net momentum %+d", summary.entries, summary.momentum)))
+		if summary.completed {
+			b.WriteString(DimStyle.Render(" · signed off"))
+		}
+	}
+
+	logged := 0
+	for _, day := range m.days {
+		if day.entries > 0 {
+			logged++
+		}
+	}
+	b.WriteString("\n")
+	b.WriteString(DimStyle.Render(fmt.Sprintf("%d of %d days logged in %s", logged, m.month.AddDate(0, 1, -1).Day(), m.month.Format("January"))))
+
+	return b.String()
+}
+
+// netMomentumArrow returns the arrow for a day's ups minus downs
+func netMomentumArrow(net int) string {
+	switch {
+	case net > 0:
+		return "↑"
+	case net < 0:
+		return "↓"
+	default:
+		return "→"
+	}
+}
+
+// momentumStyle colors a logged day by its net momentum
+func momentumStyle(net int) lipgloss.Style {
+	switch {
+	case net > 0:
+		return SuccessStyle
+	case net < 0:
+		return ErrorStyle
+	default:
+		return BodyStyle
+	}
+}
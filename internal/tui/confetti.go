@@ -16,6 +16,7 @@ type ConfettiModel struct {
 	frame         int
 	totalFrames   int
 	autoCloseTime time.Time
+	milestones    []string // Streak records to celebrate instead of day completion
 }
 
 // tickMsg is sent on each animation frame
@@ -32,6 +33,16 @@ func NewConfettiModel(day *database.Day, entries []*database.Entry) ConfettiMode
 	}
 }
 
+// NewMilestoneConfettiModel creates a confetti animation celebrating broken streak records
+func NewMilestoneConfettiModel(milestones []string) ConfettiModel {
+	return ConfettiModel{
+		frame:         0,
+		totalFrames:   20, // ~2 seconds at 10fps
+		autoCloseTime: time.Now().Add(2 * time.Second),
+		milestones:    milestones,
+	}
+}
+
 // Init starts the animation ticker
 func (m ConfettiModel) Init() tea.Cmd {
 	return tick()
@@ -75,6 +86,21 @@ func (m ConfettiModel) View() string {
 	b.WriteString(confetti)
 	b.WriteString("\n")
 
+	// Streak milestone celebration
+	if len(m.milestones) > 0 {
+		b.WriteString(HeaderStyle.Render("MILESTONE!"))
+		b.WriteString("\n\n")
+		for _, milestone := range m.milestones {
+			b.WriteString(SuccessStyle.Render("🔥 " + milestone))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+		b.WriteString(confetti)
+		b.WriteString("\n\n")
+		b.WriteString(DimStyle.Render("Keep it going! (closing in a moment...)"))
+		return BoxStyle.Render(b.String())
+	}
+
 	// Header
 	b.WriteString(HeaderStyle.Render("DAYLOG COMPLETE!"))
 	b.WriteString("\n\n")
@@ -6,7 +6,6 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
 )
 
 // ConfirmDeleteModel is the model for the delete confirmation screen
@@ -83,10 +82,8 @@ func (m ConfirmDeleteModel) View() string {
 	}
 
 	// Apply width constraint and word wrap to entry text
-	entryStyle := lipgloss.NewStyle().
+	entryStyle := PanelStyle.
 		Width(previewWidth).
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#44475A")).
 		Padding(1, 2)
 
 	entryPreview := entryStyle.Render(m.entryText)
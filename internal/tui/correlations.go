@@ -0,0 +1,95 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aaryareddy/log_cli/internal/analytics"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// CorrelationsModel is the model for the tag-momentum correlation and sequence analysis screen
+type CorrelationsModel struct {
+	report    *analytics.CorrelationReport
+	startDate string
+	endDate   string
+	viewport  viewport.Model
+	ready     bool
+}
+
+// NewCorrelationsModel creates a new correlations model
+func NewCorrelationsModel(report *analytics.CorrelationReport, startDate, endDate string) CorrelationsModel {
+	return CorrelationsModel{
+		report:    report,
+		startDate: startDate,
+		endDate:   endDate,
+	}
+}
+
+// Init initializes the model
+func (m CorrelationsModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m CorrelationsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		}
+	case tea.WindowSizeMsg:
+		if !m.ready {
+			// Initialize viewport on first window size message
+			m.viewport = viewport.New(msg.Width, msg.Height-2)
+			m.viewport.SetContent(m.generateContent())
+			m.ready = true
+		} else {
+			m.viewport.Width = msg.Width
+			m.viewport.Height = msg.Height - 2
+		}
+	}
+
+	// Update viewport (handles scrolling)
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// generateContent generates the correlations content
+func (m CorrelationsModel) generateContent() string {
+	var b strings.Builder
+
+	b.WriteString(RenderHeaderBar("CORRELATIONS", fmt.Sprintf("%s to %s", m.startDate, m.endDate)))
+	b.WriteString("\n\n")
+
+	// Strongest findings first
+	b.WriteString(report.CorrelationFindings(m.report, 0))
+	b.WriteString("\n")
+
+	// Full contingency table
+	b.WriteString(report.TagMomentumTable(m.report.TagMomentum))
+	b.WriteString("\n")
+
+	// What precedes each pattern flag
+	for _, flag := range []string{"[LEAK]", "[FLOW]", "[STUCK]"} {
+		b.WriteString(report.PrecedingTransitions(m.report, flag, 5))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// View renders the UI
+func (m CorrelationsModel) View() string {
+	if !m.ready {
+		return "Loading..."
+	}
+
+	viewContent := m.viewport.View() + "\n"
+	viewContent += DimStyle.Render("↑/↓ or j/k to scroll • q/esc to exit")
+	return viewContent
+}
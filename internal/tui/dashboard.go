@@ -0,0 +1,612 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aaryareddy/log_cli/internal/analytics"
+	"github.com/aaryareddy/log_cli/internal/config"
+	"github.com/aaryareddy/log_cli/internal/database"
+	"github.com/aaryareddy/log_cli/internal/quicklog"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Dashboard tabs, in display order
+const (
+	tabToday = iota
+	tabLog
+	tabWeek
+	tabWins
+	tabThoughts
+	tabSearch
+	tabHelp
+)
+
+// dashboardTabs are the tab labels, indexed by tab
+var dashboardTabs = []string{"Today", "Log", "Week", "Wins", "Thoughts", "Search", "Help"}
+
+// dashboardRefreshInterval is how often the dashboard reloads to pick up
+// entries logged elsewhere (log -m, the API, another terminal)
+const dashboardRefreshInterval = 30 * time.Second
+
+// dashboardFocus is which input, if any, receives typed keys
+type dashboardFocus int
+
+const (
+	focusNone   dashboardFocus = iota // Keys navigate
+	focusInput                        // Keys go to the entry bar
+	focusSearch                       // Keys go to the search query
+)
+
+// dashboardLoadedMsg carries freshly loaded tab data
+type dashboardLoadedMsg struct {
+	data *dashboardData
+	err  error
+}
+
+// dashboardRefreshMsg triggers a periodic reload
+type dashboardRefreshMsg time.Time
+
+// entryLoggedMsg reports the result of submitting the entry bar
+type entryLoggedMsg struct {
+	result *quicklog.Result
+	err    error
+}
+
+// DashboardModel is the long-running `log ui` model: tabs for today, recent
+// days, the week, wins, thoughts, search and help, with an entry bar that logs
+// without leaving. Entries go through quicklog.Logger, so hooks and webhooks
+// fire as they do for `log -m`; rituals an entry triggers run in place of the
+// tabs and are saved through the logger too.
+type DashboardModel struct {
+	store           *database.Store
+	logger          *quicklog.Logger
+	rules           analytics.DriftRules
+	synonyms        analytics.Synonyms
+	energyDays      int
+	statsMode       analytics.StatsMode
+	weekStart       time.Weekday
+	maxCollapsedLen int
+
+	tab        int
+	focus      dashboardFocus
+	input      textinput.Model
+	complete   AutocompleteState // Tag and past-entry suggestions for the entry bar
+	reveal     bool              // Show private entries' text on every tab
+	status     string            // Result of the last submit or load, until the next key
+	statusErr  bool
+	followLast bool // Scroll Today to the newest entry after the next load
+	width      int
+	height     int
+
+	ritual     ritualPrompt      // Ritual prompt in progress (nil when none)
+	ritualKind quicklog.Ritual   // Which ritual the prompt is
+	rituals    []quicklog.Ritual // Rituals still to run after it
+	ritualDay  *database.Day
+	ritualSize int // Entries logged today, for the win prompt
+
+	data     *dashboardData
+	today    ViewModel
+	feed     logFeedModel
+	week     WeekModel
+	wins     ListWinsModel
+	thoughts ListThoughtsModel
+	search   searchModel
+	help     HelpModel
+}
+
+// NewDashboardModel creates a dashboard reading from store and logging through logger
+func NewDashboardModel(store *database.Store, logger *quicklog.Logger) DashboardModel {
+	ti := textinput.New()
+	ti.Placeholder = "What are you doing right now?"
+	ti.Prompt = "› "
+	ti.PromptStyle = PromptStyle
+	ti.Focus()
+
+	return DashboardModel{
+		store:           store,
+		logger:          logger,
+		rules:           analytics.DefaultDriftRules(),
+		synonyms:        analytics.DefaultSynonyms,
+		energyDays:      analytics.DefaultEnergyWindowDays,
+		weekStart:       analytics.DefaultWeekStart,
+		maxCollapsedLen: 100,
+		focus:           focusInput,
+		input:           ti,
+		complete:        NewAutocompleteState(),
+		search:          newSearchModel(),
+		help:            NewHelpModel(),
+	}
+}
+
+// WithConfig applies resolved settings: drift rules, synonyms for review
+// themes, the energy window, the review's tag breakdown, the first day of the
+// week and when long entries collapse
+func (m DashboardModel) WithConfig(c *config.Config) DashboardModel {
+	m.rules = c.DriftRules()
+	m.energyDays = c.EnergyWindowDays()
+	m.statsMode = c.StatsMode()
+	m.weekStart = c.WeekStart()
+	m.maxCollapsedLen = c.MaxCollapsedLen()
+	if synonyms, err := c.Synonyms(); err != nil {
+		m.status, m.statusErr = err.Error(), true
+	} else {
+		m.synonyms = synonyms
+	}
+	return m
+}
+
+// Init loads the tabs and starts the refresh timer
+func (m DashboardModel) Init() tea.Cmd {
+	return tea.Batch(textinput.Blink, m.load(), dashboardTick())
+}
+
+// load reads the tab data in the background
+func (m DashboardModel) load() tea.Cmd {
+	store, rules, synonyms, energyDays, weekStart := m.store, m.rules, m.synonyms, m.energyDays, m.weekStart
+	return func() tea.Msg {
+		data, err := loadDashboard(store, rules, synonyms, energyDays, weekStart, time.Now())
+		return dashboardLoadedMsg{data: data, err: err}
+	}
+}
+
+// dashboardTick schedules the next periodic reload
+func dashboardTick() tea.Cmd {
+	return tea.Tick(dashboardRefreshInterval, func(t time.Time) tea.Msg {
+		return dashboardRefreshMsg(t)
+	})
+}
+
+// submit logs an entry in the background
+func (m DashboardModel) submit(text string) tea.Cmd {
+	logger := m.logger
+	return func() tea.Msg {
+		result, err := logger.Log(text)
+		return entryLoggedMsg{result: result, err: err}
+	}
+}
+
+// Update handles messages
+func (m DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.input.Width = msg.Width - 4
+		if m.data != nil {
+			m = m.resizeTabs()
+		}
+		return m, nil
+
+	case dashboardLoadedMsg:
+		if msg.err != nil {
+			m.status, m.statusErr = msg.err.Error(), true
+			return m, nil
+		}
+		return m.apply(msg.data), nil
+
+	case dashboardRefreshMsg:
+		return m, tea.Batch(m.load(), dashboardTick())
+
+	case entryLoggedMsg:
+		if msg.err != nil {
+			m.status, m.statusErr = msg.err.Error(), true
+			return m, nil
+		}
+		m.status, m.statusErr = msg.result.Summary(), false
+		m.followLast = true
+		if len(msg.result.Deferred) > 0 {
+			m.status = fmt.Sprintf("logged #%d at %s", msg.result.Count, msg.result.Entry.Timestamp.Format("3:04pm"))
+			m.rituals, m.ritualDay, m.ritualSize = msg.result.Deferred, msg.result.Day, msg.result.Count
+			m, cmd := m.nextRitual()
+			return m, tea.Batch(cmd, m.load())
+		}
+		return m, m.load()
+
+	case tea.KeyMsg:
+		if m.ritual != nil {
+			return m.updateRitual(msg)
+		}
+		return m.handleKey(msg)
+	}
+
+	// Cursor blinks and the like
+	var inputCmd, queryCmd, ritualCmd tea.Cmd
+	m.input, inputCmd = m.input.Update(msg)
+	m.search.query, queryCmd = m.search.query.Update(msg)
+	if m.ritual != nil {
+		var ritual tea.Model
+		ritual, ritualCmd = m.ritual.Update(msg)
+		m.ritual = ritual.(ritualPrompt)
+	}
+	return m, tea.Batch(inputCmd, queryCmd, ritualCmd)
+}
+
+// ritualPrompt is a ritual the dashboard runs in place of the tabs
+type ritualPrompt interface {
+	tea.Model
+	WasSubmitted() bool
+	Err() error
+}
+
+// nextRitual starts the next pending ritual prompt, saving through the logger
+func (m DashboardModel) nextRitual() (DashboardModel, tea.Cmd) {
+	if len(m.rituals) == 0 {
+		m.ritual = nil
+		return m, nil
+	}
+	m.ritualKind = m.rituals[0]
+	m.rituals = m.rituals[1:]
+
+	switch m.ritualKind {
+	case quicklog.RitualIntention:
+		m.ritual = NewIntentionModel().WithLogger(m.logger, m.ritualDay)
+	case quicklog.RitualWin:
+		m.ritual = NewWinModel().WithEntryCount(m.ritualSize).WithLogger(m.logger, m.ritualDay)
+	case quicklog.RitualSignoff:
+		intention := ""
+		if m.ritualDay.Intention != nil {
+			intention = *m.ritualDay.Intention
+		}
+		m.ritual = NewSignoffModel(intention).WithLogger(m.logger, m.ritualDay)
+	default:
+		return m.nextRitual()
+	}
+	return m, m.ritual.Init()
+}
+
+// updateRitual passes a key to the ritual prompt. The prompts quit when
+// submitted; here that moves on to the next ritual and reloads instead.
+func (m DashboardModel) updateRitual(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	ritual, cmd := m.ritual.Update(msg)
+	m.ritual = ritual.(ritualPrompt)
+	if !m.ritual.WasSubmitted() {
+		return m, cmd
+	}
+
+	// Saving completes the deferred ritual; skipping it does too, here
+	err := m.ritual.Err()
+	if err == nil {
+		err = quicklog.ClearRitual(m.store, m.ritualKind)
+	}
+	if err != nil {
+		m.status, m.statusErr = err.Error(), true
+	}
+	m, cmd = m.nextRitual()
+	return m, tea.Batch(cmd, m.load())
+}
+
+// handleKey routes a key to the focused input, the dashboard or the active tab
+func (m DashboardModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.status = ""
+
+	// Open suggestions take the keys that would otherwise switch tabs or scroll
+	if m.focus == focusInput && m.complete.Active && len(m.complete.Suggestions) > 0 {
+		switch msg.Type {
+		case tea.KeyUp:
+			m.complete.MoveUp()
+			return m, nil
+		case tea.KeyDown:
+			m.complete.MoveDown()
+			return m, nil
+		case tea.KeyTab:
+			return m.acceptSuggestion(), nil
+		case tea.KeyEnter:
+			// Past-entry completions need Tab; Enter logs what was typed
+			if !m.complete.IsEntryCompletion() {
+				return m.acceptSuggestion(), nil
+			}
+		case tea.KeyEsc:
+			m.complete.Deactivate()
+			return m, nil
+		}
+	}
+
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "tab":
+		return m.switchTab((m.tab + 1) % len(dashboardTabs)), nil
+	case "shift+tab":
+		return m.switchTab((m.tab + len(dashboardTabs) - 1) % len(dashboardTabs)), nil
+	case "up", "down", "pgup", "pgdown":
+		// Scrolling works while typing
+		return m.updateTab(msg)
+	}
+
+	switch m.focus {
+	case focusInput:
+		switch msg.Type {
+		case tea.KeyEnter:
+			text := strings.TrimSpace(m.input.Value())
+			if text == "" {
+				return m, nil
+			}
+			m.input.Reset()
+			m.complete.Deactivate()
+			return m, m.submit(text)
+		case tea.KeyEsc:
+			m.focus = focusNone
+			m.input.Blur()
+			return m, nil
+		}
+
+		var cmd tea.Cmd
+		previousValue := m.input.Value()
+		m.input, cmd = m.input.Update(msg)
+		if m.input.Value() != previousValue {
+			convertInputMarkers(&m.input)
+			m.complete.Update(m.input.Value(), m.input.Position())
+		}
+		return m, cmd
+
+	case focusSearch:
+		switch msg.Type {
+		case tea.KeyEnter, tea.KeyEsc:
+			m.focus = focusNone
+			m.search.query.Blur()
+			return m, nil
+		}
+
+		var cmd tea.Cmd
+		previousValue := m.search.query.Value()
+		m.search.query, cmd = m.search.query.Update(msg)
+		if m.search.query.Value() != previousValue {
+			m.search = m.search.refresh()
+		}
+		return m, cmd
+	}
+
+	switch msg.String() {
+	case "q", "esc":
+		return m, tea.Quit
+	case "i":
+		m.focus = focusInput
+		return m, m.input.Focus()
+	case "/":
+		m = m.switchTab(tabSearch)
+		m.focus = focusSearch
+		return m, m.search.query.Focus()
+	case "p":
+		// Reveal or blur private entries everywhere
+		m.reveal = !m.reveal
+		if m.data != nil {
+			m = m.apply(m.data)
+		}
+		return m, nil
+	case "1", "2", "3", "4", "5", "6", "7":
+		return m.switchTab(int(msg.Runes[0] - '1')), nil
+	}
+
+	return m.updateTab(msg)
+}
+
+// acceptSuggestion puts the selected suggestion into the entry bar
+func (m DashboardModel) acceptSuggestion() DashboardModel {
+	text, cursor := m.complete.InsertSuggestion(m.input.Value())
+	m.input.SetValue(text)
+	m.input.SetCursor(cursor)
+	m.complete.Deactivate()
+	return m
+}
+
+// switchTab makes tab active. Leaving Search finishes editing the query.
+func (m DashboardModel) switchTab(tab int) DashboardModel {
+	if m.focus == focusSearch && tab != tabSearch {
+		m.focus = focusNone
+		m.search.query.Blur()
+	}
+	m.tab = tab
+	return m
+}
+
+// updateTab passes a message to the active tab
+func (m DashboardModel) updateTab(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.data == nil {
+		return m, nil
+	}
+
+	var updated tea.Model
+	var cmd tea.Cmd
+	switch m.tab {
+	case tabToday:
+		updated, cmd = m.today.Update(msg)
+		m.today = updated.(ViewModel)
+	case tabLog:
+		updated, cmd = m.feed.Update(msg)
+		m.feed = updated.(logFeedModel)
+	case tabWeek:
+		updated, cmd = m.week.Update(msg)
+		m.week = updated.(WeekModel)
+	case tabWins:
+		updated, cmd = m.wins.Update(msg)
+		m.wins = updated.(ListWinsModel)
+	case tabThoughts:
+		updated, cmd = m.thoughts.Update(msg)
+		m.thoughts = updated.(ListThoughtsModel)
+	case tabSearch:
+		updated, cmd = m.search.Update(msg)
+		m.search = updated.(searchModel)
+	case tabHelp:
+		updated, cmd = m.help.Update(msg)
+		m.help = updated.(HelpModel)
+	}
+	return m, cmd
+}
+
+// apply rebuilds every tab from data, keeping each tab's toggles and scroll position
+func (m DashboardModel) apply(data *dashboardData) DashboardModel {
+	previous := m
+	m.data = data
+
+	m.today = NewViewModel(data.day, data.entries).
+		WithAwayIntervals(data.away, m.rules).
+		WithMaxCollapsedLen(m.maxCollapsedLen)
+	m.today.textExpanded = previous.today.textExpanded
+	m.today.revealPrivate = m.reveal
+
+	m.feed = logFeedModel{
+		entries: entriesSince(data.recent, data.loaded.AddDate(0, 0, -(logFeedDays-1))),
+		reveal:  m.reveal,
+	}
+
+	m.week = NewWeekModel(data.week).WithStatsMode(m.statsMode)
+	if previous.data != nil {
+		m.week.statsMode = previous.week.statsMode
+		m.week.expanded = previous.week.expanded
+	}
+
+	m.wins = NewListWinsModel(data.day, entriesWithPrefix(data.entries, "🌟"))
+	m.thoughts = NewListThoughtsModel(data.day, entriesWithPrefix(data.entries, "💭"))
+	m.thoughts.reveal = m.reveal
+
+	m.complete = m.complete.WithHistory(NewCompletionHistory(data.recent, data.loaded))
+
+	m.search.entries = data.recent
+	m.search.reveal = m.reveal
+	m.search.ready = false // Re-run the query at the next resize
+
+	if m.width == 0 {
+		return m
+	}
+	m = m.resizeTabs()
+
+	// Rebuilt tabs start at the top; put them back where they were
+	if m.followLast {
+		m.today.viewport.GotoBottom()
+		m.followLast = false
+	} else {
+		m.today.viewport.SetYOffset(previous.today.viewport.YOffset)
+	}
+	m.feed.viewport.SetYOffset(previous.feed.viewport.YOffset)
+	m.week.viewport.SetYOffset(previous.week.viewport.YOffset)
+	m.wins.viewport.SetYOffset(previous.wins.viewport.YOffset)
+	m.thoughts.viewport.SetYOffset(previous.thoughts.viewport.YOffset)
+	m.search.viewport.SetYOffset(previous.search.viewport.YOffset)
+	return m
+}
+
+// resizeTabs lays every tab out in the space between the tab bar and the entry bar
+func (m DashboardModel) resizeTabs() DashboardModel {
+	// Tabs reserve two lines for their own help, which the dashboard replaces
+	// with the entry bar and status line; one more goes to the tab bar
+	size := tea.WindowSizeMsg{Width: m.width, Height: m.height - 1}
+
+	m.today = sized(m.today, size)
+	m.feed = sized(m.feed, size)
+	m.week = sized(m.week, size)
+	m.wins = sized(m.wins, size)
+	m.thoughts = sized(m.thoughts, size)
+	m.search = sized(m.search, size)
+	m.help = sized(m.help, size)
+	return m
+}
+
+// sized passes a window size to a tab
+func sized[T tea.Model](tab T, size tea.WindowSizeMsg) T {
+	updated, _ := tab.Update(size)
+	return updated.(T)
+}
+
+// View renders the dashboard
+func (m DashboardModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(m.renderTabBar())
+	b.WriteString("\n")
+
+	switch {
+	case m.data == nil && m.statusErr:
+		b.WriteString(ErrorStyle.Render(m.status))
+		return b.String()
+	case m.data == nil || m.width == 0:
+		b.WriteString("Loading...")
+		return b.String()
+	}
+
+	if m.ritual != nil {
+		b.WriteString(m.ritual.View())
+		return b.String()
+	}
+
+	switch m.tab {
+	case tabToday:
+		b.WriteString(m.today.viewport.View())
+	case tabLog:
+		b.WriteString(m.feed.View())
+	case tabWeek:
+		b.WriteString(m.week.viewport.View())
+	case tabWins:
+		b.WriteString(m.wins.viewport.View())
+	case tabThoughts:
+		b.WriteString(m.thoughts.viewport.View())
+	case tabSearch:
+		b.WriteString(m.search.View())
+	case tabHelp:
+		b.WriteString(m.help.viewport.View())
+	}
+	b.WriteString("\n")
+
+	b.WriteString(m.input.View())
+	b.WriteString("\n")
+
+	switch {
+	case m.focus == focusInput && m.complete.Active && len(m.complete.Suggestions) > 0:
+		b.WriteString(m.renderSuggestion())
+	case m.status != "" && m.statusErr:
+		b.WriteString(ErrorStyle.Render(m.status))
+	case m.status != "":
+		b.WriteString(SuccessStyle.Render(m.status))
+	default:
+		b.WriteString(DimStyle.Render(m.helpLine()))
+	}
+
+	return b.String()
+}
+
+// renderSuggestion renders the selected suggestion in place of the status line
+func (m DashboardModel) renderSuggestion() string {
+	action := "Tab/Enter to insert"
+	if m.complete.IsEntryCompletion() {
+		action = "Tab to complete • Enter to log as typed"
+	}
+	hint := fmt.Sprintf("  %d/%d • %s • ↑/↓ for more • Esc to close",
+		m.complete.SelectedIndex+1, len(m.complete.Suggestions), action)
+	return SelectedStyle.Render("› "+m.complete.GetSelectedSuggestion()) + DimStyle.Render(hint)
+}
+
+// renderTabBar renders the tab labels, highlighting the active one
+func (m DashboardModel) renderTabBar() string {
+	labels := make([]string, len(dashboardTabs))
+	for i, name := range dashboardTabs {
+		if i == m.tab {
+			labels[i] = SelectedStyle.Render(fmt.Sprintf("[%d %s]", i+1, name))
+		} else {
+			labels[i] = DimStyle.Render(fmt.Sprintf(" %d %s ", i+1, name))
+		}
+	}
+	return strings.Join(labels, " ")
+}
+
+// helpLine returns the key hints for the current focus and tab
+func (m DashboardModel) helpLine() string {
+	switch m.focus {
+	case focusInput:
+		return "Enter to log • Esc to browse • Tab to switch tabs • ↑/↓ to scroll"
+	case focusSearch:
+		return "Type to filter • Enter/Esc when done"
+	}
+
+	hints := []string{"i to log", "/ to search", "1-7 or Tab to switch", "↑/↓ to scroll"}
+	switch m.tab {
+	case tabToday:
+		hints = append(hints, "Shift+R to toggle text")
+	case tabWeek:
+		hints = append(hints, "t time/count", "e to expand themes")
+	}
+	if m.data != nil && hasPrivate(m.data.recent) {
+		hints = append(hints, "p to reveal private")
+	}
+	return strings.Join(append(hints, "q to quit"), " • ")
+}
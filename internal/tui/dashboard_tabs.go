@@ -0,0 +1,310 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aaryareddy/log_cli/internal/analytics"
+	"github.com/aaryareddy/log_cli/internal/database"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	logFeedDays = 7  // Days shown on the dashboard's Log tab
+	searchDays  = 90 // Days the dashboard's Search tab looks through
+)
+
+// dashboardData is everything the dashboard tabs show, loaded in one pass
+type dashboardData struct {
+	day     *database.Day // Today (unsaved until the first entry)
+	entries []*database.Entry
+	away    []database.AwayInterval
+	recent  []*database.Entry // The last searchDays days, oldest first
+	week    *analytics.WeeklyPatternSummary
+	loaded  time.Time
+}
+
+// loadDashboard reads today, the recent entries and this week's summary
+func loadDashboard(store *database.Store, rules analytics.DriftRules, synonyms analytics.Synonyms, energyDays int, weekStart time.Weekday, now time.Time) (*dashboardData, error) {
+	date := now.Format("2006-01-02")
+	data := &dashboardData{loaded: now}
+
+	day, err := store.GetDayByDate(date)
+	if err != nil {
+		return nil, err
+	}
+	if day == nil {
+		// Viewing doesn't create the day; the first entry does
+		day = &database.Day{Date: time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)}
+	} else if data.entries, err = store.GetTodayEntries(day.ID); err != nil {
+		return nil, err
+	}
+	data.day = day
+
+	if data.away, err = store.GetAwayIntervalsForRange(date, date); err != nil {
+		return nil, err
+	}
+	if data.recent, err = store.GetEntriesForDateRange(now.AddDate(0, 0, -(searchDays-1)).Format("2006-01-02"), date); err != nil {
+		return nil, err
+	}
+
+	if data.week, err = loadPeriodSummary(store, analytics.WeekPeriod(now, weekStart), rules, synonyms, energyDays); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// loadPeriodSummary analyzes a period with its away intervals and day records,
+// clustering themes with the given synonyms and mapping energy over the last
+// energyDays days
+func loadPeriodSummary(store *database.Store, period analytics.Period, rules analytics.DriftRules, synonyms analytics.Synonyms, energyDays int) (*analytics.WeeklyPatternSummary, error) {
+	entries, err := store.GetEntriesForDateRange(period.StartDate(), period.EndDate())
+	if err != nil {
+		return nil, err
+	}
+	days, err := store.GetDaysInRange(period.StartDate(), period.EndDate())
+	if err != nil {
+		return nil, err
+	}
+	away, err := store.GetAwayIntervalsForRange(period.StartDate(), period.EndDate())
+	if err != nil {
+		return nil, err
+	}
+
+	summary := analytics.AnalyzePeriod(entries, period)
+	summary.ClusterThemes(synonyms)
+	summary.ApplyAwayIntervals(away, rules)
+	summary.ApplyDays(days)
+
+	window := analytics.EnergyWindow(period, energyDays)
+	energyEntries := entries
+	if window.StartDate() != period.StartDate() {
+		if energyEntries, err = store.GetEntriesForDateRange(window.StartDate(), window.EndDate()); err != nil {
+			return nil, err
+		}
+	}
+	summary.ApplyEnergy(energyEntries, window)
+	return summary, nil
+}
+
+// entriesWithPrefix returns the entries whose text starts with prefix
+// (wins are stored as "🌟 ..." and thoughts as "💭 ...")
+func entriesWithPrefix(entries []*database.Entry, prefix string) []*database.Entry {
+	var matched []*database.Entry
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.EntryText, prefix) {
+			matched = append(matched, entry)
+		}
+	}
+	return matched
+}
+
+// entriesSince returns the entries logged on or after the start of since's day
+func entriesSince(entries []*database.Entry, since time.Time) []*database.Entry {
+	start := time.Date(since.Year(), since.Month(), since.Day(), 0, 0, 0, 0, time.Local)
+	for i, entry := range entries {
+		if !entry.Timestamp.Before(start) {
+			return entries[i:]
+		}
+	}
+	return nil
+}
+
+// formatByDay renders entries (oldest first) under a heading per day, newest
+// day first. view supplies the private and expansion settings.
+func formatByDay(entries []*database.Entry, view ViewModel) string {
+	var dates []string
+	byDate := make(map[string][]*database.Entry)
+	for _, entry := range entries {
+		date := entry.Timestamp.Format("2006-01-02")
+		if _, ok := byDate[date]; !ok {
+			dates = append(dates, date)
+		}
+		byDate[date] = append(byDate[date], entry)
+	}
+
+	var b strings.Builder
+	for i := len(dates) - 1; i >= 0; i-- {
+		dayEntries := byDate[dates[i]]
+		if i < len(dates)-1 {
+			b.WriteString("\n\n")
+		}
+		b.WriteString(SubheaderStyle.Render(dayEntries[0].Timestamp.Format("Monday, January 2")))
+		b.WriteString(DimStyle.Render(fmt.Sprintf(" (%d)", len(dayEntries))))
+		b.WriteString("\n")
+		b.WriteString(view.formatEntries(report, dayEntries))
+	}
+	return b.String()
+}
+
+// logFeedModel is the dashboard's Log tab: the last logFeedDays days, newest first
+type logFeedModel struct {
+	entries  []*database.Entry
+	reveal   bool
+	viewport viewport.Model
+	ready    bool
+}
+
+// Init initializes the model
+func (m logFeedModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m logFeedModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	if msg, ok := msg.(tea.WindowSizeMsg); ok {
+		if !m.ready {
+			m.viewport = viewport.New(msg.Width, msg.Height-2)
+			m.viewport.SetContent(m.generateContent())
+			m.ready = true
+		} else {
+			m.viewport.Width = msg.Width
+			m.viewport.Height = msg.Height - 2
+		}
+	}
+
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// generateContent generates the recent days' entries
+func (m logFeedModel) generateContent() string {
+	var b strings.Builder
+
+	b.WriteString(RenderHeaderBar("LOG", fmt.Sprintf("last %d days", logFeedDays)))
+	b.WriteString("\n\n")
+
+	if len(m.entries) == 0 {
+		b.WriteString(DimStyle.Render("Nothing logged in the last few days. Press i to start."))
+		return b.String()
+	}
+
+	b.WriteString(formatByDay(m.entries, ViewModel{revealPrivate: m.reveal, textExpanded: true}))
+	return b.String()
+}
+
+// View renders the tab
+func (m logFeedModel) View() string {
+	return m.viewport.View()
+}
+
+// searchModel is the dashboard's Search tab: recent entries filtered by a query
+type searchModel struct {
+	query    textinput.Model
+	entries  []*database.Entry // Searched entries, oldest first
+	reveal   bool
+	viewport viewport.Model
+	ready    bool
+}
+
+// newSearchModel creates an empty search tab
+func newSearchModel() searchModel {
+	ti := textinput.New()
+	ti.Placeholder = "text, @tag or [FLAG]"
+	ti.Prompt = "/ "
+	ti.PromptStyle = PromptStyle
+	return searchModel{query: ti}
+}
+
+// Init initializes the model
+func (m searchModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages. The query input is updated by the dashboard,
+// which owns focus; call refresh after changing it.
+func (m searchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	if msg, ok := msg.(tea.WindowSizeMsg); ok {
+		// Two lines for the query
+		if !m.ready {
+			m.viewport = viewport.New(msg.Width, msg.Height-4)
+			m.viewport.SetContent(m.generateContent())
+			m.ready = true
+		} else {
+			m.viewport.Width = msg.Width
+			m.viewport.Height = msg.Height - 4
+		}
+		m.query.Width = msg.Width - 4
+	}
+
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// refresh re-runs the query and scrolls back to the top
+func (m searchModel) refresh() searchModel {
+	if m.ready {
+		m.viewport.SetContent(m.generateContent())
+		m.viewport.GotoTop()
+	}
+	return m
+}
+
+// matches returns the entries containing every term of the query in their
+// text or tags. Private entries match on tags only until revealed.
+func (m searchModel) matches() []*database.Entry {
+	terms := strings.Fields(strings.ToLower(m.query.Value()))
+	if len(terms) == 0 {
+		return nil
+	}
+
+	var matched []*database.Entry
+	for _, entry := range m.entries {
+		var haystack strings.Builder
+		if !entry.Private || m.reveal {
+			haystack.WriteString(strings.ToLower(entry.EntryText))
+		}
+		for _, tag := range entry.Tags {
+			haystack.WriteString(" " + strings.ToLower(tag.TagValue))
+		}
+
+		found := true
+		for _, term := range terms {
+			if !strings.Contains(haystack.String(), term) {
+				found = false
+				break
+			}
+		}
+		if found {
+			matched = append(matched, entry)
+		}
+	}
+	return matched
+}
+
+// generateContent generates the search results
+func (m searchModel) generateContent() string {
+	var b strings.Builder
+
+	b.WriteString(RenderHeaderBar("SEARCH", fmt.Sprintf("last %d days", searchDays)))
+	b.WriteString("\n\n")
+
+	if strings.TrimSpace(m.query.Value()) == "" {
+		b.WriteString(DimStyle.Render("Type / and a word, @tag or [FLAG] to search your recent entries."))
+		return b.String()
+	}
+
+	matched := m.matches()
+	if len(matched) == 0 {
+		b.WriteString(DimStyle.Render("No matches."))
+		return b.String()
+	}
+
+	b.WriteString(BoldStyle.Render(fmt.Sprintf("Matches: %d", len(matched))))
+	b.WriteString("\n\n")
+	b.WriteString(formatByDay(matched, ViewModel{revealPrivate: m.reveal, textExpanded: true}))
+	return b.String()
+}
+
+// View renders the tab
+func (m searchModel) View() string {
+	return m.query.View() + "\n\n" + m.viewport.View()
+}
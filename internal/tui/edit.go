@@ -30,7 +30,7 @@ func NewEditModel(entryIndex int, timestamp time.Time, originalText string) Edit
 
 	// Set generous height for visibility
 	ta.SetHeight(6)
-	ta.SetWidth(70) // Will be updated in WindowSizeMsg
+	ta.SetWidth(70)           // Will be updated in WindowSizeMsg
 	ta.SetValue(originalText) // Pre-fill with existing text
 
 	return EditModel{
@@ -0,0 +1,131 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aaryareddy/log_cli/internal/analytics"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// EnergyModel is the model for the hour-of-day × weekday energy heatmap
+type EnergyModel struct {
+	energy    *analytics.EnergyMap
+	metricIdx int
+	viewport  viewport.Model
+	ready     bool
+}
+
+// NewEnergyModel creates a new energy view model
+func NewEnergyModel(energy *analytics.EnergyMap) EnergyModel {
+	return EnergyModel{
+		energy: energy,
+	}
+}
+
+// Init initializes the model
+func (m EnergyModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m EnergyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		case "tab", "right", "l":
+			// Cycle to next metric
+			m.metricIdx = (m.metricIdx + 1) % len(analytics.EnergyMetrics)
+			if m.ready {
+				m.viewport.SetContent(m.generateContent())
+			}
+			return m, nil
+		case "shift+tab", "left", "h":
+			// Cycle to previous metric
+			m.metricIdx = (m.metricIdx - 1 + len(analytics.EnergyMetrics)) % len(analytics.EnergyMetrics)
+			if m.ready {
+				m.viewport.SetContent(m.generateContent())
+			}
+			return m, nil
+		}
+	case tea.WindowSizeMsg:
+		if !m.ready {
+			// Initialize viewport on first window size message
+			m.viewport = viewport.New(msg.Width, msg.Height-2)
+			m.viewport.SetContent(m.generateContent())
+			m.ready = true
+		} else {
+			m.viewport.Width = msg.Width
+			m.viewport.Height = msg.Height - 2
+		}
+	}
+
+	// Update viewport (handles scrolling)
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// generateContent generates the heatmap and insights content
+func (m EnergyModel) generateContent() string {
+	var b strings.Builder
+
+	b.WriteString(RenderHeaderBar("ENERGY PATTERNS", fmt.Sprintf("%s to %s", m.energy.StartDate, m.energy.EndDate)))
+	b.WriteString("\n\n")
+
+	// Metric selector
+	for i, metric := range analytics.EnergyMetrics {
+		label := metric
+		switch metric {
+		case analytics.MetricUp:
+			label = "↑"
+		case analytics.MetricDown:
+			label = "↓"
+		}
+		if i == m.metricIdx {
+			b.WriteString(SelectedStyle.Render("[" + label + "]"))
+		} else {
+			b.WriteString(DimStyle.Render(" " + label + " "))
+		}
+		b.WriteString(" ")
+	}
+	b.WriteString("\n\n")
+
+	b.WriteString(report.EnergyHeatmap(m.energy, analytics.EnergyMetrics[m.metricIdx]))
+
+	// Insights section
+	b.WriteString("\n")
+	b.WriteString(AccentStyle.Render("INSIGHTS"))
+	b.WriteString("\n")
+	b.WriteString(DimStyle.Render(strings.Repeat("━", 70)))
+	b.WriteString("\n\n")
+
+	insights := analytics.EnergyInsights(m.energy)
+	for _, insight := range insights {
+		b.WriteString(DimStyle.Render("  → "))
+		b.WriteString(insight)
+		b.WriteString("\n")
+	}
+
+	if len(insights) == 0 {
+		b.WriteString(DimStyle.Render(fmt.Sprintf("  Keep logging with momentum markers - patterns appear after %d+ days of data.", analytics.DefaultEnergyWindowDays)))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// View renders the UI
+func (m EnergyModel) View() string {
+	if !m.ready {
+		return "Loading..."
+	}
+
+	viewContent := m.viewport.View() + "\n"
+	viewContent += DimStyle.Render("Tab/←/→ to change metric • ↑/↓ or j/k to scroll • q/esc to exit")
+	return viewContent
+}
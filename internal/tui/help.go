@@ -70,16 +70,62 @@ func generateHelpContent() string {
 	b.WriteString("\n")
 	b.WriteString(MetadataStyle.Render("  log              "))
 	b.WriteString("Create a new log entry\n")
+	b.WriteString(MetadataStyle.Render("  log -m \"text\"    "))
+	b.WriteString("Log without prompts (scripts, keybindings, cron)\n")
+	b.WriteString(MetadataStyle.Render("  log -            "))
+	b.WriteString("Log each line from stdin; rituals wait for the next `log`\n")
+	b.WriteString(MetadataStyle.Render("  log ui           "))
+	b.WriteString("Dashboard with tabs and an entry bar that stays open\n")
 	b.WriteString(MetadataStyle.Render("  log view         "))
-	b.WriteString("Display today's log entries\n")
+	b.WriteString("Display today's log entries; j/k select, e edit, d delete, a add\n")
 	b.WriteString(MetadataStyle.Render("  log view [date]  "))
 	b.WriteString("View specific date (YYYY-MM-DD, -N, or 'yesterday')\n")
+	b.WriteString(MetadataStyle.Render("  log calendar     "))
+	b.WriteString("Month grid of logged days; Enter opens a day, w its week ([month] YYYY-MM)\n")
 	b.WriteString(MetadataStyle.Render("  log yesterday    "))
 	b.WriteString("Display yesterday's log entries\n")
 	b.WriteString(MetadataStyle.Render("  log stats        "))
 	b.WriteString("Quick weekly overview (logs, tags, momentum)\n")
+	b.WriteString(MetadataStyle.Render("  log stats --from --to"))
+	b.WriteString(" Stats for any date range (YYYY-MM-DD)\n")
 	b.WriteString(MetadataStyle.Render("  log week         "))
 	b.WriteString("Deep pattern analysis with grouped flags and insights\n")
+	b.WriteString(MetadataStyle.Render("  log week [when]  "))
+	b.WriteString("Review another week (-1, 2025-W41, or YYYY-MM-DD)\n")
+	b.WriteString(MetadataStyle.Render("  log month [when] "))
+	b.WriteString("Review a calendar month (-1 or YYYY-MM)\n")
+	b.WriteString(MetadataStyle.Render("  log energy       "))
+	b.WriteString("Hour × weekday heatmaps of momentum, flags and tags\n")
+	b.WriteString(MetadataStyle.Render("  log correlations "))
+	b.WriteString("Which tags go with ↑/↓ and what precedes [LEAK]/[FLOW]\n")
+	b.WriteString(MetadataStyle.Render("  log streaks      "))
+	b.WriteString("Logging, sign-off and intention streaks + consistency (celebrates new bests)\n")
+	b.WriteString(MetadataStyle.Render("  log review [when]"))
+	b.WriteString(" Intentions vs follow-through and off-track reasons (month)\n")
+	b.WriteString(MetadataStyle.Render("  log status       "))
+	b.WriteString("Time since last log for prompts (--format, --preset tmux|starship)\n")
+	b.WriteString(MetadataStyle.Render("  log serve        "))
+	b.WriteString("Local HTTP API on 127.0.0.1:7878 (--socket path, --token)\n")
+	b.WriteString(MetadataStyle.Render("  log webhooks     "))
+	b.WriteString("Webhook targets and outbox (status, replay, add <url>, remove)\n")
+	b.WriteString(MetadataStyle.Render("  log settings     "))
+	b.WriteString("Toggle rituals (intention, win, drift, sign-off, after-hours)\n")
+	b.WriteString(MetadataStyle.Render("  log config       "))
+	b.WriteString("get <key> | set <key> <value> | unset <key> | list\n")
+	b.WriteString(MetadataStyle.Render("  log profile      "))
+	b.WriteString("list | use <name> | report [week|month] across all profiles\n")
+	b.WriteString(MetadataStyle.Render("  log --profile x  "))
+	b.WriteString("Run any command against profile x (or set DAYLOG_PROFILE)\n")
+	b.WriteString(MetadataStyle.Render("  log encrypt      "))
+	b.WriteString("Encrypt the database and markdown logs with a passphrase\n")
+	b.WriteString(MetadataStyle.Render("  log decrypt      "))
+	b.WriteString("Convert an encrypted log back to plaintext\n")
+	b.WriteString(MetadataStyle.Render("  log unlock/lock  "))
+	b.WriteString("Cache the key for a while (--for 2h) or forget it now\n")
+	b.WriteString(MetadataStyle.Render("  log cat [date]   "))
+	b.WriteString("Print a day's markdown log, decrypted\n")
+	b.WriteString(MetadataStyle.Render("  log paths        "))
+	b.WriteString("Show where the database, config, logs and caches live\n")
 	b.WriteString(MetadataStyle.Render("  log edit [n]     "))
 	b.WriteString("Edit most recent entry (or entry #n)\n")
 	b.WriteString(MetadataStyle.Render("  log delete [n]   "))
@@ -91,6 +137,10 @@ func generateHelpContent() string {
 	b.WriteString(MetadataStyle.Render("  log help         "))
 	b.WriteString("Show this help screen\n")
 	b.WriteString("\n")
+	b.WriteString(DimStyle.Render("  view, stats, week and month accept --json (typed data) or --plain (no colors)"))
+	b.WriteString("\n")
+	b.WriteString(DimStyle.Render("  and --redact none|private|all (default: export.redact, private)"))
+	b.WriteString("\n\n")
 
 	// Momentum markers section
 	b.WriteString(SubheaderStyle.Render("MOMENTUM MARKERS"))
@@ -144,6 +194,8 @@ func generateHelpContent() string {
 	b.WriteString("More than 90 minutes without logging\n")
 	b.WriteString(MetadataStyle.Render("  [ANCHOR]         "))
 	b.WriteString("Non-negotiable check-in points\n")
+	b.WriteString(MetadataStyle.Render("  !private         "))
+	b.WriteString("Counted in stats, but the text stays out of markdown and exports\n")
 	b.WriteString("\n")
 
 	// Examples section
@@ -165,6 +217,11 @@ func generateHelpContent() string {
 	b.WriteString("\n")
 	b.WriteString(AccentStyle.Render("  > "))
 	b.WriteString("Finished proposal draft! [FLOW] ++ @zone\n")
+	b.WriteString("\n")
+	b.WriteString(DimStyle.Render("  Typing @ or [ lists tags, most used at this hour first; typing the start of"))
+	b.WriteString("\n")
+	b.WriteString(DimStyle.Render("  a past entry (\"stand\") offers it with its usual tags and momentum on Tab."))
+	b.WriteString("\n")
 
 	return b.String()
 }
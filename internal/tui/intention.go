@@ -3,6 +3,9 @@ package tui
 import (
 	"strings"
 
+	"github.com/aaryareddy/log_cli/internal/database"
+	"github.com/aaryareddy/log_cli/internal/quicklog"
+
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -12,6 +15,7 @@ type IntentionModel struct {
 	input     textinput.Model
 	submitted bool
 	intention string
+	saver     ritualSaver
 }
 
 // NewIntentionModel creates a new intention model
@@ -27,6 +31,12 @@ func NewIntentionModel() IntentionModel {
 	}
 }
 
+// WithLogger saves the intention for day through logger on submit
+func (m IntentionModel) WithLogger(logger *quicklog.Logger, day *database.Day) IntentionModel {
+	m.saver = ritualSaver{logger: logger, day: day}
+	return m
+}
+
 // Init initializes the model
 func (m IntentionModel) Init() tea.Cmd {
 	return textinput.Blink
@@ -41,6 +51,11 @@ func (m IntentionModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Submit intention (can be empty to skip)
 			m.submitted = true
 			m.intention = strings.TrimSpace(m.input.Value())
+			if m.intention != "" {
+				m.saver.save(func(l *quicklog.Logger, day *database.Day) error {
+					return l.SetIntention(day, m.intention)
+				})
+			}
 			return m, tea.Quit
 		case tea.KeyCtrlC, tea.KeyEsc:
 			// Cancel without setting intention
@@ -90,3 +105,8 @@ func (m IntentionModel) GetIntention() string {
 func (m IntentionModel) WasSubmitted() bool {
 	return m.submitted
 }
+
+// Err returns the error saving the intention through the logger, if any
+func (m IntentionModel) Err() error {
+	return m.saver.err
+}
@@ -14,6 +14,7 @@ type ListThoughtsModel struct {
 	thoughts []*database.Entry
 	viewport viewport.Model
 	ready    bool
+	reveal   bool // Show private thoughts instead of blurring them
 }
 
 // NewListThoughtsModel creates a new list thoughts model
@@ -38,6 +39,10 @@ func (m ListThoughtsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch msg.String() {
 		case "q", "esc", "ctrl+c":
 			return m, tea.Quit
+		case "p":
+			m.reveal = !m.reveal
+			m.viewport.SetContent(m.generateContent())
+			return m, nil
 		}
 	case tea.WindowSizeMsg:
 		if !m.ready {
@@ -82,6 +87,9 @@ func (m ListThoughtsModel) generateContent() string {
 
 			// Thought text (keep the 💭 prefix for display)
 			thoughtText := thought.EntryText
+			if thought.Private && !m.reveal {
+				thoughtText = DimStyle.Render(blurredText)
+			}
 			b.WriteString(thoughtText)
 		}
 	}
@@ -95,7 +103,12 @@ func (m ListThoughtsModel) View() string {
 		return "Loading..."
 	}
 
+	help := "↑/↓ or j/k to scroll • q/esc to exit"
+	if hasPrivate(m.thoughts) {
+		help = "↑/↓ or j/k to scroll • p to reveal private • q/esc to exit"
+	}
+
 	viewContent := m.viewport.View() + "\n"
-	viewContent += DimStyle.Render("↑/↓ or j/k to scroll • q/esc to exit")
+	viewContent += DimStyle.Render(help)
 	return viewContent
 }
@@ -5,6 +5,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/aaryareddy/log_cli/internal/analytics"
+	"github.com/aaryareddy/log_cli/internal/database"
+	"github.com/aaryareddy/log_cli/internal/quicklog"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -23,6 +26,7 @@ type LogEntryModel struct {
 	submitted             bool
 	entryText             string
 	autocomplete          AutocompleteState
+	saver                 ritualSaver
 }
 
 // NewLogEntryModel creates a new log entry model
@@ -35,7 +39,7 @@ func NewLogEntryModel(lastLog time.Time, count int, dayCompleted bool) LogEntryM
 	now := time.Now()
 	isDrift := false
 	// Only show drift alert if day is not completed and sufficient time has passed
-	if !dayCompleted && !lastLog.IsZero() && now.Sub(lastLog).Minutes() >= 90 {
+	if !dayCompleted && !lastLog.IsZero() && now.Sub(lastLog) >= analytics.DefaultDriftThreshold {
 		isDrift = true
 	}
 
@@ -52,6 +56,25 @@ func NewLogEntryModel(lastLog time.Time, count int, dayCompleted bool) LogEntryM
 	}
 }
 
+// WithLogger records an acknowledged drift alert for day through logger
+func (m LogEntryModel) WithLogger(logger *quicklog.Logger, day *database.Day) LogEntryModel {
+	m.saver = ritualSaver{logger: logger, day: day}
+	return m
+}
+
+// WithDriftAlert applies the configured drift threshold, or disables the alert
+func (m LogEntryModel) WithDriftAlert(threshold time.Duration, enabled bool) LogEntryModel {
+	m.isDriftAlert = enabled && !m.dayCompleted && !m.lastLogTime.IsZero() &&
+		m.timestamp.Sub(m.lastLogTime) >= threshold
+	return m
+}
+
+// WithHistory ranks tags by past use and suggests past activities as you type
+func (m LogEntryModel) WithHistory(history *CompletionHistory) LogEntryModel {
+	m.autocomplete = m.autocomplete.WithHistory(history)
+	return m
+}
+
 // Init initializes the model
 func (m LogEntryModel) Init() tea.Cmd {
 	return textinput.Blink
@@ -74,8 +97,9 @@ func (m LogEntryModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.autocomplete.MoveDown()
 				return m, nil
 			case tea.KeyTab, tea.KeyEnter:
-				// If Enter and autocomplete has suggestions, insert suggestion
-				if msg.Type == tea.KeyEnter && m.autocomplete.GetSelectedSuggestion() != "" {
+				// If Enter and autocomplete has tag suggestions, insert suggestion
+				// (Enter logs past-entry completions' text as typed)
+				if msg.Type == tea.KeyEnter && !m.autocomplete.IsEntryCompletion() && m.autocomplete.GetSelectedSuggestion() != "" {
 					newText, newCursorPos := m.autocomplete.InsertSuggestion(m.input.Value())
 					m.input.SetValue(newText)
 					m.input.SetCursor(newCursorPos)
@@ -108,6 +132,9 @@ func (m LogEntryModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// User wants to remove drift alert
 				m.driftRemovalRequested = true
 				m.isDriftAlert = false
+				m.saver.save(func(l *quicklog.Logger, day *database.Day) error {
+					return l.AcknowledgeDrift(day, m.lastLogTime, m.timestamp)
+				})
 				return m, nil
 			}
 		case tea.KeyEnter:
@@ -132,30 +159,7 @@ func (m LogEntryModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	// If text changed, apply momentum conversion and update autocomplete
 	if m.input.Value() != previousValue {
-		oldValue := m.input.Value()
-		cursorPos := m.input.Position()
-
-		// Apply real-time momentum marker conversion
-		newValue := convertMomentumMarkers(oldValue)
-
-		// Update input if conversion changed the text
-		if newValue != oldValue {
-			// Calculate cursor position adjustment due to text length change
-			// Count how many characters were added/removed before the cursor
-			beforeCursor := oldValue[:cursorPos]
-			convertedBefore := convertMomentumMarkers(beforeCursor)
-			cursorDelta := len(convertedBefore) - len(beforeCursor)
-
-			m.input.SetValue(newValue)
-			// Adjust cursor position based on text length change
-			newCursorPos := cursorPos + cursorDelta
-			if newCursorPos > len(newValue) {
-				newCursorPos = len(newValue)
-			} else if newCursorPos < 0 {
-				newCursorPos = 0
-			}
-			m.input.SetCursor(newCursorPos)
-		}
+		convertInputMarkers(&m.input)
 
 		// Update autocomplete state with current text and cursor position
 		m.autocomplete.Update(m.input.Value(), m.input.Position())
@@ -222,7 +226,9 @@ func (m LogEntryModel) View() string {
 	b.WriteString("\n\n")
 
 	// Control hints - context aware
-	if m.autocomplete.Active {
+	if m.autocomplete.IsEntryCompletion() {
+		b.WriteString(DimStyle.Render("↑↓ navigate • Tab complete • Enter log as typed • Esc close"))
+	} else if m.autocomplete.Active {
 		b.WriteString(DimStyle.Render("↑↓ navigate • Tab/Enter select • Esc close • Ctrl+C cancel"))
 	} else {
 		b.WriteString(DimStyle.Render("Enter to log • Ctrl+C exit • log help for full guide"))
@@ -246,6 +252,17 @@ func (m LogEntryModel) WasDriftRemovalRequested() bool {
 	return m.driftRemovalRequested
 }
 
+// Err returns the error recording the drift acknowledgment through the logger, if any
+func (m LogEntryModel) Err() error {
+	return m.saver.err
+}
+
+// GetDriftInterval returns the gap the drift alert covered (last log until this session started)
+// Used to record an acknowledged "stepped away" interval when drift removal is requested
+func (m LogEntryModel) GetDriftInterval() (start, end time.Time) {
+	return m.lastLogTime, m.timestamp
+}
+
 // formatDuration formats a duration into a human-readable string
 func formatDuration(d time.Duration) string {
 	hours := int(d.Hours())
@@ -256,6 +273,34 @@ func formatDuration(d time.Duration) string {
 	return fmt.Sprintf("%dm", mins)
 }
 
+// convertInputMarkers applies real-time momentum marker conversion to an
+// input, keeping the cursor after the same character
+func convertInputMarkers(input *textinput.Model) {
+	oldValue := input.Value()
+	cursorPos := input.Position()
+
+	newValue := convertMomentumMarkers(oldValue)
+	if newValue == oldValue {
+		return
+	}
+
+	// Calculate cursor position adjustment due to text length change
+	// Count how many characters were added/removed before the cursor
+	beforeCursor := oldValue[:cursorPos]
+	convertedBefore := convertMomentumMarkers(beforeCursor)
+	cursorDelta := len(convertedBefore) - len(beforeCursor)
+
+	input.SetValue(newValue)
+	// Adjust cursor position based on text length change
+	newCursorPos := cursorPos + cursorDelta
+	if newCursorPos > len(newValue) {
+		newCursorPos = len(newValue)
+	} else if newCursorPos < 0 {
+		newCursorPos = 0
+	}
+	input.SetCursor(newCursorPos)
+}
+
 // convertMomentumMarkers converts momentum shortcuts to arrow symbols in real-time
 func convertMomentumMarkers(text string) string {
 	// Replace shortcuts with arrows
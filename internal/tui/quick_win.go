@@ -3,6 +3,9 @@ package tui
 import (
 	"strings"
 
+	"github.com/aaryareddy/log_cli/internal/database"
+	"github.com/aaryareddy/log_cli/internal/quicklog"
+
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -14,6 +17,7 @@ type QuickWinModel struct {
 	height    int
 	submitted bool
 	winText   string
+	saver     ritualSaver
 }
 
 // NewQuickWinModel creates a new quick win model
@@ -29,6 +33,12 @@ func NewQuickWinModel() QuickWinModel {
 	}
 }
 
+// WithLogger saves the win for day through logger on submit
+func (m QuickWinModel) WithLogger(logger *quicklog.Logger, day *database.Day) QuickWinModel {
+	m.saver = ritualSaver{logger: logger, day: day}
+	return m
+}
+
 // Init initializes the model
 func (m QuickWinModel) Init() tea.Cmd {
 	return textinput.Blink
@@ -44,6 +54,9 @@ func (m QuickWinModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.input.Value() != "" {
 				m.submitted = true
 				m.winText = m.input.Value()
+				m.saver.save(func(l *quicklog.Logger, day *database.Day) error {
+					return l.RecordWin(day, m.winText)
+				})
 				return m, tea.Quit
 			}
 			return m, nil
@@ -89,3 +102,8 @@ func (m QuickWinModel) GetWin() string {
 func (m QuickWinModel) WasSubmitted() bool {
 	return m.submitted
 }
+
+// Err returns the error saving the win through the logger, if any
+func (m QuickWinModel) Err() error {
+	return m.saver.err
+}
@@ -0,0 +1,90 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/aaryareddy/log_cli/internal/analytics"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ReviewModel is the model for reviewing intentions and sign-off reflections over a period
+type ReviewModel struct {
+	period        analytics.Period
+	followThrough *analytics.FollowThrough
+	pairings      []analytics.ProtectPairing
+	reasons       []analytics.Theme
+	viewport      viewport.Model
+	ready         bool
+}
+
+// NewReviewModel creates a new reflection review model
+func NewReviewModel(period analytics.Period, followThrough *analytics.FollowThrough, pairings []analytics.ProtectPairing, reasons []analytics.Theme) ReviewModel {
+	return ReviewModel{
+		period:        period,
+		followThrough: followThrough,
+		pairings:      pairings,
+		reasons:       reasons,
+	}
+}
+
+// Init initializes the model
+func (m ReviewModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m ReviewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		}
+	case tea.WindowSizeMsg:
+		if !m.ready {
+			// Initialize viewport on first window size message
+			m.viewport = viewport.New(msg.Width, msg.Height-2)
+			m.viewport.SetContent(m.generateContent())
+			m.ready = true
+		} else {
+			m.viewport.Width = msg.Width
+			m.viewport.Height = msg.Height - 2
+		}
+	}
+
+	// Update viewport (handles scrolling)
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// generateContent generates the review content
+func (m ReviewModel) generateContent() string {
+	var b strings.Builder
+
+	b.WriteString(RenderHeaderBar("REFLECTION REVIEW", m.period.Label))
+	b.WriteString("\n\n")
+
+	b.WriteString(report.FollowThrough(m.followThrough))
+	b.WriteString("\n")
+
+	b.WriteString(report.ReflectionThemes(m.reasons))
+	b.WriteString("\n")
+
+	b.WriteString(report.ProtectPairings(m.pairings))
+
+	return b.String()
+}
+
+// View renders the UI
+func (m ReviewModel) View() string {
+	if !m.ready {
+		return "Loading..."
+	}
+
+	viewContent := m.viewport.View() + "\n"
+	viewContent += DimStyle.Render("↑/↓ or j/k to scroll • q/esc to exit")
+	return viewContent
+}
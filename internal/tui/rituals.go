@@ -0,0 +1,22 @@
+package tui
+
+import (
+	"github.com/aaryareddy/log_cli/internal/database"
+	"github.com/aaryareddy/log_cli/internal/quicklog"
+)
+
+// ritualSaver saves a prompt's answer through quicklog.Logger when the prompt
+// was given one (see the prompts' WithLogger), so hooks and webhooks fire
+// as they do for entries. Without a logger the caller saves the answer.
+type ritualSaver struct {
+	logger *quicklog.Logger
+	day    *database.Day
+	err    error
+}
+
+// save runs fn with the logger and day, keeping its error for Err
+func (s *ritualSaver) save(fn func(*quicklog.Logger, *database.Day) error) {
+	if s.logger != nil {
+		s.err = fn(s.logger, s.day)
+	}
+}
@@ -0,0 +1,183 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aaryareddy/log_cli/internal/config"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// settingItem is one row in the settings screen
+type settingItem struct {
+	setting config.Setting
+	label   string
+	value   string // Current (possibly edited) value
+	initial string // Value when the screen opened
+	step    int    // Increment for numeric settings
+}
+
+// SettingsModel is the model for toggling rituals and adjusting their thresholds
+type SettingsModel struct {
+	items         []settingItem
+	selectedIndex int
+	saved         bool
+	cancelled     bool
+}
+
+// NewSettingsModel creates a settings model showing the resolved config
+func NewSettingsModel(c *config.Config) SettingsModel {
+	rows := []struct {
+		key   string
+		label string
+		step  int
+	}{
+		{config.KeyRitualIntention, "Morning intention prompt", 0},
+		{config.KeyRitualWin, "Win prompt", 0},
+		{config.KeyWinPrompt, "  ...after this many entries", 1},
+		{config.KeyRitualDrift, "Drift alerts", 0},
+		{config.KeyDriftThreshold, "  ...after this many minutes", 15},
+		{config.KeyRitualSignoff, "Sign-off reflection prompts", 0},
+		{config.KeyRitualAfterHours, "After-hours messages", 0},
+		{config.KeyAfterHoursStart, "  ...starting at hour (24h)", 1},
+	}
+
+	var items []settingItem
+	for _, row := range rows {
+		setting, ok := config.Lookup(row.key)
+		if !ok {
+			continue
+		}
+		value, _, _ := c.Get(row.key)
+		items = append(items, settingItem{
+			setting: setting,
+			label:   row.label,
+			value:   value,
+			initial: value,
+			step:    row.step,
+		})
+	}
+
+	return SettingsModel{items: items}
+}
+
+// Init initializes the model
+func (m SettingsModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m SettingsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "j", "down":
+			if m.selectedIndex < len(m.items)-1 {
+				m.selectedIndex++
+			}
+		case "k", "up":
+			if m.selectedIndex > 0 {
+				m.selectedIndex--
+			}
+		case " ", "enter", "x":
+			m.toggle()
+		case "l", "right", "+":
+			m.adjust(1)
+		case "h", "left", "-":
+			m.adjust(-1)
+		case "s":
+			m.saved = true
+			return m, tea.Quit
+		case "esc", "q", "ctrl+c":
+			m.cancelled = true
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+// toggle flips the selected setting if it is on/off
+func (m *SettingsModel) toggle() {
+	item := &m.items[m.selectedIndex]
+	if item.setting.Kind != config.KindBool {
+		return
+	}
+	on, _ := strconv.ParseBool(item.value)
+	item.value = strconv.FormatBool(!on)
+}
+
+// adjust steps the selected numeric setting up or down within its bounds
+func (m *SettingsModel) adjust(direction int) {
+	item := &m.items[m.selectedIndex]
+	if item.setting.Kind != config.KindInt {
+		return
+	}
+	n, _ := strconv.Atoi(item.value)
+	n += direction * item.step
+	n = max(item.setting.Min, min(item.setting.Max, n))
+	item.value = strconv.Itoa(n)
+}
+
+// View renders the settings screen
+func (m SettingsModel) View() string {
+	if m.saved || m.cancelled {
+		return ""
+	}
+
+	var b strings.Builder
+
+	b.WriteString(HeaderStyle.Render("DAILY RITUAL SETTINGS"))
+	b.WriteString("\n\n")
+
+	for i, item := range m.items {
+		var value string
+		switch item.setting.Kind {
+		case config.KindBool:
+			if item.value == "true" {
+				value = SuccessStyle.Render("[✓] on ")
+			} else {
+				value = DimStyle.Render("[ ] off")
+			}
+		default:
+			value = AccentStyle.Render(fmt.Sprintf("‹ %s ›", item.value))
+		}
+
+		label := fmt.Sprintf("%-30s", item.label)
+		if item.value != item.initial {
+			label = fmt.Sprintf("%-30s", item.label+" *")
+		}
+
+		if i == m.selectedIndex {
+			b.WriteString(SelectedStyle.Render("› " + label))
+		} else {
+			b.WriteString("  " + label)
+		}
+		b.WriteString(" ")
+		b.WriteString(value)
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(DimStyle.Render(m.items[m.selectedIndex].setting.Description))
+	b.WriteString("\n\n")
+	b.WriteString(DimStyle.Render("j/k to move • Space to toggle • ←/→ to adjust • s to save • Esc to cancel"))
+
+	return BoxStyle.Render(b.String())
+}
+
+// WasSaved returns whether the user saved their changes
+func (m SettingsModel) WasSaved() bool {
+	return m.saved
+}
+
+// GetChanges returns the settings that differ from when the screen opened, by key
+func (m SettingsModel) GetChanges() map[string]string {
+	changes := make(map[string]string)
+	for _, item := range m.items {
+		if item.value != item.initial {
+			changes[item.setting.Key] = item.value
+		}
+	}
+	return changes
+}
@@ -3,6 +3,8 @@ package tui
 import (
 	"strings"
 
+	"github.com/aaryareddy/log_cli/internal/database"
+	"github.com/aaryareddy/log_cli/internal/quicklog"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -15,6 +17,21 @@ type SignoffModel struct {
 	submitted bool
 	answers   []string
 	intention string
+	rating    bool                     // Rating the intention before the reflection questions
+	status    database.IntentionStatus // Empty if not rated
+	cancelled bool
+	saver     ritualSaver
+}
+
+// intentionRatings are the follow-through options shown at sign-off
+var intentionRatings = []struct {
+	key    string
+	label  string
+	status database.IntentionStatus
+}{
+	{"1", "Met", database.IntentionMet},
+	{"2", "Partly met", database.IntentionPartial},
+	{"3", "Missed", database.IntentionMissed},
 }
 
 // NewSignoffModel creates a new sign-off reflection model
@@ -43,9 +60,16 @@ func NewSignoffModel(intention string) SignoffModel {
 		submitted: false,
 		answers:   make([]string, len(questions)),
 		intention: intention,
+		rating:    intention != "",
 	}
 }
 
+// WithLogger signs day off through logger when the reflections are submitted
+func (m SignoffModel) WithLogger(logger *quicklog.Logger, day *database.Day) SignoffModel {
+	m.saver = ritualSaver{logger: logger, day: day}
+	return m
+}
+
 // Init initializes the model
 func (m SignoffModel) Init() tea.Cmd {
 	return textinput.Blink
@@ -55,6 +79,10 @@ func (m SignoffModel) Init() tea.Cmd {
 func (m SignoffModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.rating {
+			return m.updateRating(msg)
+		}
+
 		switch msg.Type {
 		case tea.KeyEnter:
 			// Save current answer
@@ -69,18 +97,26 @@ func (m SignoffModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			} else {
 				// All questions answered, submit
 				m.submitted = true
+				m.saver.save(func(l *quicklog.Logger, day *database.Day) error {
+					return l.SignOff(day, m.status, m.answers[0], m.answers[1], m.answers[2])
+				})
 				return m, tea.Quit
 			}
 
 		case tea.KeyCtrlC, tea.KeyEsc:
 			// Cancel sign-off
 			m.submitted = true
+			m.cancelled = true
 			// Clear answers to indicate cancellation
 			m.answers = make([]string, len(m.questions))
 			return m, tea.Quit
 
 		case tea.KeyShiftTab:
-			// Go back to previous question
+			// Go back to previous question (or the intention rating)
+			if m.current == 0 && m.intention != "" {
+				m.rating = true
+				return m, nil
+			}
 			if m.current > 0 {
 				m.inputs[m.current].Blur()
 				m.current--
@@ -95,6 +131,33 @@ func (m SignoffModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// updateRating handles keys while rating the day's intention
+func (m SignoffModel) updateRating(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC, tea.KeyEsc:
+		// Cancel sign-off
+		m.submitted = true
+		m.cancelled = true
+		m.answers = make([]string, len(m.questions))
+		m.status = ""
+		return m, tea.Quit
+	case tea.KeyEnter:
+		// Skip rating (keeps any earlier choice)
+		m.rating = false
+		return m, textinput.Blink
+	}
+
+	for _, r := range intentionRatings {
+		if msg.String() == r.key {
+			m.status = r.status
+			m.rating = false
+			return m, textinput.Blink
+		}
+	}
+
+	return m, nil
+}
+
 // View renders the UI
 func (m SignoffModel) View() string {
 	if m.submitted {
@@ -107,11 +170,28 @@ func (m SignoffModel) View() string {
 	b.WriteString(HeaderStyle.Render("DAY COMPLETE 🌙"))
 	b.WriteString("\n\n")
 
-	// Show intention if set
+	// Show intention if set, with its follow-through rating
 	if m.intention != "" {
 		b.WriteString(BoldStyle.Render("Today's Intention: "))
 		b.WriteString(m.intention)
 		b.WriteString("\n\n")
+
+		if m.rating {
+			b.WriteString(PromptStyle.Render("Did you follow through?"))
+			b.WriteString("\n")
+			for _, r := range intentionRatings {
+				b.WriteString(BoldStyle.Render(r.key))
+				b.WriteString(" " + r.label + "   ")
+			}
+			b.WriteString("\n\n")
+			b.WriteString(DimStyle.Render("1-3 to rate | Enter to skip | Esc to cancel"))
+			return BoxStyle.Render(b.String())
+		}
+
+		if m.status != "" {
+			b.WriteString(DimStyle.Render("Follow-through: " + intentionStatusLabel(m.status)))
+			b.WriteString("\n\n")
+		}
 	}
 
 	// Show all questions and inputs
@@ -154,7 +234,32 @@ func (m SignoffModel) GetReflections() (pulledOffTrack, keptOnTrack, tomorrowPro
 	return "", "", ""
 }
 
+// GetIntentionStatus returns the intention rating, or "" if it was skipped
+func (m SignoffModel) GetIntentionStatus() database.IntentionStatus {
+	return m.status
+}
+
+// intentionStatusLabel returns the display label for a rating
+func intentionStatusLabel(status database.IntentionStatus) string {
+	for _, r := range intentionRatings {
+		if r.status == status {
+			return r.label
+		}
+	}
+	return string(status)
+}
+
 // WasSubmitted returns whether the form was submitted
 func (m SignoffModel) WasSubmitted() bool {
 	return m.submitted
 }
+
+// WasCancelled returns whether sign-off was skipped with Esc
+func (m SignoffModel) WasCancelled() bool {
+	return m.cancelled
+}
+
+// Err returns the error signing off through the logger, if any
+func (m SignoffModel) Err() error {
+	return m.saver.err
+}
@@ -0,0 +1,204 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aaryareddy/log_cli/internal/analytics"
+	"github.com/aaryareddy/log_cli/internal/database"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// StreaksModel is the model for viewing logging streaks and the consistency score
+type StreaksModel struct {
+	report      *analytics.StreakReport
+	viewport    viewport.Model
+	ready       bool
+	celebration *ConfettiModel // Shown first when records were broken
+}
+
+// NewStreaksModel creates a new streaks model
+func NewStreaksModel(report *analytics.StreakReport) StreaksModel {
+	return StreaksModel{
+		report: report,
+	}
+}
+
+// WithRecords celebrates broken streak records (see LoadStreaks) with
+// confetti before showing the streaks
+func (m StreaksModel) WithRecords(records []string) StreaksModel {
+	if len(records) > 0 {
+		celebration := NewMilestoneConfettiModel(records)
+		m.celebration = &celebration
+	}
+	return m
+}
+
+// allTime is the start date for queries over the whole log
+const allTime = "0001-01-01"
+
+// LoadStreaks computes streaks over the whole log and checks them against the
+// stored personal bests, returning a message for each record beaten at a
+// milestone. The bests are then updated; the first run only records them.
+func LoadStreaks(store *database.Store, rules analytics.StreakRules, now time.Time) (*analytics.StreakReport, []string, error) {
+	today := now.Format("2006-01-02")
+	days, err := store.GetDaysInRange(allTime, today)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries, err := store.GetEntriesForDateRange(allTime, today)
+	if err != nil {
+		return nil, nil, err
+	}
+	report := analytics.CalculateStreaks(days, entries, now, rules)
+
+	previous, found, err := loadStreakRecords(store)
+	if err != nil {
+		return nil, nil, err
+	}
+	var broken []string
+	if found {
+		broken = report.BrokenRecords(previous)
+	}
+
+	current := report.Records()
+	best := analytics.StreakRecords{
+		Logging:   max(previous.Logging, current.Logging),
+		Signoff:   max(previous.Signoff, current.Signoff),
+		Intention: max(previous.Intention, current.Intention),
+	}
+	if !found || best != previous {
+		if err := saveStreakRecords(store, best); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return report, broken, nil
+}
+
+// loadStreakRecords reads the stored personal bests; found is false until
+// they have been saved once
+func loadStreakRecords(store *database.Store) (records analytics.StreakRecords, found bool, err error) {
+	for _, record := range []struct {
+		key   string
+		value *int
+	}{
+		{analytics.ConfigStreakBestLogging, &records.Logging},
+		{analytics.ConfigStreakBestSignoff, &records.Signoff},
+		{analytics.ConfigStreakBestIntention, &records.Intention},
+	} {
+		value, ok, err := store.GetConfig(record.key)
+		if err != nil {
+			return records, false, err
+		}
+		if !ok {
+			continue
+		}
+		found = true
+		if *record.value, err = strconv.Atoi(value); err != nil {
+			return records, false, fmt.Errorf("invalid integer for config %q: %w", record.key, err)
+		}
+	}
+	return records, found, nil
+}
+
+// saveStreakRecords stores the personal bests
+func saveStreakRecords(store *database.Store, records analytics.StreakRecords) error {
+	for key, value := range map[string]int{
+		analytics.ConfigStreakBestLogging:   records.Logging,
+		analytics.ConfigStreakBestSignoff:   records.Signoff,
+		analytics.ConfigStreakBestIntention: records.Intention,
+	} {
+		if err := store.SetConfig(key, strconv.Itoa(value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Init starts the celebration, if any
+func (m StreaksModel) Init() tea.Cmd {
+	if m.celebration != nil {
+		return m.celebration.Init()
+	}
+	return nil
+}
+
+// Update handles messages
+func (m StreaksModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	// Confetti first: any key or the end of the animation moves on to the streaks
+	if m.celebration != nil {
+		switch msg.(type) {
+		case tea.KeyMsg:
+			m.celebration = nil
+			return m, nil
+		case tickMsg:
+			m.celebration.frame++
+			if m.celebration.frame >= m.celebration.totalFrames {
+				m.celebration = nil
+				return m, nil
+			}
+			return m, tick()
+		}
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		}
+	case tea.WindowSizeMsg:
+		if !m.ready {
+			// Initialize viewport on first window size message
+			m.viewport = viewport.New(msg.Width, msg.Height-2)
+			m.viewport.SetContent(m.generateContent())
+			m.ready = true
+		} else {
+			m.viewport.Width = msg.Width
+			m.viewport.Height = msg.Height - 2
+		}
+	}
+
+	// Update viewport (handles scrolling)
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// generateContent generates the streaks content
+func (m StreaksModel) generateContent() string {
+	var b strings.Builder
+
+	b.WriteString(RenderHeaderBar("STREAKS", time.Now().Format("Monday, January 2, 2006")))
+	b.WriteString("\n\n")
+
+	if m.report.Logging.Longest == 0 {
+		b.WriteString(DimStyle.Render("No logs yet. Your streak starts with your first entry!"))
+		b.WriteString("\n\n")
+		b.WriteString(DimStyle.Render("Type: log"))
+		return b.String()
+	}
+
+	b.WriteString(report.Streaks(m.report))
+
+	return b.String()
+}
+
+// View renders the UI
+func (m StreaksModel) View() string {
+	if m.celebration != nil {
+		return m.celebration.View()
+	}
+	if !m.ready {
+		return "Loading..."
+	}
+
+	viewContent := m.viewport.View() + "\n"
+	viewContent += DimStyle.Render("↑/↓ or j/k to scroll • q/esc to exit")
+	return viewContent
+}
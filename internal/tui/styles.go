@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/aaryareddy/log_cli/internal/output"
+	"github.com/aaryareddy/log_cli/internal/theme"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -12,120 +14,77 @@ import (
 // Precision with warmth - Clean, colorful, and functional
 // ━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━
 
-// Color Palette (Vibrant)
+// Styles by role, set from the current theme (see internal/theme)
 var (
-	colorPrimary  = lipgloss.Color("#FF79C6") // Magenta for headers
-	colorAccent   = lipgloss.Color("#8BE9FD") // Cyan for interactive
-	colorSuccess  = lipgloss.Color("#50FA7B") // Green for wins/positive
-	colorWarning  = lipgloss.Color("#FFB86C") // Orange for alerts
-	colorError    = lipgloss.Color("#FF5555") // Red for errors
-	colorMetadata = lipgloss.Color("#BD93F9") // Purple for labels
-	colorDim      = lipgloss.Color("#6272A4") // Gray for helper text
-	colorBorder   = lipgloss.Color("#44475A") // Subtle border
-	colorText     = lipgloss.Color("#D0D0D0") // Softer off-white text (less harsh)
-)
+	HeaderStyle    lipgloss.Style // Main titles
+	SubheaderStyle lipgloss.Style // Sections
+	BoldStyle      lipgloss.Style // Emphasis
+	BodyStyle      lipgloss.Style // Regular body text
+	DimStyle       lipgloss.Style // Helper/meta info
+	AccentStyle    lipgloss.Style // Interactive elements
+	SuccessStyle   lipgloss.Style // Wins/positive
+	WarningStyle   lipgloss.Style // Alerts/drift
+	ErrorStyle     lipgloss.Style // Problems
+	MetadataStyle  lipgloss.Style // Labels
+	PromptStyle    lipgloss.Style // Input prompts
+	AlertStyle     lipgloss.Style // Alias for warning
+	SelectedStyle  lipgloss.Style // List selections
+
+	// Pattern-specific styles (for weekly review)
+	FlowStyle  lipgloss.Style
+	GoldStyle  lipgloss.Style
+	StuckStyle lipgloss.Style
+	LeakStyle  lipgloss.Style
 
-// Typography Styles
-var (
-	// Header for main titles
-	HeaderStyle = lipgloss.NewStyle().
-			Foreground(colorPrimary).
-			Bold(true)
-
-	// Subheader for sections
-	SubheaderStyle = lipgloss.NewStyle().
-			Foreground(colorMetadata).
-			Bold(true)
-
-	// Bold text for emphasis
-	BoldStyle = lipgloss.NewStyle().
-			Foreground(colorText).
-			Bold(true)
-
-	// Regular body text
-	BodyStyle = lipgloss.NewStyle().
-			Foreground(colorText)
-
-	// Dim text for helper/meta info
-	DimStyle = lipgloss.NewStyle().
-			Foreground(colorDim)
-
-	// Accent for interactive elements
-	AccentStyle = lipgloss.NewStyle().
-			Foreground(colorAccent).
-			Bold(true)
-
-	// Success for wins/positive
-	SuccessStyle = lipgloss.NewStyle().
-			Foreground(colorSuccess).
-			Bold(true)
-
-	// Warning for alerts/drift
-	WarningStyle = lipgloss.NewStyle().
-			Foreground(colorWarning).
-			Bold(true)
-
-	// Error for problems
-	ErrorStyle = lipgloss.NewStyle().
-			Foreground(colorError).
-			Bold(true)
-
-	// Metadata labels (purple, subtle)
-	MetadataStyle = lipgloss.NewStyle().
-			Foreground(colorMetadata)
-
-	// Prompt style (cyan, friendly)
-	PromptStyle = lipgloss.NewStyle().
-			Foreground(colorAccent)
-
-	// Alert style (alias for warning)
-	AlertStyle = WarningStyle
-
-	// Selected item style (cyan, bold for list selections)
-	SelectedStyle = lipgloss.NewStyle().
-			Foreground(colorAccent).
-			Bold(true)
-
-	// Pattern-specific color styles (for weekly review)
-	FlowStyle  = SuccessStyle // Green for [FLOW] patterns
-	GoldStyle  = AccentStyle  // Cyan for [GOLD] patterns
-	StuckStyle = WarningStyle // Orange for [STUCK] patterns
-	LeakStyle  = ErrorStyle   // Red for [LEAK] patterns
-)
-
-// Box Styles
-var (
 	// Main container with borders (no fixed width - adapts to terminal)
-	BoxStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(colorBorder).
-			Padding(2, 3)
-
-	// Input field style (neutral)
-	InputStyle = lipgloss.NewStyle().
-			Foreground(colorText)
-
-	// Focused input highlight (cyan glow)
-	InputFocusedStyle = lipgloss.NewStyle().
-				Foreground(colorAccent)
+	BoxStyle lipgloss.Style
+	// Bordered inset without padding, e.g. an entry preview
+	PanelStyle        lipgloss.Style
+	InputStyle        lipgloss.Style
+	InputFocusedStyle lipgloss.Style
+
+	// report renders analytics results in the same theme
+	report *output.Renderer
 )
 
+func init() {
+	theme.Register(applyTheme)
+}
+
+// applyTheme points the style variables at a theme's styles
+func applyTheme(t *theme.Theme) {
+	s := t.Styles
+	HeaderStyle = s.Header
+	SubheaderStyle = s.Subheader
+	BoldStyle = s.Bold
+	BodyStyle = s.Body
+	DimStyle = s.Dim
+	AccentStyle = s.Accent
+	SuccessStyle = s.Success
+	WarningStyle = s.Warning
+	ErrorStyle = s.Error
+	MetadataStyle = s.Metadata
+	PromptStyle = s.Prompt
+	AlertStyle = s.Warning
+	SelectedStyle = s.Selected
+
+	FlowStyle = s.Flow
+	GoldStyle = s.Gold
+	StuckStyle = s.Stuck
+	LeakStyle = s.Leak
+
+	BoxStyle = s.Box
+	PanelStyle = s.Panel
+	InputStyle = s.Input
+	InputFocusedStyle = s.InputFocused
+
+	report = output.ForTheme(t)
+}
+
 // Helper Functions
 // RenderHeaderBar creates a styled header bar with title and date
 func RenderHeaderBar(title, date string) string {
-	// Format: ┌─ TITLE ──────────────── DATE ─┐
-	titlePart := "─ " + title + " "
-	datePart := " " + date + " ─"
-
-	// Calculate dashes needed to fill width
-	totalLen := len(titlePart) + len(datePart) + 2 // +2 for corners
-	dashesNeeded := 80 - totalLen
-	if dashesNeeded < 0 {
-		dashesNeeded = 0
-	}
-
-	dashes := strings.Repeat("─", dashesNeeded)
-	return HeaderStyle.Render("┌" + titlePart + dashes + datePart + "┐")
+	return report.HeaderBar(title, date)
 }
 
 // RenderDivider creates a horizontal divider line
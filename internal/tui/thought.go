@@ -4,6 +4,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/aaryareddy/log_cli/internal/parser"
 	"github.com/charmbracelet/bubbles/textarea"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -17,6 +18,7 @@ type ThoughtModel struct {
 	height      int
 	submitted   bool
 	thoughtText string
+	private     bool // Kept out of markdown and exports
 }
 
 // NewThoughtModel creates a new thought model
@@ -56,6 +58,10 @@ func (m ThoughtModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, tea.Quit
 			}
 			return m, nil
+		case tea.KeyCtrlP:
+			// Ctrl+P toggles private (↑ still moves between lines)
+			m.private = !m.private
+			return m, nil
 		case tea.KeyCtrlC, tea.KeyEsc:
 			return m, tea.Quit
 		}
@@ -88,6 +94,9 @@ func (m ThoughtModel) View() string {
 	b.WriteString(HeaderStyle.Render("💭 THOUGHT"))
 	b.WriteString("\n")
 	b.WriteString(DimStyle.Render(m.timestamp.Format("3:04pm")))
+	if m.private {
+		b.WriteString(DimStyle.Render(" · private"))
+	}
 	b.WriteString("\n\n")
 
 	// Expansive text area
@@ -97,7 +106,7 @@ func (m ThoughtModel) View() string {
 	// Simplified helper text
 	b.WriteString(DimStyle.Render("No tags or momentum needed - just your thought"))
 	b.WriteString("\n\n")
-	b.WriteString(DimStyle.Render("Ctrl+D to save • Ctrl+C to cancel"))
+	b.WriteString(DimStyle.Render("Ctrl+D to save • Ctrl+P to toggle private • Ctrl+C to cancel"))
 
 	// Use minimal, open box style - no visible border, generous padding
 	openBoxStyle := lipgloss.NewStyle().
@@ -107,9 +116,14 @@ func (m ThoughtModel) View() string {
 	return openBoxStyle.Render(b.String())
 }
 
-// GetThought returns the thought text
+// GetThought returns the thought text (without a typed !private marker)
 func (m ThoughtModel) GetThought() string {
-	return m.thoughtText
+	return parser.StripPrivate(m.thoughtText)
+}
+
+// IsPrivate returns whether the thought was marked private, with Ctrl+P or !private
+func (m ThoughtModel) IsPrivate() bool {
+	return m.private || parser.IsPrivate(m.thoughtText)
 }
 
 // WasSubmitted returns whether the thought was submitted
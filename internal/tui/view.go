@@ -4,19 +4,26 @@ import (
 	"strings"
 	"time"
 
+	"github.com/aaryareddy/log_cli/internal/analytics"
 	"github.com/aaryareddy/log_cli/internal/database"
+	"github.com/aaryareddy/log_cli/internal/output"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 // ViewModel is the model for viewing today's log entries
 type ViewModel struct {
-	day              *database.Day
-	entries          []*database.Entry
-	viewport         viewport.Model
-	ready            bool
-	textExpanded     bool // Toggle for rolling/unrolling long text
-	maxCollapsedLen  int  // Maximum characters before text is collapsed
+	day             *database.Day
+	entries         []*database.Entry
+	viewport        viewport.Model
+	ready           bool
+	textExpanded    bool                              // Toggle for rolling/unrolling long text
+	maxCollapsedLen int                               // Maximum characters before text is collapsed
+	revealPrivate   bool                              // Show private entries' text instead of blurring it
+	gaps            map[*database.Entry]analytics.Gap // Gap following each entry, if any
+	away            []database.AwayInterval           // Kept to recompute gaps after edits
+	rules           analytics.DriftRules
+	edit            viewEditState // Cursor and inline actions (see WithEditor)
 }
 
 // NewViewModel creates a new view model
@@ -26,9 +33,35 @@ func NewViewModel(day *database.Day, entries []*database.Entry) ViewModel {
 		entries:         entries,
 		textExpanded:    false, // Start with text collapsed
 		maxCollapsedLen: 100,   // Collapse text longer than 100 chars
+		gaps:            gapsByEntry(analytics.DetectGaps(entries, nil, analytics.DefaultDriftRules())),
+		rules:           analytics.DefaultDriftRules(),
 	}
 }
 
+// WithAwayIntervals reclassifies gap markers using acknowledged "stepped away" intervals
+func (m ViewModel) WithAwayIntervals(away []database.AwayInterval, rules analytics.DriftRules) ViewModel {
+	m.gaps = gapsByEntry(analytics.DetectGaps(m.entries, away, rules))
+	m.away, m.rules = away, rules
+	return m
+}
+
+// WithMaxCollapsedLen sets how many characters are shown before long entries collapse
+func (m ViewModel) WithMaxCollapsedLen(n int) ViewModel {
+	if n > 0 {
+		m.maxCollapsedLen = n
+	}
+	return m
+}
+
+// gapsByEntry indexes gaps by the entry that opened them
+func gapsByEntry(gaps []analytics.Gap) map[*database.Entry]analytics.Gap {
+	byEntry := make(map[*database.Entry]analytics.Gap, len(gaps))
+	for _, gap := range gaps {
+		byEntry[gap.Before] = gap
+	}
+	return byEntry
+}
+
 // Init initializes the model
 func (m ViewModel) Init() tea.Cmd {
 	return nil
@@ -40,6 +73,14 @@ func (m ViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.edit.editor != nil {
+			updated, cmd, handled := m.updateEditor(msg)
+			if handled {
+				return updated, cmd
+			}
+			m = updated
+		}
+
 		switch msg.String() {
 		case "q", "esc", "ctrl+c":
 			return m, tea.Quit
@@ -47,15 +88,24 @@ func (m ViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Toggle text expansion
 			m.textExpanded = !m.textExpanded
 			// Regenerate content with new expansion state
-			m.viewport.SetContent(m.generateViewContent())
+			m.viewport.SetContent(m.generateViewContent(report))
+			return m, nil
+		case "p":
+			// Toggle private entries between blurred and revealed
+			m.revealPrivate = !m.revealPrivate
+			m.viewport.SetContent(m.generateViewContent(report))
 			return m, nil
 		}
 	case tea.WindowSizeMsg:
+		m.edit.width, m.edit.height = msg.Width, msg.Height
 		if !m.ready {
 			// Initialize viewport on first window size message
 			m.viewport = viewport.New(msg.Width, msg.Height-2)
-			m.viewport.SetContent(m.generateViewContent())
+			m.viewport.SetContent(m.generateViewContent(report))
 			m.ready = true
+			if m.edit.editor != nil {
+				m = m.refreshContent()
+			}
 		} else {
 			m.viewport.Width = msg.Width
 			m.viewport.Height = msg.Height - 2
@@ -68,53 +118,54 @@ func (m ViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 // generateViewContent generates the log view content
-func (m ViewModel) generateViewContent() string {
+func (m ViewModel) generateViewContent(r *output.Renderer) string {
 	var b strings.Builder
+	s := r.Styles()
 
 	// Header with date
 	dateStr := m.day.Date.Format("Monday, January 2, 2006")
-	b.WriteString(RenderHeaderBar("DAYLOG", dateStr))
+	b.WriteString(r.HeaderBar("DAYLOG", dateStr))
 	b.WriteString("\n\n")
 
 	// Show intention if set
 	if m.day.Intention != nil && *m.day.Intention != "" {
-		b.WriteString(BoldStyle.Render("Intention: "))
+		b.WriteString(s.Bold.Render("Intention: "))
 		b.WriteString(*m.day.Intention)
 		b.WriteString("\n\n")
 	}
 
 	// Show entries
 	if len(m.entries) == 0 {
-		b.WriteString(DimStyle.Render("No logs yet today. Start logging to build your daylog!"))
+		b.WriteString(s.Dim.Render("No logs yet today. Start logging to build your daylog!"))
 		b.WriteString("\n\n")
-		b.WriteString(DimStyle.Render("Type: log"))
+		b.WriteString(s.Dim.Render("Type: log"))
 	} else {
 		// Split entries if day is completed
 		regularEntries, afterHoursEntries := splitEntries(m.entries, m.day.Completed)
 
 		// Display regular entries (wins now appear inline with timestamps)
-		b.WriteString(m.formatEntries(regularEntries))
+		b.WriteString(m.formatEntries(r, regularEntries))
 
 		// Show reflections if day is completed
 		if m.day.Completed {
 			b.WriteString("\n\n")
-			b.WriteString(DimStyle.Render("─────────────────────────────────────"))
+			b.WriteString(s.Dim.Render("─────────────────────────────────────"))
 			b.WriteString("\n\n")
 
 			if m.day.PulledOffTrack != nil && *m.day.PulledOffTrack != "" {
-				b.WriteString(DimStyle.Render("Pulled off track: "))
+				b.WriteString(s.Dim.Render("Pulled off track: "))
 				b.WriteString(*m.day.PulledOffTrack)
 				b.WriteString("\n")
 			}
 
 			if m.day.KeptOnTrack != nil && *m.day.KeptOnTrack != "" {
-				b.WriteString(DimStyle.Render("Kept on track: "))
+				b.WriteString(s.Dim.Render("Kept on track: "))
 				b.WriteString(*m.day.KeptOnTrack)
 				b.WriteString("\n")
 			}
 
 			if m.day.TomorrowProtect != nil && *m.day.TomorrowProtect != "" {
-				b.WriteString(DimStyle.Render("Tomorrow protect: "))
+				b.WriteString(s.Dim.Render("Tomorrow protect: "))
 				b.WriteString(*m.day.TomorrowProtect)
 			}
 		}
@@ -122,27 +173,45 @@ func (m ViewModel) generateViewContent() string {
 		// Show after-hours section if any entries
 		if len(afterHoursEntries) > 0 {
 			b.WriteString("\n\n")
-			b.WriteString(DimStyle.Render("═════════════════════════════════════"))
+			b.WriteString(s.Dim.Render("═════════════════════════════════════"))
 			b.WriteString("\n")
-			b.WriteString(BoldStyle.Render("After-Hours"))
+			b.WriteString(s.Bold.Render("After-Hours"))
 			b.WriteString("\n")
-			b.WriteString(DimStyle.Render("═════════════════════════════════════"))
+			b.WriteString(s.Dim.Render("═════════════════════════════════════"))
 			b.WriteString("\n\n")
-			b.WriteString(m.formatEntries(afterHoursEntries))
+			b.WriteString(m.formatEntries(r, afterHoursEntries))
 		}
 	}
 
 	return b.String()
 }
 
+// Render returns the day view content without the interactive viewport,
+// styled for the format (--plain output has no ANSI styling)
+func (m ViewModel) Render(format output.Format) string {
+	return m.generateViewContent(output.NewRenderer(format))
+}
+
 // View renders the UI
 func (m ViewModel) View() string {
 	if !m.ready {
 		return "Loading..."
 	}
 
+	if m.edit.mode == editDelete {
+		return m.edit.confirm.View()
+	}
+	if m.edit.editor != nil {
+		return m.viewport.View() + "\n" + m.editFooter()
+	}
+
+	help := "↑/↓ or j/k to scroll • Shift+R to toggle text • q/esc to exit"
+	if hasPrivate(m.entries) {
+		help = "↑/↓ or j/k to scroll • Shift+R to toggle text • p to reveal private • q/esc to exit"
+	}
+
 	viewContent := m.viewport.View() + "\n"
-	viewContent += DimStyle.Render("↑/↓ or j/k to scroll • Shift+R to toggle text • q/esc to exit")
+	viewContent += DimStyle.Render(help)
 	return viewContent
 }
 
@@ -185,24 +254,41 @@ func splitEntries(entries []*database.Entry, dayCompleted bool) ([]*database.Ent
 }
 
 // formatEntries formats the list of entries for display
-func (m ViewModel) formatEntries(entries []*database.Entry) string {
+func (m ViewModel) formatEntries(r *output.Renderer, entries []*database.Entry) string {
 	var b strings.Builder
+	s := r.Styles()
 
 	for i, entry := range entries {
 		if i > 0 {
 			b.WriteString("\n")
 		}
 
+		// Cursor gutter while editing
+		selected := m.edit.editor != nil && entry == m.selectedEntry()
+		if m.edit.editor != nil {
+			if selected {
+				b.WriteString(cursorGutter)
+			} else {
+				b.WriteString("  ")
+			}
+		}
+
 		// Time
 		timeStr := entry.Timestamp.Format("3:04pm")
-		b.WriteString(DimStyle.Render(timeStr))
+		if selected {
+			b.WriteString(s.Selected.Render(timeStr))
+		} else {
+			b.WriteString(s.Dim.Render(timeStr))
+		}
 		b.WriteString(" | ")
 
-		// Entry text - truncate if collapsed and text is long
+		// Entry text - blurred if private, truncated if collapsed and long
 		entryText := entry.EntryText
-		if !m.textExpanded && len(entryText) > m.maxCollapsedLen {
+		if entry.Private && !m.revealPrivate {
+			entryText = s.Dim.Render(blurredText)
+		} else if !m.textExpanded && len(entryText) > m.maxCollapsedLen {
 			// Truncate and add indicator
-			entryText = entryText[:m.maxCollapsedLen] + DimStyle.Render("... [Shift+R to expand]")
+			entryText = entryText[:m.maxCollapsedLen] + s.Dim.Render("... [Shift+R to expand]")
 		}
 		b.WriteString(entryText)
 
@@ -215,13 +301,32 @@ func (m ViewModel) formatEntries(entries []*database.Entry) string {
 		// Tags
 		if len(entry.Tags) > 0 {
 			b.WriteString(" ")
-			b.WriteString(formatTags(entry.Tags))
+			b.WriteString(s.Dim.Render(formatTags(entry.Tags)))
+		}
+
+		// Gap marker before the next entry (drift, stepped away, long break)
+		if gap, ok := m.gaps[entry]; ok && i < len(entries)-1 && gap.Kind != analytics.GapAfterHours {
+			b.WriteString("\n")
+			b.WriteString(r.GapMarker(gap))
 		}
 	}
 
 	return b.String()
 }
 
+// blurredText stands in for a private entry's text until it is revealed
+const blurredText = "░░░░░░░░░░ private"
+
+// hasPrivate reports whether any entry is private
+func hasPrivate(entries []*database.Entry) bool {
+	for _, entry := range entries {
+		if entry.Private {
+			return true
+		}
+	}
+	return false
+}
+
 // formatMomentum returns the visual representation of momentum
 func formatMomentum(momentum string) string {
 	switch momentum {
@@ -238,11 +343,11 @@ func formatMomentum(momentum string) string {
 	}
 }
 
-// formatTags formats tags for display
+// formatTags joins tag values for display
 func formatTags(tags []database.Tag) string {
 	var parts []string
 	for _, tag := range tags {
 		parts = append(parts, tag.TagValue)
 	}
-	return DimStyle.Render(strings.Join(parts, " "))
+	return strings.Join(parts, " ")
 }
@@ -0,0 +1,470 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aaryareddy/log_cli/internal/analytics"
+	"github.com/aaryareddy/log_cli/internal/database"
+	"github.com/aaryareddy/log_cli/internal/parser"
+	"github.com/aaryareddy/log_cli/internal/quicklog"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// cursorGutter marks the selected entry in the day view while editing
+const cursorGutter = "▸ "
+
+// editMode is the inline action in progress in the day view
+type editMode int
+
+const (
+	editNone   editMode = iota // Moving the cursor
+	editText                   // Editing the selected entry's text
+	editTime                   // Retiming the selected entry
+	editAdd                    // Adding a missed entry after the selected one
+	editTags                   // Toggling tags and flags on the selected entry
+	editDelete                 // Confirming deletion of the selected entry
+)
+
+// retimeLayouts are the accepted time formats (after lowercasing and removing spaces)
+var retimeLayouts = []string{"3:04pm", "3pm", "15:04"}
+
+// dayEditor saves day view changes through the logger, so edits refresh the
+// status cache, fire hooks and webhooks and rewrite the day's markdown like
+// any other entry
+type dayEditor struct {
+	store  *database.Store
+	logger *quicklog.Logger
+}
+
+// viewEditState is the day view's cursor and inline action state
+type viewEditState struct {
+	editor    *dayEditor
+	cursor    int // Index into the view's entries
+	mode      editMode
+	input     textinput.Model
+	confirm   ConfirmDeleteModel
+	tagCursor int
+	status    string // Last action's error, until the next key
+	width     int
+	height    int
+}
+
+// WithEditor adds a cursor and inline actions to the view: edit text,
+// momentum keys, tag toggles, retime, delete and adding a missed entry.
+// Changes are saved by logger; the day is re-read from store.
+func (m ViewModel) WithEditor(store *database.Store, logger *quicklog.Logger) ViewModel {
+	m.edit.editor = &dayEditor{store: store, logger: logger}
+	m.edit.cursor = len(m.entries) - 1
+	if m.edit.cursor < 0 {
+		m.edit.cursor = 0
+	}
+	return m
+}
+
+// selectedEntry returns the entry under the cursor (nil when the day is empty)
+func (m ViewModel) selectedEntry() *database.Entry {
+	if m.edit.cursor < 0 || m.edit.cursor >= len(m.entries) {
+		return nil
+	}
+	return m.entries[m.edit.cursor]
+}
+
+// updateEditor handles keys for the cursor and inline actions. It reports
+// false for keys the read-only view should handle.
+func (m ViewModel) updateEditor(msg tea.KeyMsg) (ViewModel, tea.Cmd, bool) {
+	m.edit.status = ""
+
+	switch m.edit.mode {
+	case editText, editTime, editAdd:
+		switch msg.Type {
+		case tea.KeyEsc:
+			m.edit.mode = editNone
+			return m, nil, true
+		case tea.KeyEnter:
+			m, cmd := m.submitInput()
+			return m, cmd, true
+		case tea.KeyCtrlC:
+			return m, tea.Quit, true
+		}
+
+		var cmd tea.Cmd
+		previousValue := m.edit.input.Value()
+		m.edit.input, cmd = m.edit.input.Update(msg)
+		if m.edit.mode != editTime && m.edit.input.Value() != previousValue {
+			convertInputMarkers(&m.edit.input)
+		}
+		return m, cmd, true
+
+	case editTags:
+		choices := tagChoices()
+		switch msg.String() {
+		case "left", "h":
+			m.edit.tagCursor = (m.edit.tagCursor + len(choices) - 1) % len(choices)
+		case "right", "l":
+			m.edit.tagCursor = (m.edit.tagCursor + 1) % len(choices)
+		case " ", "x":
+			return m.toggleTag(choices[m.edit.tagCursor])
+		case "enter", "esc", "t":
+			m.edit.mode = editNone
+		case "ctrl+c":
+			return m, tea.Quit, true
+		}
+		return m, nil, true
+
+	case editDelete:
+		// The confirmation quits its own program; here it only sets its result
+		updated, _ := m.edit.confirm.Update(msg)
+		m.edit.confirm = updated.(ConfirmDeleteModel)
+		switch {
+		case m.edit.confirm.WasConfirmed():
+			m.edit.mode = editNone
+			return m.deleteSelected()
+		case m.edit.confirm.WasCancelled():
+			m.edit.mode = editNone
+		}
+		return m, nil, true
+	}
+
+	entry := m.selectedEntry()
+	switch msg.String() {
+	case "up", "k":
+		if m.edit.cursor > 0 {
+			m.edit.cursor--
+			m = m.refreshContent()
+		}
+		return m, nil, true
+	case "down", "j":
+		if m.edit.cursor < len(m.entries)-1 {
+			m.edit.cursor++
+			m = m.refreshContent()
+		}
+		return m, nil, true
+	case "a":
+		if m.day.ID == 0 {
+			m.edit.status = "No log for this day yet: add entries with `log`"
+			return m, nil, true
+		}
+		m.edit.mode = editAdd
+		return m, m.startInput("add › ", m.missedEntryTime().Format("3:04pm")+" | "), true
+	}
+
+	if entry == nil {
+		return m, nil, false
+	}
+
+	switch msg.String() {
+	case "e", "enter":
+		text := parser.ReconstructEntryText(entry.EntryText, entry.Momentum, entry.Tags)
+		if entry.Private {
+			text += " " + parser.PrivateMarker
+		}
+		m.edit.mode = editText
+		return m, m.startInput("edit › ", text), true
+	case "r":
+		m.edit.mode = editTime
+		return m, m.startInput("time › ", entry.Timestamp.Format("3:04pm")), true
+	case "t":
+		m.edit.mode = editTags
+		return m, nil, true
+	case "d":
+		text := entry.EntryText
+		if entry.Private && !m.revealPrivate {
+			text = blurredText
+		}
+		m.edit.confirm = NewConfirmDeleteModel(m.edit.cursor+1, entry.Timestamp, text)
+		m.edit.confirm = sized(m.edit.confirm, tea.WindowSizeMsg{Width: m.edit.width, Height: m.edit.height})
+		m.edit.mode = editDelete
+		return m, nil, true
+	case "+":
+		return m.setMomentum(database.MomentumUp)
+	case "-":
+		return m.setMomentum(database.MomentumDown)
+	case "=":
+		return m.setMomentum(database.MomentumNeutral)
+	case "<":
+		return m.setMomentum(database.MomentumBack)
+	}
+
+	return m, nil, false
+}
+
+// startInput opens the inline input with a prompt and pre-filled value
+func (m *ViewModel) startInput(prompt, value string) tea.Cmd {
+	ti := textinput.New()
+	ti.Prompt = prompt
+	ti.PromptStyle = PromptStyle
+	ti.Width = max(m.edit.width-len(prompt)-2, 20)
+	ti.SetValue(value)
+	ti.CursorEnd()
+	m.edit.input = ti
+	return m.edit.input.Focus()
+}
+
+// submitInput saves the inline input for the current mode
+func (m ViewModel) submitInput() (ViewModel, tea.Cmd) {
+	value := strings.TrimSpace(m.edit.input.Value())
+	entry := m.selectedEntry()
+
+	switch m.edit.mode {
+	case editText:
+		cleanText, momentum, tags := parser.ParseEntry(value)
+		if cleanText == "" && len(tags) == 0 {
+			m.edit.status = quicklog.ErrEmptyEntry.Error()
+			return m, nil
+		}
+		updated := *entry
+		updated.EntryText, updated.Momentum, updated.Tags = cleanText, momentum, tags
+		updated.Private = parser.IsPrivate(value)
+		m.edit.mode = editNone
+		m, cmd, _ := m.save(&updated)
+		return m, cmd
+
+	case editTime:
+		timestamp, err := m.parseTime(value)
+		if err != nil {
+			m.edit.status = err.Error()
+			return m, nil
+		}
+		updated := *entry
+		updated.Timestamp = timestamp
+		m.edit.mode = editNone
+		m, cmd, _ := m.save(&updated)
+		return m, cmd
+
+	case editAdd:
+		timestamp := m.missedEntryTime()
+		if when, text, found := strings.Cut(value, "|"); found {
+			parsed, err := m.parseTime(when)
+			if err != nil {
+				m.edit.status = err.Error()
+				return m, nil
+			}
+			timestamp, value = parsed, strings.TrimSpace(text)
+		}
+
+		cleanText, momentum, tags := parser.ParseEntry(value)
+		if cleanText == "" && len(tags) == 0 {
+			m.edit.status = quicklog.ErrEmptyEntry.Error()
+			return m, nil
+		}
+		added := &database.Entry{
+			DayID:     m.day.ID,
+			Timestamp: timestamp,
+			EntryText: cleanText,
+			Momentum:  momentum,
+			Private:   parser.IsPrivate(value),
+			Tags:      tags,
+		}
+		err := m.edit.editor.logger.Insert(m.day, added)
+		if added.ID == 0 {
+			m.edit.status = err.Error()
+			return m, nil
+		}
+		m.edit.mode = editNone
+		return m.reload(added.ID, err), nil
+	}
+
+	return m, nil
+}
+
+// parseTime reads a time of day on the viewed day. Entries can't be moved into the future.
+func (m ViewModel) parseTime(value string) (time.Time, error) {
+	value = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(value), " ", ""))
+	for _, layout := range retimeLayouts {
+		t, err := time.ParseInLocation(layout, value, time.Local)
+		if err != nil {
+			continue
+		}
+		d := m.day.Date
+		timestamp := time.Date(d.Year(), d.Month(), d.Day(), t.Hour(), t.Minute(), 0, 0, time.Local)
+		if timestamp.After(time.Now()) {
+			return time.Time{}, fmt.Errorf("%s is in the future", timestamp.Format("3:04pm"))
+		}
+		return timestamp, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time: %q (use 2:15pm or 14:15)", value)
+}
+
+// missedEntryTime suggests a time for an entry added after the selected one:
+// halfway to the next entry, or a minute later (never in the future)
+func (m ViewModel) missedEntryTime() time.Time {
+	entry := m.selectedEntry()
+	if entry == nil {
+		d := m.day.Date
+		noon := time.Date(d.Year(), d.Month(), d.Day(), 12, 0, 0, 0, time.Local)
+		if noon.After(time.Now()) {
+			return time.Now().Truncate(time.Minute)
+		}
+		return noon
+	}
+
+	if m.edit.cursor+1 < len(m.entries) {
+		next := m.entries[m.edit.cursor+1]
+		return entry.Timestamp.Add(next.Timestamp.Sub(entry.Timestamp) / 2).Truncate(time.Minute)
+	}
+	suggested := entry.Timestamp.Add(time.Minute).Truncate(time.Minute)
+	if suggested.After(time.Now()) {
+		return entry.Timestamp
+	}
+	return suggested
+}
+
+// setMomentum sets the selected entry's momentum, or clears it when it already has it
+func (m ViewModel) setMomentum(momentum database.Momentum) (ViewModel, tea.Cmd, bool) {
+	updated := *m.selectedEntry()
+	if updated.Momentum != nil && *updated.Momentum == string(momentum) {
+		updated.Momentum = nil
+	} else {
+		value := string(momentum)
+		updated.Momentum = &value
+	}
+	return m.save(&updated)
+}
+
+// tagChoices are the tags and flags offered by the tag picker
+func tagChoices() []database.Tag {
+	var choices []database.Tag
+	for _, tag := range parser.ContextTags() {
+		// Signing off runs the sign-off ritual, so it isn't toggled here
+		if tag != string(database.TagSignoff) {
+			choices = append(choices, database.Tag{TagType: "context", TagValue: tag})
+		}
+	}
+	for _, flag := range []database.FlagTag{database.FlagLeak, database.FlagFlow, database.FlagStuck, database.FlagGold} {
+		choices = append(choices, database.Tag{TagType: "flag", TagValue: string(flag)})
+	}
+	return choices
+}
+
+// hasTag reports whether entry carries a tag value
+func hasTag(entry *database.Entry, value string) bool {
+	for _, tag := range entry.Tags {
+		if tag.TagValue == value {
+			return true
+		}
+	}
+	return false
+}
+
+// toggleTag adds or removes a tag on the selected entry
+func (m ViewModel) toggleTag(choice database.Tag) (ViewModel, tea.Cmd, bool) {
+	updated := *m.selectedEntry()
+	updated.Tags = nil
+	for _, tag := range m.selectedEntry().Tags {
+		if tag.TagValue != choice.TagValue {
+			updated.Tags = append(updated.Tags, tag)
+		}
+	}
+	if !hasTag(m.selectedEntry(), choice.TagValue) {
+		updated.Tags = append(updated.Tags, choice)
+	}
+	return m.save(&updated)
+}
+
+// save writes an edited entry and reloads the day
+func (m ViewModel) save(entry *database.Entry) (ViewModel, tea.Cmd, bool) {
+	err := m.edit.editor.logger.Update(m.day, entry)
+	return m.reload(entry.ID, err), nil, true
+}
+
+// deleteSelected deletes the selected entry and reloads the day
+func (m ViewModel) deleteSelected() (ViewModel, tea.Cmd, bool) {
+	if err := m.edit.editor.logger.Delete(m.day, m.selectedEntry().ID); err != nil {
+		return m.reload(m.selectedEntry().ID, err), nil, true
+	}
+	m.edit.cursor--
+	next := 0
+	if entry := m.selectedEntry(); entry != nil {
+		next = entry.ID
+	}
+	return m.reload(next, nil), nil, true
+}
+
+// reload re-reads the day's entries with the cursor on entryID. A failed
+// change (err) is shown in the status line; the day is re-read regardless,
+// since the store may have been written before a later step failed.
+func (m ViewModel) reload(entryID int, err error) ViewModel {
+	if err != nil {
+		m.edit.status = err.Error()
+	}
+	entries, err := m.edit.editor.store.GetTodayEntries(m.day.ID)
+	if err != nil {
+		m.edit.status = err.Error()
+		return m
+	}
+
+	m.entries = entries
+	m.gaps = gapsByEntry(analytics.DetectGaps(entries, m.away, m.rules))
+	m.edit.cursor = max(min(m.edit.cursor, len(entries)-1), 0)
+	for i, entry := range entries {
+		if entry.ID == entryID {
+			m.edit.cursor = i
+		}
+	}
+
+	return m.refreshContent()
+}
+
+// refreshContent re-renders the view and scrolls the cursor into sight
+func (m ViewModel) refreshContent() ViewModel {
+	if !m.ready {
+		return m
+	}
+	content := m.generateViewContent(report)
+	m.viewport.SetContent(content)
+
+	for line, text := range strings.Split(content, "\n") {
+		if !strings.HasPrefix(text, cursorGutter) {
+			continue
+		}
+		if line < m.viewport.YOffset {
+			m.viewport.SetYOffset(line)
+		} else if line >= m.viewport.YOffset+m.viewport.Height {
+			m.viewport.SetYOffset(line - m.viewport.Height + 1)
+		}
+		break
+	}
+	return m
+}
+
+// editFooter renders the line under the viewport while editing
+func (m ViewModel) editFooter() string {
+	switch m.edit.mode {
+	case editText, editTime, editAdd:
+		if m.edit.status != "" {
+			return m.edit.input.View() + "  " + ErrorStyle.Render(m.edit.status)
+		}
+		return m.edit.input.View()
+	case editTags:
+		entry := m.selectedEntry()
+		var parts []string
+		for i, choice := range tagChoices() {
+			label := choice.TagValue
+			if hasTag(entry, choice.TagValue) {
+				label = SuccessStyle.Render("✓" + label)
+			} else {
+				label = DimStyle.Render(" " + label)
+			}
+			if i == m.edit.tagCursor {
+				label = SelectedStyle.Render("›") + label
+			} else {
+				label = " " + label
+			}
+			parts = append(parts, label)
+		}
+		return strings.Join(parts, "") + DimStyle.Render("  ←/→ • Space to toggle • Enter when done")
+	}
+
+	if m.edit.status != "" {
+		return ErrorStyle.Render(m.edit.status)
+	}
+
+	help := "j/k select • e edit • +/-/=/< momentum • t tags • r retime • a add after • d delete • Shift+R text • q/esc to exit"
+	if hasPrivate(m.entries) {
+		help = "j/k select • e edit • +/-/=/< momentum • t tags • r retime • a add after • d delete • p reveal • q/esc to exit"
+	}
+	return DimStyle.Render(help)
+}
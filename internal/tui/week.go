@@ -5,24 +5,41 @@ import (
 	"strings"
 
 	"github.com/aaryareddy/log_cli/internal/analytics"
+	"github.com/aaryareddy/log_cli/internal/output"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 // WeekModel is the model for displaying weekly pattern analysis
 type WeekModel struct {
-	summary  *analytics.WeeklyPatternSummary
-	viewport viewport.Model
-	ready    bool
+	summary   *analytics.WeeklyPatternSummary
+	viewport  viewport.Model
+	ready     bool
+	statsMode analytics.StatsMode // Time-weighted (default) or count-based tag breakdown
+	expanded  bool                // Show the member entries of each theme
 }
 
 // NewWeekModel creates a new week review model
 func NewWeekModel(summary *analytics.WeeklyPatternSummary) WeekModel {
 	return WeekModel{
-		summary: summary,
+		summary:   summary,
+		statsMode: analytics.StatsByTime,
 	}
 }
 
+// WithRedaction hides entry text in the review's patterns, themes and waste
+// as the level requires, for --plain exports
+func (m WeekModel) WithRedaction(level output.Redaction) WeekModel {
+	m.summary = output.RedactSummary(m.summary, level)
+	return m
+}
+
+// WithStatsMode sets the tag breakdown shown first (t toggles it)
+func (m WeekModel) WithStatsMode(mode analytics.StatsMode) WeekModel {
+	m.statsMode = mode
+	return m
+}
+
 // Init initializes the model
 func (m WeekModel) Init() tea.Cmd {
 	return nil
@@ -37,12 +54,30 @@ func (m WeekModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch msg.String() {
 		case "q", "esc", "ctrl+c":
 			return m, tea.Quit
+		case "t":
+			// Toggle between time-weighted and count-based breakdown
+			if m.statsMode == analytics.StatsByTime {
+				m.statsMode = analytics.StatsByCount
+			} else {
+				m.statsMode = analytics.StatsByTime
+			}
+			if m.ready {
+				m.viewport.SetContent(m.generateWeekContent(report))
+			}
+			return m, nil
+		case "e":
+			// Toggle theme member expansion
+			m.expanded = !m.expanded
+			if m.ready {
+				m.viewport.SetContent(m.generateWeekContent(report))
+			}
+			return m, nil
 		}
 	case tea.WindowSizeMsg:
 		if !m.ready {
 			// Initialize viewport on first window size message
 			m.viewport = viewport.New(msg.Width, msg.Height-2)
-			m.viewport.SetContent(m.generateWeekContent())
+			m.viewport.SetContent(m.generateWeekContent(report))
 			m.ready = true
 		} else {
 			m.viewport.Width = msg.Width
@@ -56,66 +91,120 @@ func (m WeekModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 // generateWeekContent generates the weekly review content
-func (m WeekModel) generateWeekContent() string {
+func (m WeekModel) generateWeekContent(r *output.Renderer) string {
 	var b strings.Builder
+	s := r.Styles()
 
 	// Header (keep this as is - top border preserved)
-	b.WriteString(RenderHeaderBar("WEEKLY REVIEW", fmt.Sprintf("%s to %s", m.summary.StartDate, m.summary.EndDate)))
+	title := "WEEKLY REVIEW"
+	period := "this week"
+	switch m.summary.Kind {
+	case analytics.PeriodMonth:
+		title = "MONTHLY REVIEW"
+		period = "this month"
+	case analytics.PeriodRange:
+		title = "REVIEW"
+		period = "in this range"
+	}
+	rangeStr := fmt.Sprintf("%s to %s", m.summary.StartDate, m.summary.EndDate)
+	if m.summary.Label != "" {
+		rangeStr = m.summary.Label + " · " + rangeStr
+	}
+	b.WriteString(r.HeaderBar(title, rangeStr))
 	b.WriteString("\n\n")
 
 	// Summary stats (no emoji, bold white)
-	b.WriteString(BoldStyle.Render(fmt.Sprintf("%d entries logged this week", m.summary.TotalEntries)))
+	b.WriteString(s.Bold.Render(fmt.Sprintf("%d entries logged %s", m.summary.TotalEntries, period)))
+	b.WriteString("\n")
+	b.WriteString(s.Dim.Render(fmt.Sprintf("%d of %d days logged • %.1f/logged day • %.1f/calendar day",
+		m.summary.DaysLogged, m.summary.TotalDays, m.summary.AvgPerLoggedDay(), m.summary.AvgPerCalendarDay())))
 	b.WriteString("\n\n")
 
 	if m.summary.TotalEntries == 0 {
-		b.WriteString(DimStyle.Render("No entries to analyze yet. Start logging to see patterns!"))
+		b.WriteString(s.Dim.Render("No entries to analyze yet. Start logging to see patterns!"))
 		return b.String()
 	}
 
 	// Pattern groups header (purple metadata style with double line)
-	b.WriteString(MetadataStyle.Render("PATTERN ANALYSIS"))
+	b.WriteString(s.Metadata.Render("PATTERN ANALYSIS"))
 	b.WriteString("\n")
-	b.WriteString(DimStyle.Render(strings.Repeat("━", 70)))
+	b.WriteString(s.Dim.Render(strings.Repeat("━", 70)))
 	b.WriteString("\n\n")
 
 	// Display each pattern type
 	patternOrder := []string{"[FLOW]", "[GOLD]", "[STUCK]", "[LEAK]"}
 	for _, flagType := range patternOrder {
 		if entries, exists := m.summary.PatternGroups[flagType]; exists && len(entries) > 0 {
-			formatted := analytics.FormatPatternGroup(flagType, entries)
+			// Prefer clustered themes ("Social media (3x)") when available
+			formatted := r.PatternGroup(flagType, entries)
+			if themes, ok := m.summary.Themes[flagType]; ok && len(themes) > 0 {
+				formatted = r.Themes(flagType, themes, m.expanded)
+			}
 			b.WriteString(formatted)
 			b.WriteString("\n\n")
 		}
 	}
 
+	// Where the time went (time-weighted or count-based)
+	b.WriteString("\n")
+	if m.statsMode == analytics.StatsByTime {
+		b.WriteString(analytics.FormatTimeStats(m.summary.TimeStats))
+	} else {
+		b.WriteString(analytics.FormatTagCounts(m.summary.TagCounts))
+	}
+
 	// Momentum distribution
 	b.WriteString("\n")
-	b.WriteString(analytics.FormatMomentumStats(m.summary.MomentumStats))
+	b.WriteString(r.MomentumStats(m.summary.MomentumStats))
 	b.WriteString("\n")
 
+	// Strongest tag/momentum correlations and sequences
+	b.WriteString("\n")
+	b.WriteString(r.CorrelationFindings(m.summary.Correlations, 3))
+	b.WriteString("\n")
+
+	// Drift vs acknowledged stepped-away time
+	b.WriteString("\n")
+	b.WriteString(r.DriftReport(m.summary.Drift))
+	b.WriteString("\n")
+
+	// Intention follow-through (when day records were loaded)
+	if m.summary.FollowThrough != nil {
+		b.WriteString("\n")
+		b.WriteString(r.FollowThrough(m.summary.FollowThrough))
+		b.WriteString("\n")
+	}
+
+	// Trends vs previous periods
+	for _, cmp := range m.summary.Comparisons {
+		b.WriteString("\n")
+		b.WriteString(r.Comparison(cmp))
+		b.WriteString("\n")
+	}
+
 	// Waste patterns (if any)
 	if len(m.summary.WastePatterns) > 0 {
-		b.WriteString(analytics.FormatWastePatterns(m.summary.WastePatterns))
+		b.WriteString(r.WastePatterns(m.summary.WastePatterns))
 		b.WriteString("\n")
 	}
 
 	// Insights section (cyan accent style with double line)
 	b.WriteString("\n")
-	b.WriteString(AccentStyle.Render("INSIGHTS"))
+	b.WriteString(s.Accent.Render("INSIGHTS"))
 	b.WriteString("\n")
-	b.WriteString(DimStyle.Render(strings.Repeat("━", 70)))
+	b.WriteString(s.Dim.Render(strings.Repeat("━", 70)))
 	b.WriteString("\n\n")
 
 	// Generate insights based on patterns
-	insights := generateInsights(m.summary)
+	insights := analytics.WeekInsights(m.summary)
 	for _, insight := range insights {
-		b.WriteString(DimStyle.Render("  → "))
+		b.WriteString(s.Dim.Render("  → "))
 		b.WriteString(insight)
 		b.WriteString("\n")
 	}
 
 	if len(insights) == 0 {
-		b.WriteString(DimStyle.Render("  Keep logging to generate personalized insights!"))
+		b.WriteString(s.Dim.Render("  Keep logging to generate personalized insights!"))
 		b.WriteString("\n")
 	}
 
@@ -129,54 +218,12 @@ func (m WeekModel) View() string {
 	}
 
 	viewContent := m.viewport.View() + "\n"
-	viewContent += DimStyle.Render("↑/↓ or j/k to scroll • q/esc to exit")
+	viewContent += DimStyle.Render("↑/↓ or j/k to scroll • e to expand themes • t to toggle time/count • q/esc to exit")
 	return viewContent
 }
 
-// generateInsights creates actionable insights from the week's data
-func generateInsights(summary *analytics.WeeklyPatternSummary) []string {
-	var insights []string
-
-	// Momentum insights
-	if summary.MomentumStats.TotalCount > 0 {
-		upPct := float64(summary.MomentumStats.UpCount) / float64(summary.MomentumStats.TotalCount) * 100
-		backPct := float64(summary.MomentumStats.BackCount) / float64(summary.MomentumStats.TotalCount) * 100
-
-		if upPct > 60 {
-			insights = append(insights, "Strong momentum this week! You logged ↑ on over 60% of marked entries.")
-		} else if upPct < 30 {
-			insights = append(insights, "Momentum was lower this week. Consider what conditions help you feel more energized.")
-		}
-
-		if backPct > 10 {
-			insights = append(insights, fmt.Sprintf("%.0f%% of entries were marked as waste (←). Review these patterns to reclaim time.", backPct))
-		}
-	}
-
-	// Pattern insights
-	if len(summary.PatternGroups["[FLOW]"]) > 0 {
-		insights = append(insights, fmt.Sprintf("You hit flow %d times this week. What conditions enabled those states?", len(summary.PatternGroups["[FLOW]"])))
-	}
-
-	if len(summary.PatternGroups["[LEAK]"]) > 0 {
-		insights = append(insights, fmt.Sprintf("Identified %d leak patterns. Common themes in what pulled you off track?", len(summary.PatternGroups["[LEAK]"])))
-	}
-
-	if len(summary.PatternGroups["[STUCK]"]) > 0 {
-		insights = append(insights, fmt.Sprintf("You got stuck %d times. Consider documenting solutions when you break through.", len(summary.PatternGroups["[STUCK]"])))
-	}
-
-	if len(summary.PatternGroups["[GOLD]"]) > 0 {
-		insights = append(insights, fmt.Sprintf("Captured %d gold moments! Celebrate these wins.", len(summary.PatternGroups["[GOLD]"])))
-	}
-
-	// Entry frequency insight
-	avgPerDay := float64(summary.TotalEntries) / 7.0
-	if avgPerDay < 3 {
-		insights = append(insights, "Log frequency is low. More frequent logs = better awareness and pattern detection.")
-	} else if avgPerDay > 15 {
-		insights = append(insights, "High logging frequency! You're building strong awareness habits.")
-	}
-
-	return insights
+// Render returns the review content without the interactive viewport, styled
+// for the format (--plain output has no ANSI styling)
+func (m WeekModel) Render(format output.Format) string {
+	return m.generateWeekContent(output.NewRenderer(format))
 }
@@ -1,17 +1,23 @@
 package tui
 
 import (
+	"fmt"
 	"strings"
 
+	"github.com/aaryareddy/log_cli/internal/database"
+	"github.com/aaryareddy/log_cli/internal/quicklog"
+
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
-// WinModel is the model for the 10-entry win prompt
+// WinModel is the model for the win prompt (shown at the 10th entry by default)
 type WinModel struct {
-	input     textinput.Model
-	submitted bool
-	win       string
+	input      textinput.Model
+	submitted  bool
+	win        string
+	entryCount int
+	saver      ritualSaver
 }
 
 // NewWinModel creates a new win model
@@ -22,11 +28,24 @@ func NewWinModel() WinModel {
 	ti.Width = 70
 
 	return WinModel{
-		input:     ti,
-		submitted: false,
+		input:      ti,
+		submitted:  false,
+		entryCount: 10,
 	}
 }
 
+// WithEntryCount sets the entry count shown in the celebration (win.prompt_entries)
+func (m WinModel) WithEntryCount(n int) WinModel {
+	m.entryCount = n
+	return m
+}
+
+// WithLogger saves the win for day through logger on submit
+func (m WinModel) WithLogger(logger *quicklog.Logger, day *database.Day) WinModel {
+	m.saver = ritualSaver{logger: logger, day: day}
+	return m
+}
+
 // Init initializes the model
 func (m WinModel) Init() tea.Cmd {
 	return textinput.Blink
@@ -41,6 +60,11 @@ func (m WinModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Submit win (can be empty to skip)
 			m.submitted = true
 			m.win = strings.TrimSpace(m.input.Value())
+			if m.win != "" {
+				m.saver.save(func(l *quicklog.Logger, day *database.Day) error {
+					return l.RecordWin(day, m.win)
+				})
+			}
 			return m, tea.Quit
 		case tea.KeyCtrlC, tea.KeyEsc:
 			// Cancel without setting win
@@ -64,7 +88,7 @@ func (m WinModel) View() string {
 	var b strings.Builder
 
 	// Celebration
-	b.WriteString(HeaderStyle.Render("You've logged 10 entries today! 💪"))
+	b.WriteString(HeaderStyle.Render(fmt.Sprintf("You've logged %d entries today! 💪", m.entryCount)))
 	b.WriteString("\n\n")
 
 	// Prompt
@@ -90,3 +114,8 @@ func (m WinModel) GetWin() string {
 func (m WinModel) WasSubmitted() bool {
 	return m.submitted
 }
+
+// Err returns the error saving the win through the logger, if any
+func (m WinModel) Err() error {
+	return m.saver.err
+}
@@ -0,0 +1,251 @@
+package vault
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/aaryareddy/log_cli/internal/config"
+	"github.com/aaryareddy/log_cli/internal/database"
+	"github.com/aaryareddy/log_cli/internal/markdown"
+	"github.com/charmbracelet/x/term"
+)
+
+// MinPassphraseLen is the shortest passphrase `log encrypt` accepts
+const MinPassphraseLen = 8
+
+// Prompt asks for a passphrase without echoing it
+type Prompt func(label string) (string, error)
+
+// TerminalPrompt reads passphrases from the terminal on in
+func TerminalPrompt(in *os.File, out io.Writer) Prompt {
+	return func(label string) (string, error) {
+		if !term.IsTerminal(in.Fd()) {
+			return "", errors.New("a passphrase is needed but stdin is not a terminal (run `log unlock` first)")
+		}
+		fmt.Fprint(out, label)
+		passphrase, err := term.ReadPassword(in.Fd())
+		fmt.Fprintln(out)
+		if err != nil {
+			return "", fmt.Errorf("failed to read passphrase: %w", err)
+		}
+		return string(passphrase), nil
+	}
+}
+
+// Attach prepares an encrypted log for use by this process: it finds the key
+// (a live unlock session, else prompt) and attaches it to the store and the
+// markdown writer. It returns nil when the log isn't encrypted. A nil prompt
+// makes a locked log fail with database.ErrLocked, as `log -m` from cron should.
+func Attach(c *config.Config, store *database.Store, writer *markdown.Writer, prompt Prompt) (*Key, error) {
+	encrypted, err := store.Encrypted()
+	if err != nil || !encrypted {
+		return nil, err
+	}
+
+	key, err := resolveKey(c, store, prompt)
+	if err != nil {
+		return nil, err
+	}
+	store.WithCipher(key)
+	if writer != nil {
+		writer.WithCipher(key)
+	}
+	return key, nil
+}
+
+// resolveKey returns the key from the unlock session, or asks for the
+// passphrase and starts a new session
+func resolveKey(c *config.Config, store *database.Store, prompt Prompt) (*Key, error) {
+	sessionPath := c.Paths().Session
+
+	key, err := LoadSession(sessionPath, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if key != nil {
+		if Verify(store, key) == nil {
+			return key, nil
+		}
+		// Left over from before a re-encrypt
+		if err := ClearSession(sessionPath); err != nil {
+			return nil, err
+		}
+	}
+
+	if prompt == nil {
+		return nil, database.ErrLocked
+	}
+	passphrase, err := prompt("Passphrase: ")
+	if err != nil {
+		return nil, err
+	}
+	if key, err = Unlock(store, passphrase); err != nil {
+		return nil, err
+	}
+	if err := SaveSession(sessionPath, key, c.UnlockTimeout(), time.Now()); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// RunCommand handles the encryption commands:
+//
+//	encrypt           choose a passphrase and encrypt the database and markdown in place
+//	decrypt           decrypt everything in place and turn encryption off
+//	unlock [--for d]  cache the key for encryption.unlock_minutes (or d, e.g. 2h)
+//	lock              forget the cached key now
+//	cat [date]        print a day's markdown, decrypted (default today)
+func RunCommand(c *config.Config, store *database.Store, prompt Prompt, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: log encrypt | decrypt | unlock | lock | cat [date]")
+	}
+
+	switch args[0] {
+	case "encrypt":
+		return runEncrypt(c, store, prompt, out)
+
+	case "decrypt":
+		return runDecrypt(c, store, prompt, out)
+
+	case "unlock":
+		ttl := c.UnlockTimeout()
+		switch {
+		case len(args) == 3 && args[1] == "--for":
+			d, err := time.ParseDuration(args[2])
+			if err != nil || d <= 0 {
+				return fmt.Errorf("invalid --for duration: %q (e.g. 30m, 2h)", args[2])
+			}
+			ttl = d
+		case len(args) != 1:
+			return fmt.Errorf("usage: log unlock [--for 30m]")
+		}
+		if ttl <= 0 {
+			return fmt.Errorf("%s is 0, so nothing is cached: each command asks for the passphrase", config.KeyUnlockMinutes)
+		}
+		passphrase, err := prompt("Passphrase: ")
+		if err != nil {
+			return err
+		}
+		key, err := Unlock(store, passphrase)
+		if err != nil {
+			return err
+		}
+		if err := SaveSession(c.Paths().Session, key, ttl, time.Now()); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Unlocked until %s\n", time.Now().Add(ttl).Format("3:04pm"))
+		return nil
+
+	case "lock":
+		if err := ClearSession(c.Paths().Session); err != nil {
+			return err
+		}
+		fmt.Fprintln(out, "Locked")
+		return nil
+
+	case "cat":
+		day := time.Now().Format("2006-01-02")
+		if len(args) > 1 {
+			day = args[1]
+		}
+		key, err := Attach(c, store, nil, prompt)
+		if err != nil {
+			return err
+		}
+		data, err := Cat(c.MarkdownDir(), day, key)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		return err
+	}
+
+	return fmt.Errorf("unknown command: %q (use encrypt, decrypt, unlock, lock or cat)", args[0])
+}
+
+// runEncrypt sets up encryption and converts existing data. Run again on an
+// encrypted log, it finishes converting anything left in plaintext.
+func runEncrypt(c *config.Config, store *database.Store, prompt Prompt, out io.Writer) error {
+	encrypted, err := store.Encrypted()
+	if err != nil {
+		return err
+	}
+
+	var key *Key
+	if encrypted {
+		if key, err = resolveKey(c, store, prompt); err != nil {
+			return err
+		}
+	} else {
+		passphrase, err := prompt("New passphrase: ")
+		if err != nil {
+			return err
+		}
+		if len(passphrase) < MinPassphraseLen {
+			return fmt.Errorf("passphrase must be at least %d characters", MinPassphraseLen)
+		}
+		confirm, err := prompt("Repeat passphrase: ")
+		if err != nil {
+			return err
+		}
+		if confirm != passphrase {
+			return errors.New("passphrases don't match")
+		}
+		if key, err = Setup(store, passphrase); err != nil {
+			return err
+		}
+	}
+
+	fields, err := store.Reseal(key, key)
+	if err != nil {
+		return err
+	}
+	files, err := EncryptMarkdown(c.MarkdownDir(), key)
+	if err != nil {
+		return err
+	}
+	if err := SaveSession(c.Paths().Session, key, c.UnlockTimeout(), time.Now()); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Encrypted %d fields and %d markdown files\n", fields, files)
+	fmt.Fprintln(out, "Keep the passphrase safe: there is no way to recover the log without it")
+	return nil
+}
+
+// runDecrypt converts everything back to plaintext and turns encryption off
+func runDecrypt(c *config.Config, store *database.Store, prompt Prompt, out io.Writer) error {
+	encrypted, err := store.Encrypted()
+	if err != nil {
+		return err
+	}
+	if !encrypted {
+		return errors.New("the log is not encrypted")
+	}
+
+	key, err := resolveKey(c, store, prompt)
+	if err != nil {
+		return err
+	}
+
+	fields, err := store.Reseal(key, nil)
+	if err != nil {
+		return err
+	}
+	files, err := DecryptMarkdown(c.MarkdownDir(), key)
+	if err != nil {
+		return err
+	}
+	if err := Disable(store); err != nil {
+		return err
+	}
+	if err := ClearSession(c.Paths().Session); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Decrypted %d fields and %d markdown files\n", fields, files)
+	return nil
+}
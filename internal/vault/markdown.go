@@ -0,0 +1,104 @@
+package vault
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aaryareddy/log_cli/internal/markdown"
+)
+
+// EncryptMarkdown replaces each YYYY-MM-DD.md in dir with YYYY-MM-DD.md.enc.
+// Subdirectories (other profiles' logs) are left alone.
+func EncryptMarkdown(dir string, key *Key) (int, error) {
+	return convertDir(dir, ".md", func(name string, data []byte) (string, []byte, os.FileMode, error) {
+		sealed, err := key.SealFile(data)
+		return name + markdown.EncryptedExt, sealed, 0600, err
+	})
+}
+
+// DecryptMarkdown replaces each YYYY-MM-DD.md.enc in dir with YYYY-MM-DD.md
+func DecryptMarkdown(dir string, key *Key) (int, error) {
+	return convertDir(dir, ".md"+markdown.EncryptedExt, func(name string, data []byte) (string, []byte, os.FileMode, error) {
+		plaintext, err := key.OpenFile(data)
+		return strings.TrimSuffix(name, markdown.EncryptedExt), plaintext, 0644, err
+	})
+}
+
+// convertDir rewrites every file with the suffix through convert. The new file
+// is complete on disk before the old one is removed, and an existing target
+// is never overwritten.
+func convertDir(dir, suffix string, convert func(name string, data []byte) (string, []byte, os.FileMode, error)) (int, error) {
+	files, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read markdown directory: %w", err)
+	}
+
+	count := 0
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), suffix) {
+			continue
+		}
+		from := filepath.Join(dir, f.Name())
+		data, err := os.ReadFile(from)
+		if err != nil {
+			return count, fmt.Errorf("failed to read %s: %w", from, err)
+		}
+
+		name, converted, perm, err := convert(f.Name(), data)
+		if err != nil {
+			return count, fmt.Errorf("%s: %w", from, err)
+		}
+		to := filepath.Join(dir, name)
+		if _, err := os.Stat(to); err == nil {
+			return count, fmt.Errorf("%s already exists: move it aside and run again", to)
+		}
+
+		tmp := to + ".tmp"
+		if err := os.WriteFile(tmp, converted, perm); err != nil {
+			return count, fmt.Errorf("failed to write %s: %w", to, err)
+		}
+		if err := os.Rename(tmp, to); err != nil {
+			os.Remove(tmp)
+			return count, fmt.Errorf("failed to write %s: %w", to, err)
+		}
+		if err := os.Remove(from); err != nil {
+			return count, fmt.Errorf("failed to remove %s: %w", from, err)
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// Cat returns a day's markdown, decrypting it if needed. day is a date
+// (YYYY-MM-DD) looked up in dir, or a path to a file.
+func Cat(dir, day string, key *Key) ([]byte, error) {
+	path := day
+	if !strings.ContainsRune(day, filepath.Separator) {
+		path = filepath.Join(dir, day+".md"+markdown.EncryptedExt)
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			path = filepath.Join(dir, day+".md")
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no markdown log for %s", day)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if !IsSealedFile(data) {
+		return data, nil
+	}
+	if key == nil {
+		return nil, fmt.Errorf("%s is encrypted and no key is available", path)
+	}
+	return key.OpenFile(data)
+}
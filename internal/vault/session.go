@@ -0,0 +1,75 @@
+package vault
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// session is the unlock session file: the derived key and when it expires.
+// It lives in $XDG_RUNTIME_DIR, which is private and cleared on logout.
+type session struct {
+	Key       string `json:"key"`
+	ExpiresAt int64  `json:"expires_at"` // Unix seconds
+}
+
+// SaveSession caches a key until now+ttl. A ttl of zero caches nothing.
+func SaveSession(path string, key *Key, ttl time.Duration, now time.Time) error {
+	if ttl <= 0 {
+		return ClearSession(path)
+	}
+
+	data, err := json.Marshal(session{
+		Key:       base64.StdEncoding.EncodeToString(key.raw),
+		ExpiresAt: now.Add(ttl).Unix(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode session: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create session directory: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write session: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write session: %w", err)
+	}
+	return nil
+}
+
+// LoadSession returns the cached key, or nil when there is no live session.
+// Expired sessions are removed.
+func LoadSession(path string, now time.Time) (*Key, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session: %w", err)
+	}
+
+	var s session
+	if err := json.Unmarshal(data, &s); err != nil || now.Unix() >= s.ExpiresAt {
+		return nil, ClearSession(path)
+	}
+	raw, err := base64.StdEncoding.DecodeString(s.Key)
+	if err != nil {
+		return nil, ClearSession(path)
+	}
+	return newKey(raw)
+}
+
+// ClearSession ends an unlock session (no-op if there is none)
+func ClearSession(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove session: %w", err)
+	}
+	return nil
+}
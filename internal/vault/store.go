@@ -0,0 +1,96 @@
+package vault
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aaryareddy/log_cli/internal/database"
+)
+
+// ConfigCheck is the config key holding a sealed known value. Opening it
+// proves a passphrase is right before anything is decrypted or written.
+const ConfigCheck = "encryption.check"
+
+// checkValue is the plaintext sealed under ConfigCheck
+const checkValue = "daylog"
+
+// Setup enables encryption on a store with a new passphrase. Existing data
+// is left as it is; Store.Reseal converts it.
+func Setup(store *database.Store, passphrase string) (*Key, error) {
+	encrypted, err := store.Encrypted()
+	if err != nil {
+		return nil, err
+	}
+	if encrypted {
+		return nil, errors.New("the log is already encrypted")
+	}
+
+	params, err := NewParams()
+	if err != nil {
+		return nil, err
+	}
+	key, err := DeriveKey(passphrase, params)
+	if err != nil {
+		return nil, err
+	}
+	check, err := key.Seal(checkValue)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := store.SetConfig(ConfigCheck, check); err != nil {
+		return nil, err
+	}
+	if err := store.SetConfig(database.ConfigEncryptionKDF, params.String()); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// Unlock derives the store's key from a passphrase and verifies it
+func Unlock(store *database.Store, passphrase string) (*Key, error) {
+	encoded, ok, err := store.GetConfig(database.ConfigEncryptionKDF)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("the log is not encrypted (run `log encrypt` first)")
+	}
+	params, err := ParseParams(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := DeriveKey(passphrase, params)
+	if err != nil {
+		return nil, err
+	}
+	if err := Verify(store, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Verify checks that key belongs to the store
+func Verify(store *database.Store, key *Key) error {
+	check, ok, err := store.GetConfig(ConfigCheck)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("missing %s in the config table", ConfigCheck)
+	}
+	if value, err := key.Open(check); err != nil || value != checkValue {
+		return ErrWrongPassphrase
+	}
+	return nil
+}
+
+// Disable removes the encryption settings once everything is decrypted
+func Disable(store *database.Store) error {
+	if err := store.DeleteConfig(database.ConfigEncryptionKDF); err != nil {
+		return err
+	}
+	return store.DeleteConfig(ConfigCheck)
+}
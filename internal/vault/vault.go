@@ -0,0 +1,188 @@
+// Package vault encrypts the log at rest. Sensitive database columns and whole
+// markdown files are sealed with AES-256-GCM under a key derived from a
+// passphrase with Argon2id. The key-derivation parameters and a passphrase
+// check live in the config table, so the database carries everything needed
+// to unlock it except the passphrase.
+package vault
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aaryareddy/log_cli/internal/database"
+	"golang.org/x/crypto/argon2"
+)
+
+// KeySize is the AES-256 key length in bytes
+const KeySize = 32
+
+// fileMagic opens every encrypted markdown file
+var fileMagic = []byte("DAYLOG-ENC-1\n")
+
+// ErrWrongPassphrase is returned when a passphrase doesn't match the store's key
+var ErrWrongPassphrase = errors.New("wrong passphrase")
+
+// Params are the Argon2id settings and salt for deriving a key
+type Params struct {
+	Time    uint32 // Passes over memory
+	Memory  uint32 // KiB
+	Threads uint8
+	Salt    []byte
+}
+
+// NewParams returns the default cost with a fresh random salt
+func NewParams() (Params, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return Params{}, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	return Params{Time: 3, Memory: 64 * 1024, Threads: 4, Salt: salt}, nil
+}
+
+// String encodes params as "argon2id$t=3$m=65536$p=4$<base64 salt>"
+func (p Params) String() string {
+	return fmt.Sprintf("argon2id$t=%d$m=%d$p=%d$%s",
+		p.Time, p.Memory, p.Threads, base64.RawStdEncoding.EncodeToString(p.Salt))
+}
+
+// ParseParams decodes params written by Params.String
+func ParseParams(s string) (Params, error) {
+	parts := strings.Split(s, "$")
+	if len(parts) != 5 || parts[0] != "argon2id" {
+		return Params{}, fmt.Errorf("unsupported key derivation: %q", s)
+	}
+
+	var p Params
+	for i, field := range []struct {
+		prefix string
+		bits   int
+		set    func(uint64)
+	}{
+		{"t=", 32, func(n uint64) { p.Time = uint32(n) }},
+		{"m=", 32, func(n uint64) { p.Memory = uint32(n) }},
+		{"p=", 8, func(n uint64) { p.Threads = uint8(n) }},
+	} {
+		value, ok := strings.CutPrefix(parts[i+1], field.prefix)
+		if !ok {
+			return Params{}, fmt.Errorf("invalid key derivation parameters: %q", s)
+		}
+		n, err := strconv.ParseUint(value, 10, field.bits)
+		if err != nil || n == 0 {
+			return Params{}, fmt.Errorf("invalid key derivation parameters: %q", s)
+		}
+		field.set(n)
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil || len(salt) < 8 {
+		return Params{}, fmt.Errorf("invalid key derivation salt: %q", s)
+	}
+	p.Salt = salt
+
+	return p, nil
+}
+
+// Key seals and opens database fields and markdown files. It implements
+// database.FieldCipher and markdown.FileCipher.
+type Key struct {
+	raw  []byte
+	aead cipher.AEAD
+}
+
+// DeriveKey stretches a passphrase into a key
+func DeriveKey(passphrase string, p Params) (*Key, error) {
+	return newKey(argon2.IDKey([]byte(passphrase), p.Salt, p.Time, p.Memory, p.Threads, KeySize))
+}
+
+// newKey wraps raw key bytes
+func newKey(raw []byte) (*Key, error) {
+	if len(raw) != KeySize {
+		return nil, fmt.Errorf("invalid key length: %d", len(raw))
+	}
+	block, err := aes.NewCipher(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return &Key{raw: raw, aead: aead}, nil
+}
+
+// seal encrypts with a random nonce, returning nonce || ciphertext
+func (k *Key) seal(plaintext, additional []byte) ([]byte, error) {
+	nonce := make([]byte, k.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return k.aead.Seal(nonce, nonce, plaintext, additional), nil
+}
+
+// open reverses seal
+func (k *Key) open(sealed, additional []byte) ([]byte, error) {
+	if len(sealed) < k.aead.NonceSize() {
+		return nil, errors.New("ciphertext is truncated")
+	}
+	nonce, ciphertext := sealed[:k.aead.NonceSize()], sealed[k.aead.NonceSize():]
+	plaintext, err := k.aead.Open(nil, nonce, ciphertext, additional)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	return plaintext, nil
+}
+
+// Seal encrypts a database field as database.SealedPrefix + base64
+func (k *Key) Seal(plaintext string) (string, error) {
+	sealed, err := k.seal([]byte(plaintext), nil)
+	if err != nil {
+		return "", err
+	}
+	return database.SealedPrefix + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Open decrypts a database field written by Seal
+func (k *Key) Open(value string) (string, error) {
+	encoded, ok := strings.CutPrefix(value, database.SealedPrefix)
+	if !ok {
+		return "", errors.New("value is not encrypted")
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("invalid encrypted value: %w", err)
+	}
+	plaintext, err := k.open(sealed, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// SealFile encrypts a markdown file's contents
+func (k *Key) SealFile(plaintext []byte) ([]byte, error) {
+	sealed, err := k.seal(plaintext, fileMagic)
+	if err != nil {
+		return nil, err
+	}
+	return append(append([]byte{}, fileMagic...), sealed...), nil
+}
+
+// OpenFile decrypts a file written by SealFile
+func (k *Key) OpenFile(data []byte) ([]byte, error) {
+	if !IsSealedFile(data) {
+		return nil, errors.New("file is not encrypted by daylog")
+	}
+	return k.open(data[len(fileMagic):], fileMagic)
+}
+
+// IsSealedFile reports whether data was written by SealFile
+func IsSealedFile(data []byte) bool {
+	return bytes.HasPrefix(data, fileMagic)
+}
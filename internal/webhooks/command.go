@@ -0,0 +1,248 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aaryareddy/log_cli/internal/database"
+)
+
+// recentDeliveries is how many outbox rows `log webhooks status` lists
+const recentDeliveries = 10
+
+// TargetStatus summarizes the outbox for one target
+type TargetStatus struct {
+	Target    database.WebhookTarget `json:"target"`
+	Pending   int                    `json:"pending"`
+	Delivered int                    `json:"delivered"`
+	Failed    int                    `json:"failed"`
+}
+
+// StatusReport is the result of `log webhooks status`
+type StatusReport struct {
+	Targets []TargetStatus              `json:"targets"`
+	Recent  []*database.WebhookDelivery `json:"recent"`
+}
+
+// Status summarizes targets and the most recent deliveries
+func (d *Dispatcher) Status(recent int) (*StatusReport, error) {
+	targets, err := d.store.GetWebhookTargets()
+	if err != nil {
+		return nil, err
+	}
+	counts, err := d.store.CountWebhooksByStatus()
+	if err != nil {
+		return nil, err
+	}
+	deliveries, err := d.store.GetRecentWebhooks(recent)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &StatusReport{Targets: []TargetStatus{}, Recent: deliveries}
+	for _, t := range targets {
+		c := counts[t.ID]
+		report.Targets = append(report.Targets, TargetStatus{
+			Target:    t,
+			Pending:   c[database.DeliveryPending],
+			Delivered: c[database.DeliveryDelivered],
+			Failed:    c[database.DeliveryFailed],
+		})
+	}
+	if report.Recent == nil {
+		report.Recent = []*database.WebhookDelivery{}
+	}
+	return report, nil
+}
+
+// FormatStatus renders a status report
+func FormatStatus(r *StatusReport) string {
+	var b strings.Builder
+
+	b.WriteString("WEBHOOKS\n")
+	b.WriteString(strings.Repeat("━", 70))
+	b.WriteString("\n\n")
+
+	if len(r.Targets) == 0 {
+		b.WriteString("  No webhook targets (add one with `log webhooks add <url>`)\n")
+		return b.String()
+	}
+
+	for _, t := range r.Targets {
+		events := t.Target.Events
+		if events == "" {
+			events = "all events"
+		}
+		b.WriteString(fmt.Sprintf("#%d  %s  (%s)\n", t.Target.ID, t.Target.URL, events))
+		b.WriteString(fmt.Sprintf("     %d delivered, %d pending, %d failed\n", t.Delivered, t.Pending, t.Failed))
+	}
+
+	if len(r.Recent) > 0 {
+		b.WriteString("\nRECENT DELIVERIES\n")
+		for _, d := range r.Recent {
+			line := fmt.Sprintf("  %-5d %-15s → #%-3d %-9s %d attempt(s)", d.ID, d.Event, d.TargetID, d.Status, d.Attempts)
+			switch database.DeliveryStatus(d.Status) {
+			case database.DeliveryPending:
+				if d.Attempts > 0 {
+					line += fmt.Sprintf(", retry at %s", d.NextAttemptAt.Format("Jan 2 3:04pm"))
+				}
+			case database.DeliveryDelivered:
+				if d.DeliveredAt != nil {
+					line += fmt.Sprintf(", %s", d.DeliveredAt.Format("Jan 2 3:04pm"))
+				}
+			}
+			if d.LastError != nil && d.Status != string(database.DeliveryDelivered) {
+				line += "\n        " + *d.LastError
+			}
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+	}
+
+	return b.String()
+}
+
+// RunCommand handles `log webhooks <subcommand>`:
+//
+//	status                            targets, outbox counts and recent deliveries
+//	replay [id...]                    requeue deliveries (default: every failed one) and deliver now
+//	add <url> [--events a,b] [--secret s]
+//	remove <id>
+func RunCommand(d *Dispatcher, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		args = []string{"status"}
+	}
+
+	switch args[0] {
+	case "status":
+		report, err := d.Status(recentDeliveries)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(out, FormatStatus(report))
+		return nil
+
+	case "replay":
+		ids, err := parseIDs(args[1:])
+		if err != nil {
+			return err
+		}
+		n, err := d.Replay(ids)
+		if err != nil {
+			return err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		delivered, failed, err := d.Deliver(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "requeued %d, delivered %d, failed %d\n", n, delivered, failed)
+		return nil
+
+	case "add":
+		target, secretGenerated, err := parseTarget(args[1:])
+		if err != nil {
+			return err
+		}
+		if err := d.store.InsertWebhookTarget(target); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "added webhook #%d → %s\n", target.ID, target.URL)
+		if secretGenerated {
+			fmt.Fprintf(out, "signing secret: %s\n", target.Secret)
+		}
+		return nil
+
+	case "remove":
+		ids, err := parseIDs(args[1:])
+		if err != nil || len(ids) != 1 {
+			return fmt.Errorf("usage: log webhooks remove <id>")
+		}
+		if err := d.store.DeleteWebhookTarget(ids[0]); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "removed webhook #%d\n", ids[0])
+		return nil
+	}
+
+	return fmt.Errorf("unknown webhooks command: %q (use status, replay, add or remove)", args[0])
+}
+
+// parseTarget parses `add` arguments, generating a secret when none is given
+func parseTarget(args []string) (*database.WebhookTarget, bool, error) {
+	target := &database.WebhookTarget{}
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--events", "--secret":
+			if i+1 >= len(args) {
+				return nil, false, fmt.Errorf("%s requires a value", args[i])
+			}
+			if args[i] == "--events" {
+				target.Events = args[i+1]
+			} else {
+				target.Secret = args[i+1]
+			}
+			i++
+		default:
+			if target.URL != "" {
+				return nil, false, fmt.Errorf("unexpected argument: %q", args[i])
+			}
+			target.URL = args[i]
+		}
+	}
+
+	if target.URL == "" {
+		return nil, false, fmt.Errorf("usage: log webhooks add <url> [--events a,b] [--secret s]")
+	}
+	if u, err := url.Parse(target.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, false, fmt.Errorf("invalid webhook url: %q", target.URL)
+	}
+	for _, e := range strings.Split(target.Events, ",") {
+		if e = strings.TrimSpace(e); e != "" && !knownEvent(e) {
+			return nil, false, fmt.Errorf("unknown event %q (use %s)", e, strings.Join(Events, ", "))
+		}
+	}
+
+	generated := false
+	if target.Secret == "" {
+		buf := make([]byte, 32)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, false, fmt.Errorf("failed to generate secret: %w", err)
+		}
+		target.Secret = hex.EncodeToString(buf)
+		generated = true
+	}
+
+	return target, generated, nil
+}
+
+// parseIDs parses numeric ids
+func parseIDs(args []string) ([]int, error) {
+	ids := make([]int, 0, len(args))
+	for _, arg := range args {
+		id, err := strconv.Atoi(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid id: %q", arg)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// knownEvent reports whether e is a webhook event
+func knownEvent(e string) bool {
+	for _, known := range Events {
+		if e == known {
+			return true
+		}
+	}
+	return false
+}
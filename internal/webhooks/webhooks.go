@@ -0,0 +1,269 @@
+// Package webhooks pushes daylog events to HTTP endpoints such as a team
+// dashboard. Events are written to a durable outbox table first, so logging
+// never waits on the network; a dispatcher delivers them in the background,
+// signed with HMAC-SHA256, retrying with exponential backoff.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aaryareddy/log_cli/internal/database"
+)
+
+// Events delivered to webhook targets
+const (
+	EventEntryCreated  = "entry.created"  // An entry was logged
+	EventDayCompleted  = "day.completed"  // The day was signed off
+	EventWeeklySummary = "summary.weekly" // Last week's review is ready
+)
+
+// Events lists every event a target can subscribe to
+var Events = []string{EventEntryCreated, EventDayCompleted, EventWeeklySummary}
+
+// Delivery headers
+const (
+	HeaderEvent     = "X-Daylog-Event"
+	HeaderDelivery  = "X-Daylog-Delivery"  // Outbox row id, stable across retries
+	HeaderTimestamp = "X-Daylog-Timestamp" // Unix seconds, included in the signature
+	HeaderSignature = "X-Daylog-Signature" // "sha256=" + hex HMAC of "<timestamp>.<body>"
+)
+
+// Retry policy
+const (
+	MaxAttempts     = 8                // Attempts before a delivery is marked failed
+	BaseBackoff     = 30 * time.Second // Delay after the first failure, doubled each time
+	MaxBackoff      = 6 * time.Hour
+	RequestTimeout  = 10 * time.Second
+	DefaultInterval = 30 * time.Second // How often Run checks the outbox
+	batchSize       = 50
+)
+
+// ConfigLastWeeklySummary is the config key recording the last week announced
+const ConfigLastWeeklySummary = "webhooks.last_weekly_summary"
+
+// Envelope is the JSON body posted to targets
+type Envelope struct {
+	Event     string          `json:"event"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Dispatcher queues events in the outbox and delivers them
+type Dispatcher struct {
+	store  *database.Store
+	client *http.Client
+	now    func() time.Time
+	mu     sync.Mutex // One delivery pass at a time
+}
+
+// NewDispatcher creates a dispatcher backed by the store's outbox
+func NewDispatcher(store *database.Store) *Dispatcher {
+	return &Dispatcher{
+		store:  store,
+		client: &http.Client{Timeout: RequestTimeout},
+		now:    time.Now,
+	}
+}
+
+// Enqueue records an event for every subscribed target. It only writes to
+// SQLite, so it is safe to call from interactive flows. A nil dispatcher does nothing.
+func (d *Dispatcher) Enqueue(event string, data any) error {
+	if d == nil {
+		return nil
+	}
+
+	targets, err := d.store.GetWebhookTargets()
+	if err != nil || len(targets) == 0 {
+		return err
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook data: %w", err)
+	}
+	now := d.now()
+	body, err := json.Marshal(Envelope{Event: event, CreatedAt: now, Data: raw})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	for _, target := range targets {
+		if !Subscribed(target, event) {
+			continue
+		}
+		if err := d.store.EnqueueWebhook(target.ID, event, string(body), now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EnqueueWeeklySummary announces a week's review once; later calls for the same
+// week label are ignored
+func (d *Dispatcher) EnqueueWeeklySummary(label string, data any) error {
+	if d == nil {
+		return nil
+	}
+	last, _, err := d.store.GetConfig(ConfigLastWeeklySummary)
+	if err != nil || last == label {
+		return err
+	}
+	if err := d.Enqueue(EventWeeklySummary, data); err != nil {
+		return err
+	}
+	return d.store.SetConfig(ConfigLastWeeklySummary, label)
+}
+
+// Subscribed reports whether a target wants an event (an empty filter means all)
+func Subscribed(target database.WebhookTarget, event string) bool {
+	if strings.TrimSpace(target.Events) == "" {
+		return true
+	}
+	for _, e := range strings.Split(target.Events, ",") {
+		if strings.TrimSpace(e) == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Run delivers due events every interval until ctx is cancelled
+func (d *Dispatcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		_, _, _ = d.Deliver(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Deliver attempts every due delivery once and returns how many succeeded and failed
+func (d *Dispatcher) Deliver(ctx context.Context) (delivered, failed int, err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	targets, err := d.store.GetWebhookTargets()
+	if err != nil {
+		return 0, 0, err
+	}
+	byID := make(map[int]database.WebhookTarget, len(targets))
+	for _, t := range targets {
+		byID[t.ID] = t
+	}
+
+	due, err := d.store.GetDueWebhooks(d.now(), batchSize)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, delivery := range due {
+		if ctx.Err() != nil {
+			break
+		}
+
+		sendErr := d.send(ctx, byID[delivery.TargetID], delivery)
+		if sendErr == nil {
+			if err := d.store.MarkWebhookDelivered(delivery.ID, d.now()); err != nil {
+				return delivered, failed, err
+			}
+			delivered++
+			continue
+		}
+
+		attempt := delivery.Attempts + 1
+		giveUp := attempt >= MaxAttempts
+		next := d.now().Add(Backoff(attempt))
+		if err := d.store.RecordWebhookFailure(delivery.ID, sendErr.Error(), next, giveUp); err != nil {
+			return delivered, failed, err
+		}
+		failed++
+	}
+
+	return delivered, failed, nil
+}
+
+// send posts one delivery; any non-2xx response is an error
+func (d *Dispatcher) send(ctx context.Context, target database.WebhookTarget, delivery *database.WebhookDelivery) error {
+	if target.ID == 0 {
+		return fmt.Errorf("webhook target %d no longer exists", delivery.TargetID)
+	}
+
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(d.now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "daylog-webhooks")
+	req.Header.Set(HeaderEvent, delivery.Event)
+	req.Header.Set(HeaderDelivery, strconv.Itoa(delivery.ID))
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(target.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("target responded %s", resp.Status)
+	}
+	return nil
+}
+
+// Sign returns the signature header value for a body sent at timestamp.
+// Receivers recompute it with the shared secret and compare in constant time.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a signature header against the body and timestamp
+func Verify(secret, timestamp string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// Backoff returns the delay before retrying after the given failed attempt (1-based):
+// 30s, 1m, 2m, 4m, ... capped at MaxBackoff
+func Backoff(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	delay := BaseBackoff
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= MaxBackoff {
+			return MaxBackoff
+		}
+	}
+	return delay
+}
+
+// Replay requeues deliveries by outbox id (or every failed delivery when ids is empty)
+func (d *Dispatcher) Replay(ids []int) (int, error) {
+	return d.store.RequeueWebhooks(ids, d.now())
+}
@@ -0,0 +1,399 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aaryareddy/log_cli/internal/database"
+)
+
+// receiver is a local stand-in for a webhook target
+type receiver struct {
+	*httptest.Server
+	mu       sync.Mutex
+	status   int
+	requests []received
+}
+
+// received is one request the receiver saw
+type received struct {
+	header http.Header
+	body   []byte
+}
+
+func newReceiver(t *testing.T, status int) *receiver {
+	t.Helper()
+
+	r := &receiver{status: status}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.requests = append(r.requests, received{header: req.Header.Clone(), body: body})
+		w.WriteHeader(r.status)
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+func (r *receiver) setStatus(status int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.status = status
+}
+
+func (r *receiver) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.requests)
+}
+
+// newTestDispatcher returns a dispatcher over a temporary store with its
+// clock stopped at start; advance it through the returned pointer
+func newTestDispatcher(t *testing.T, start time.Time) (*Dispatcher, *database.Store, *time.Time) {
+	t.Helper()
+
+	store, err := database.NewStore(filepath.Join(t.TempDir(), "daylog.db"))
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	clock := start
+	d := NewDispatcher(store)
+	d.now = func() time.Time { return clock }
+	return d, store, &clock
+}
+
+func addTarget(t *testing.T, store *database.Store, url, secret, events string) database.WebhookTarget {
+	t.Helper()
+
+	target := database.WebhookTarget{URL: url, Secret: secret, Events: events}
+	if err := store.InsertWebhookTarget(&target); err != nil {
+		t.Fatalf("InsertWebhookTarget: %v", err)
+	}
+	return target
+}
+
+// onlyDelivery returns the single outbox row
+func onlyDelivery(t *testing.T, store *database.Store) *database.WebhookDelivery {
+	t.Helper()
+
+	deliveries, err := store.GetRecentWebhooks(10)
+	if err != nil {
+		t.Fatalf("GetRecentWebhooks: %v", err)
+	}
+	if len(deliveries) != 1 {
+		t.Fatalf("outbox has %d rows, want 1", len(deliveries))
+	}
+	return deliveries[0]
+}
+
+func deliver(t *testing.T, d *Dispatcher) (delivered, failed int) {
+	t.Helper()
+
+	delivered, failed, err := d.Deliver(context.Background())
+	if err != nil {
+		t.Fatalf("Deliver: %v", err)
+	}
+	return delivered, failed
+}
+
+var start = time.Date(2025, 10, 15, 9, 30, 0, 0, time.UTC)
+
+func TestSignAndVerify(t *testing.T) {
+	body := []byte(`{"event":"entry.created"}`)
+	signature := Sign("s3cret", "1760520600", body)
+
+	if signature != Sign("s3cret", "1760520600", body) {
+		t.Error("Sign is not deterministic")
+	}
+	if len(signature) != len("sha256=")+64 || signature[:7] != "sha256=" {
+		t.Errorf("signature = %q, want sha256= and 64 hex digits", signature)
+	}
+	if !Verify("s3cret", "1760520600", body, signature) {
+		t.Error("Verify rejected a valid signature")
+	}
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		body      []byte
+	}{
+		{"wrong secret", "other", "1760520600", body},
+		{"replayed at another time", "s3cret", "1760520601", body},
+		{"tampered body", "s3cret", "1760520600", []byte(`{"event":"day.completed"}`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Verify(tt.secret, tt.timestamp, tt.body, signature) {
+				t.Error("Verify accepted a mismatched signature")
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{0, BaseBackoff},
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{8, 64 * time.Minute},
+		{20, MaxBackoff},
+	}
+	for _, tt := range tests {
+		if got := Backoff(tt.attempt); got != tt.want {
+			t.Errorf("Backoff(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestSubscribed(t *testing.T) {
+	tests := []struct {
+		events string
+		event  string
+		want   bool
+	}{
+		{"", EventEntryCreated, true},
+		{"  ", EventDayCompleted, true},
+		{EventEntryCreated, EventEntryCreated, true},
+		{EventEntryCreated, EventDayCompleted, false},
+		{"day.completed, summary.weekly", EventWeeklySummary, true},
+		{"day.completed, summary.weekly", EventEntryCreated, false},
+		{"entry", EventEntryCreated, false},
+	}
+	for _, tt := range tests {
+		target := database.WebhookTarget{Events: tt.events}
+		if got := Subscribed(target, tt.event); got != tt.want {
+			t.Errorf("Subscribed(%q, %q) = %v, want %v", tt.events, tt.event, got, tt.want)
+		}
+	}
+}
+
+func TestEnqueueOnlyForSubscribedTargets(t *testing.T) {
+	d, store, _ := newTestDispatcher(t, start)
+	all := addTarget(t, store, "http://127.0.0.1:1/all", "a", "")
+	addTarget(t, store, "http://127.0.0.1:1/days", "b", EventDayCompleted)
+
+	if err := d.Enqueue(EventEntryCreated, map[string]string{"text": "Standup"}); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	delivery := onlyDelivery(t, store)
+	if delivery.TargetID != all.ID || delivery.Event != EventEntryCreated {
+		t.Errorf("queued %s for target %d, want %s for target %d", delivery.Event, delivery.TargetID, EventEntryCreated, all.ID)
+	}
+}
+
+func TestEnqueueWeeklySummaryOncePerWeek(t *testing.T) {
+	d, store, _ := newTestDispatcher(t, start)
+	addTarget(t, store, "http://127.0.0.1:1/weekly", "a", EventWeeklySummary)
+
+	for _, label := range []string{"2025-W41", "2025-W41", "2025-W42"} {
+		if err := d.EnqueueWeeklySummary(label, map[string]string{"label": label}); err != nil {
+			t.Fatalf("EnqueueWeeklySummary(%s): %v", label, err)
+		}
+	}
+
+	deliveries, err := store.GetRecentWebhooks(10)
+	if err != nil {
+		t.Fatalf("GetRecentWebhooks: %v", err)
+	}
+	if len(deliveries) != 2 {
+		t.Fatalf("outbox has %d rows, want one per week (2)", len(deliveries))
+	}
+	for _, delivery := range deliveries {
+		if delivery.Event != EventWeeklySummary {
+			t.Errorf("queued %s, want %s", delivery.Event, EventWeeklySummary)
+		}
+	}
+	if last, _, _ := store.GetConfig(ConfigLastWeeklySummary); last != "2025-W42" {
+		t.Errorf("%s = %q, want 2025-W42", ConfigLastWeeklySummary, last)
+	}
+}
+
+func TestDeliverDayCompletedToSubscribers(t *testing.T) {
+	days := newReceiver(t, http.StatusOK)
+	entries := newReceiver(t, http.StatusOK)
+	d, store, _ := newTestDispatcher(t, start)
+	addTarget(t, store, days.URL, "a", EventDayCompleted)
+	addTarget(t, store, entries.URL, "b", EventEntryCreated)
+
+	if err := d.Enqueue(EventDayCompleted, map[string]string{"date": "2025-10-15"}); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	if delivered, failed := deliver(t, d); delivered != 1 || failed != 0 {
+		t.Fatalf("Deliver = %d delivered, %d failed, want 1, 0", delivered, failed)
+	}
+
+	if days.count() != 1 || entries.count() != 0 {
+		t.Fatalf("day target got %d requests and entry target %d, want 1 and 0", days.count(), entries.count())
+	}
+	if got := days.requests[0].header.Get(HeaderEvent); got != EventDayCompleted {
+		t.Errorf("%s = %q, want %q", HeaderEvent, got, EventDayCompleted)
+	}
+}
+
+func TestDeliverSignsRequest(t *testing.T) {
+	r := newReceiver(t, http.StatusOK)
+	d, store, _ := newTestDispatcher(t, start)
+	addTarget(t, store, r.URL, "s3cret", "")
+
+	if err := d.Enqueue(EventEntryCreated, map[string]string{"text": "Standup"}); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	if delivered, failed := deliver(t, d); delivered != 1 || failed != 0 {
+		t.Fatalf("Deliver = %d delivered, %d failed, want 1, 0", delivered, failed)
+	}
+
+	req := r.requests[0]
+	delivery := onlyDelivery(t, store)
+	timestamp := strconv.FormatInt(start.Unix(), 10)
+	headers := map[string]string{
+		"Content-Type":  "application/json",
+		HeaderEvent:     EventEntryCreated,
+		HeaderDelivery:  strconv.Itoa(delivery.ID),
+		HeaderTimestamp: timestamp,
+	}
+	for name, want := range headers {
+		if got := req.header.Get(name); got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+	if !Verify("s3cret", timestamp, req.body, req.header.Get(HeaderSignature)) {
+		t.Errorf("%s does not verify against the body", HeaderSignature)
+	}
+
+	var envelope Envelope
+	if err := json.Unmarshal(req.body, &envelope); err != nil {
+		t.Fatalf("decode envelope: %v", err)
+	}
+	if envelope.Event != EventEntryCreated || !envelope.CreatedAt.Equal(start) || string(envelope.Data) != `{"text":"Standup"}` {
+		t.Errorf("envelope = %+v", envelope)
+	}
+
+	if delivery.Status != string(database.DeliveryDelivered) || delivery.Attempts != 1 {
+		t.Errorf("delivery = %s after %d attempts, want delivered after 1", delivery.Status, delivery.Attempts)
+	}
+	if delivered, failed := deliver(t, d); delivered+failed != 0 {
+		t.Errorf("delivered row was sent again")
+	}
+}
+
+func TestDeliverRetriesServerErrorsWithBackoff(t *testing.T) {
+	r := newReceiver(t, http.StatusServiceUnavailable)
+	d, store, clock := newTestDispatcher(t, start)
+	addTarget(t, store, r.URL, "s3cret", "")
+
+	if err := d.Enqueue(EventEntryCreated, "Standup"); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	for attempt := 1; attempt <= 3; attempt++ {
+		if delivered, failed := deliver(t, d); delivered != 0 || failed != 1 {
+			t.Fatalf("attempt %d: Deliver = %d delivered, %d failed, want 0, 1", attempt, delivered, failed)
+		}
+
+		delivery := onlyDelivery(t, store)
+		wantNext := clock.Add(Backoff(attempt))
+		if delivery.Status != string(database.DeliveryPending) || delivery.Attempts != attempt {
+			t.Errorf("attempt %d: delivery = %s after %d attempts", attempt, delivery.Status, delivery.Attempts)
+		}
+		if !delivery.NextAttemptAt.Equal(wantNext) {
+			t.Errorf("attempt %d: next attempt at %v, want %v", attempt, delivery.NextAttemptAt, wantNext)
+		}
+		if delivery.LastError == nil || *delivery.LastError != "target responded 503 Service Unavailable" {
+			t.Errorf("attempt %d: last error = %v", attempt, delivery.LastError)
+		}
+
+		// Nothing is due until the backoff has passed
+		*clock = wantNext.Add(-time.Second)
+		if delivered, failed := deliver(t, d); delivered+failed != 0 {
+			t.Errorf("attempt %d: retried before the backoff elapsed", attempt)
+		}
+		*clock = wantNext
+	}
+
+	if got := r.count(); got != 3 {
+		t.Errorf("receiver saw %d requests, want 3", got)
+	}
+}
+
+func TestDeliverGivesUpAtMaxAttempts(t *testing.T) {
+	r := newReceiver(t, http.StatusInternalServerError)
+	d, store, clock := newTestDispatcher(t, start)
+	addTarget(t, store, r.URL, "s3cret", "")
+
+	if err := d.Enqueue(EventDayCompleted, "2025-10-15"); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	for attempt := 1; attempt <= MaxAttempts; attempt++ {
+		if _, failed := deliver(t, d); failed != 1 {
+			t.Fatalf("attempt %d: %d failed, want 1", attempt, failed)
+		}
+		*clock = clock.Add(MaxBackoff)
+	}
+
+	delivery := onlyDelivery(t, store)
+	if delivery.Status != string(database.DeliveryFailed) || delivery.Attempts != MaxAttempts {
+		t.Errorf("delivery = %s after %d attempts, want failed after %d", delivery.Status, delivery.Attempts, MaxAttempts)
+	}
+
+	*clock = clock.Add(24 * time.Hour)
+	if delivered, failed := deliver(t, d); delivered+failed != 0 {
+		t.Error("failed delivery was retried")
+	}
+	if got := r.count(); got != MaxAttempts {
+		t.Errorf("receiver saw %d requests, want %d", got, MaxAttempts)
+	}
+}
+
+func TestReplayRequeuesFailedDeliveries(t *testing.T) {
+	r := newReceiver(t, http.StatusBadGateway)
+	d, store, clock := newTestDispatcher(t, start)
+	addTarget(t, store, r.URL, "s3cret", "")
+
+	if err := d.Enqueue(EventEntryCreated, "Standup"); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	for attempt := 1; attempt <= MaxAttempts; attempt++ {
+		deliver(t, d)
+		*clock = clock.Add(MaxBackoff)
+	}
+
+	n, err := d.Replay(nil)
+	if err != nil || n != 1 {
+		t.Fatalf("Replay(nil) = %d, %v, want 1", n, err)
+	}
+	delivery := onlyDelivery(t, store)
+	if delivery.Status != string(database.DeliveryPending) || delivery.Attempts != 0 || !delivery.NextAttemptAt.Equal(*clock) {
+		t.Errorf("replayed delivery = %s, %d attempts, due %v; want pending, 0, due now", delivery.Status, delivery.Attempts, delivery.NextAttemptAt)
+	}
+
+	r.setStatus(http.StatusNoContent)
+	if delivered, failed := deliver(t, d); delivered != 1 || failed != 0 {
+		t.Errorf("Deliver after replay = %d delivered, %d failed, want 1, 0", delivered, failed)
+	}
+
+	// Replaying by id works on delivered rows too
+	if n, err := d.Replay([]int{delivery.ID}); err != nil || n != 1 {
+		t.Errorf("Replay([%d]) = %d, %v, want 1", delivery.ID, n, err)
+	}
+	if n, err := d.Replay(nil); err != nil || n != 0 {
+		t.Errorf("Replay(nil) with nothing failed = %d, %v, want 0", n, err)
+	}
+}